@@ -0,0 +1,75 @@
+package testdemo
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// InterfaceFromSlice returns data as a sort.Interface, for code that
+// still takes one, such as sort.Sort or sort.IsSorted. It shares data,
+// so sorting it sorts data.
+func InterfaceFromSlice(data []int) sort.Interface {
+	return sort.IntSlice(data)
+}
+
+// SliceFromInterface returns the ints behind s, which is possible when s
+// is a sort.IntSlice, as InterfaceFromSlice returns; the slice is shared,
+// not copied. An arbitrary sort.Interface exposes only Len, Less and
+// Swap, so any other type returns an error.
+func SliceFromInterface(s sort.Interface) ([]int, error) {
+	if ints, ok := s.(sort.IntSlice); ok {
+		return ints, nil
+	}
+	return nil, fmt.Errorf("cannot extract ints from %T", s)
+}
+
+// SortedFunc is a slice known to be sorted in non-decreasing order of a
+// three-way comparator, which it keeps so that Search and Insert order
+// elements the same way it was checked. Like Sorted, it shares the slice
+// it was made from.
+type SortedFunc[T any] struct {
+	data    []T
+	compare func(a, b T) int
+}
+
+// AsSortedFromSortFunc returns data wrapped with compare, the comparator
+// it was sorted with by slices.SortFunc, or an *UnsortedError for the
+// first pair out of order when it is not sorted by compare.
+func AsSortedFromSortFunc[T any](data []T, compare func(a, b T) int) (SortedFunc[T], error) {
+	for i := 1; i < len(data); i++ {
+		if compare(data[i-1], data[i]) > 0 {
+			return SortedFunc[T]{}, &UnsortedError{Index: i, Prev: data[i-1], Next: data[i], Len: len(data)}
+		}
+	}
+	return SortedFunc[T]{data: data, compare: compare}, nil
+}
+
+// Slice returns the sorted slice, which must not be modified.
+func (s SortedFunc[T]) Slice() []T {
+	return s.data
+}
+
+// Len returns the number of elements.
+func (s SortedFunc[T]) Len() int {
+	return len(s.data)
+}
+
+// Search reports whether target is in s, along with the index of its
+// first occurrence when it is, and where it would be inserted when it is
+// not.
+func (s SortedFunc[T]) Search(target T) (int, bool) {
+	return SearchCompareFunc(s.data, target, s.compare)
+}
+
+// Insert returns s with v inserted after any elements equal to it. Like
+// append, it may reuse the slice s shares, so use the result in place of
+// s: s = s.Insert(v).
+func (s SortedFunc[T]) Insert(v T) SortedFunc[T] {
+	i, _ := SearchCompareFunc(s.data, v, s.compare)
+	for i < len(s.data) && s.compare(s.data[i], v) == 0 {
+		i++
+	}
+	s.data = slices.Insert(s.data, i, v)
+	return s
+}
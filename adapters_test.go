@@ -0,0 +1,81 @@
+package testdemo
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceRoundTrip(t *testing.T) {
+	data := []int{3, 1, 2}
+	s := InterfaceFromSlice(data)
+	sort.Sort(s)
+	require.Equal(t, []int{1, 2, 3}, data)
+	require.True(t, sort.IsSorted(s))
+
+	back, err := SliceFromInterface(s)
+	require.NoError(t, err)
+	require.Equal(t, data, back)
+	back[0] = 9
+	require.Equal(t, 9, data[0], "slice is not shared")
+
+	_, err = SliceFromInterface(sort.StringSlice{"a"})
+	require.EqualError(t, err, "cannot extract ints from sort.StringSlice")
+}
+
+// spyCompare counts the calls to cmp.Compare made through it.
+type spyCompare struct {
+	calls int
+}
+
+func (s *spyCompare) compare(a, b int) int {
+	s.calls++
+	return cmp.Compare(a, b)
+}
+
+func TestAsSortedFromSortFunc(t *testing.T) {
+	descending := func(a, b int) int { return cmp.Compare(b, a) }
+	data := []int{5, 1, 4, 2}
+	slices.SortFunc(data, descending)
+	s, err := AsSortedFromSortFunc(data, descending)
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 4, 2, 1}, s.Slice())
+	require.Equal(t, 4, s.Len())
+
+	// Search and Insert must keep ordering by descending, not ascending.
+	index, found := s.Search(2)
+	require.True(t, found)
+	require.Equal(t, 2, index)
+	index, found = s.Search(3)
+	require.False(t, found)
+	require.Equal(t, 2, index)
+	s = s.Insert(3)
+	require.Equal(t, []int{5, 4, 3, 2, 1}, s.Slice())
+	_, err = AsSortedFromSortFunc(s.Slice(), descending)
+	require.NoError(t, err)
+
+	_, err = AsSortedFromSortFunc([]int{1, 2}, descending)
+	var unsorted *UnsortedError
+	require.ErrorAs(t, err, &unsorted)
+	require.Equal(t, &UnsortedError{Index: 1, Prev: 1, Next: 2, Len: 2}, unsorted)
+}
+
+func TestSortedFuncUsesItsComparator(t *testing.T) {
+	spy := &spyCompare{}
+	s, err := AsSortedFromSortFunc([]int{1, 2, 2, 4}, spy.compare)
+	require.NoError(t, err)
+	require.Equal(t, 3, spy.calls)
+
+	spy.calls = 0
+	_, found := s.Search(2)
+	require.True(t, found)
+	require.NotZero(t, spy.calls, "Search did not use the comparator")
+
+	spy.calls = 0
+	s = s.Insert(2)
+	require.NotZero(t, spy.calls, "Insert did not use the comparator")
+	require.Equal(t, []int{1, 2, 2, 2, 4}, s.Slice())
+}
@@ -0,0 +1,86 @@
+package testdemo
+
+import "errors"
+
+// ErrModified is returned by AppendChecker.Append when the data changed
+// other than through Append since the checker last saw it.
+var ErrModified = errors.New("data modified outside AppendChecker")
+
+// AppendChecker keeps an append-only slice sorted in non-decreasing order
+// without rechecking all of it after every batch: it checks the data once
+// up front, then each batch against itself and the last value before it.
+type AppendChecker struct {
+	data []int
+	// n and last are the length and last value as of the last check.
+	n    int
+	last int
+}
+
+// NewAppendChecker checks that initial is sorted and returns a checker
+// appending to it. initial is not copied, so the checker owns it from
+// then on. It returns an *UnsortedError for the first pair out of order
+// when initial is not sorted.
+func NewAppendChecker(initial []int) (*AppendChecker, error) {
+	c := &AppendChecker{data: initial}
+	err := c.check(initial, 0)
+	reportCheck(len(initial), err)
+	if err != nil {
+		return nil, err
+	}
+	c.record()
+	return c, nil
+}
+
+// Append adds vals to the data if the result is still sorted, comparing
+// only vals and the last value before them. Otherwise it leaves the data
+// as it was and returns an *UnsortedError, with Index counting from the
+// start of the data. It returns ErrModified instead, before looking at
+// vals, when the length or last element of the data is no longer what
+// the last call left: a cheap check that catches truncation and a
+// rewritten tail, though not every change to the elements before it.
+func (c *AppendChecker) Append(vals ...int) error {
+	if len(c.data) != c.n || c.n > 0 && c.data[c.n-1] != c.last {
+		return ErrModified
+	}
+	err := c.check(vals, c.n)
+	reportCheck(len(vals), err)
+	if err != nil {
+		return err
+	}
+	c.data = append(c.data, vals...)
+	c.record()
+	return nil
+}
+
+// Snapshot returns the data. It is the checker's own slice, valid until
+// the next call to Append, and must not be modified.
+func (c *AppendChecker) Snapshot() []int {
+	return c.data
+}
+
+// check checks that vals, which would start at index start, are sorted
+// and not less than the data before start.
+func (c *AppendChecker) check(vals []int, start int) error {
+	for i, v := range vals {
+		var prev int
+		switch {
+		case i > 0:
+			prev = vals[i-1]
+		case start > 0:
+			prev = c.last
+		default:
+			continue
+		}
+		if v < prev {
+			return &UnsortedError{Index: start + i, Prev: prev, Next: v}
+		}
+	}
+	return nil
+}
+
+func (c *AppendChecker) record() {
+	c.n = len(c.data)
+	if c.n > 0 {
+		c.last = c.data[c.n-1]
+	}
+}
@@ -0,0 +1,74 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAppendChecker(t *testing.T) {
+	_, err := NewAppendChecker([]int{1, 3, 2})
+	require.Equal(t, &UnsortedError{Index: 2, Prev: 3, Next: 2}, err)
+
+	c, err := NewAppendChecker(nil)
+	require.NoError(t, err)
+	require.Empty(t, c.Snapshot())
+}
+
+func TestAppendCheckerManySmallAppends(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	c, err := NewAppendChecker([]int{0})
+	require.NoError(t, err)
+	next := 0
+	for i := 0; i < 1000; i++ {
+		batch := make([]int, rng.Intn(4))
+		for j := range batch {
+			next += rng.Intn(3)
+			batch[j] = next
+		}
+		require.NoError(t, c.Append(batch...))
+	}
+	require.True(t, IsSorted(c.Snapshot()))
+	require.Equal(t, next, c.Snapshot()[len(c.Snapshot())-1])
+}
+
+func TestAppendCheckerViolatingBatch(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Batch []int
+		Err   error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			c, err := NewAppendChecker([]int{1, 5})
+			require.NoError(t, err)
+			require.Equal(t, tc.Err, c.Append(tc.Batch...))
+			require.Equal(t, []int{1, 5}, c.Snapshot(), "a rejected batch must not be appended")
+			require.NoError(t, c.Append(6), "the checker must still work after a rejected batch")
+		})
+	}
+	validate(t, testCase{Name: "Below the boundary",
+		Batch: []int{4, 6},
+		Err:   &UnsortedError{Index: 2, Prev: 5, Next: 4},
+	})
+	validate(t, testCase{Name: "Unsorted within the batch",
+		Batch: []int{5, 7, 6},
+		Err:   &UnsortedError{Index: 4, Prev: 7, Next: 6},
+	})
+}
+
+func TestAppendCheckerDetectsTampering(t *testing.T) {
+	c, err := NewAppendChecker([]int{1, 2, 3})
+	require.NoError(t, err)
+	c.Snapshot()[2] = 9
+	require.ErrorIs(t, c.Append(10), ErrModified)
+
+	c, err = NewAppendChecker([]int{1, 2, 3})
+	require.NoError(t, err)
+	// Nothing exported can shorten the data, so shorten it directly.
+	c.data = c.data[:2]
+	require.ErrorIs(t, c.Append(10), ErrModified)
+}
@@ -0,0 +1,49 @@
+package testdemo
+
+import "fmt"
+
+// Argsort returns the permutation that sorts data, without moving data:
+// indices perm such that data[perm[0]] <= data[perm[1]] <= .... It is
+// stable, so the indices of equal values stay in increasing order.
+func Argsort(data []int) []int {
+	perm := make([]int, len(data))
+	for i := range perm {
+		perm[i] = i
+	}
+	return MergeSortFunc(perm, func(a, b int) bool { return data[a] < data[b] })
+}
+
+// VerifyArgsort checks that perm is a permutation of the indices of data
+// that visits data in non-decreasing order, as Argsort returns. It
+// returns an *UnsortedError, with Index counting positions in perm, if
+// the order is wrong, and an error describing the problem if perm is not
+// a permutation of 0 to len(data)-1. Stability is not checked.
+func VerifyArgsort(data, perm []int) error {
+	if err := checkPermutation(perm, len(data)); err != nil {
+		return err
+	}
+	for i := 1; i < len(perm); i++ {
+		if prev, next := data[perm[i-1]], data[perm[i]]; next < prev {
+			return &UnsortedError{Index: i, Prev: prev, Next: next}
+		}
+	}
+	return nil
+}
+
+// checkPermutation checks that perm holds each of 0 to n-1 exactly once.
+func checkPermutation(perm []int, n int) error {
+	if len(perm) != n {
+		return fmt.Errorf("permutation has %d elements, want %d", len(perm), n)
+	}
+	seen := make([]bool, n)
+	for i, p := range perm {
+		if p < 0 || p >= n {
+			return fmt.Errorf("permutation index %d: %d is out of range [0, %d)", i, p, n)
+		}
+		if seen[p] {
+			return fmt.Errorf("permutation index %d: %d is repeated", i, p)
+		}
+		seen[p] = true
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgsort(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Expected []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := Argsort(tc.Array)
+			require.Equal(t, tc.Expected, actual)
+			require.NoError(t, VerifyArgsort(tc.Array, actual))
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		Expected: []int{},
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array:    []int{1, 2, 3},
+		Expected: []int{0, 1, 2},
+	})
+	validate(t, testCase{Name: "Reversed",
+		Array:    []int{3, 2, 1},
+		Expected: []int{2, 1, 0},
+	})
+	validate(t, testCase{Name: "Duplicates keep index order",
+		Array:    []int{2, 1, 2, 1, 0},
+		Expected: []int{4, 1, 3, 0, 2},
+	})
+}
+
+func TestVerifyArgsort(t *testing.T) {
+	data := []int{30, 10, 20}
+	type testCase struct {
+		Name     string
+		Perm     []int
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			err := VerifyArgsort(data, tc.Perm)
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	validate(t, testCase{Name: "Correct",
+		Perm: []int{1, 2, 0},
+	})
+	validate(t, testCase{Name: "Wrong order",
+		Perm:     []int{1, 0, 2},
+		Expected: "index 2: 30 followed by 20",
+	})
+	validate(t, testCase{Name: "Wrong length",
+		Perm:     []int{1, 2},
+		Expected: "permutation has 2 elements, want 3",
+	})
+	validate(t, testCase{Name: "Out of range",
+		Perm:     []int{1, 2, 3},
+		Expected: "permutation index 2: 3 is out of range [0, 3)",
+	})
+	validate(t, testCase{Name: "Repeated",
+		Perm:     []int{1, 1, 0},
+		Expected: "permutation index 1: 1 is repeated",
+	})
+}
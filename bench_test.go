@@ -0,0 +1,725 @@
+package testdemo
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/tabletest"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func BenchmarkIsSorted(b *testing.B) {
+	type benchCase struct {
+		Name string
+		Data []int
+	}
+	sorted := make([]int, 10000)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	unsortedLast := append([]int(nil), sorted...)
+	unsortedLast[len(unsortedLast)-1] = -1
+	cases := []benchCase{
+		{Name: "Empty", Data: []int{}},
+		{Name: "Sorted 10k", Data: sorted},
+		{Name: "Unsorted at end 10k", Data: unsortedLast},
+		{Name: "Unsorted at start 10k", Data: append([]int{1}, sorted...)},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		for i := 0; i < b.N; i++ {
+			IsSorted(c.Data)
+		}
+	}, tabletest.Bytes(func(c benchCase) int64 { return int64(len(c.Data)) * strconv.IntSize / 8 }))
+}
+
+func BenchmarkReferenceSorts(b *testing.B) {
+	type benchCase struct {
+		Name string
+		Data []int
+		Sort func([]int)
+	}
+	rng := rand.New(rand.NewSource(1))
+	random := func(n int) []int {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = rng.Int()
+		}
+		return data
+	}
+	nearlySorted := func(n int) []int {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = i
+		}
+		for i := 0; i < n/100+1; i++ {
+			a, b := rng.Intn(n), rng.Intn(n)
+			data[a], data[b] = data[b], data[a]
+		}
+		return data
+	}
+	sorts := []struct {
+		Name string
+		Sort func([]int)
+	}{
+		{Name: "InsertionSort", Sort: InsertionSort},
+		{Name: "MergeSortInts", Sort: func(data []int) { MergeSortInts(data) }},
+		{Name: "sort.Ints", Sort: sort.Ints},
+	}
+	var cases []benchCase
+	for _, n := range []int{16, 256, 4096} {
+		for _, s := range sorts {
+			cases = append(cases,
+				benchCase{Name: fmt.Sprintf("%s/random %d", s.Name, n), Data: random(n), Sort: s.Sort},
+				benchCase{Name: fmt.Sprintf("%s/nearly sorted %d", s.Name, n), Data: nearlySorted(n), Sort: s.Sort},
+			)
+		}
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		data := make([]int, len(c.Data))
+		for i := 0; i < b.N; i++ {
+			copy(data, c.Data)
+			c.Sort(data)
+		}
+	})
+}
+
+func BenchmarkGallopSearch(b *testing.B) {
+	type benchCase struct {
+		Name   string
+		Search func(data []int, target, hint int) int
+	}
+	const size = 1 << 20
+	data := make([]int, size)
+	for i := range data {
+		data[i] = 2 * i
+	}
+	// Each search starts where the last one ended and looks for a target
+	// a few elements further on, as a merge join does.
+	cases := []benchCase{
+		{Name: "LowerBound", Search: func(data []int, target, _ int) int { return LowerBound(data, target) }},
+		{Name: "GallopSearch", Search: GallopSearch},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		hint := 0
+		for i := 0; i < b.N; i++ {
+			if hint >= size-16 {
+				hint = 0
+			}
+			hint = c.Search(data, data[hint]+2*(i%16), hint)
+		}
+	})
+}
+
+func BenchmarkInterpolationSearch(b *testing.B) {
+	type benchCase struct {
+		Name   string
+		Data   []int
+		Search func(data []int, target int) (int, bool)
+	}
+	const size = 1 << 20
+	rng := rand.New(rand.NewSource(1))
+	uniform := make([]int, size)
+	skewed := make([]int, size)
+	for i := range uniform {
+		uniform[i] = rng.Intn(1 << 30)
+		// Squaring crowds most values toward the bottom of the range.
+		skewed[i] = (uniform[i] >> 15) * (uniform[i] >> 15)
+	}
+	sort.Ints(uniform)
+	sort.Ints(skewed)
+
+	var cases []benchCase
+	for _, search := range []struct {
+		Name   string
+		Search func(data []int, target int) (int, bool)
+	}{
+		{Name: "Search", Search: Search},
+		{Name: "InterpolationSearch", Search: InterpolationSearch},
+	} {
+		cases = append(cases,
+			benchCase{Name: search.Name + "/uniform", Data: uniform, Search: search.Search},
+			benchCase{Name: search.Name + "/skewed", Data: skewed, Search: search.Search},
+		)
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		for i := 0; i < b.N; i++ {
+			c.Search(c.Data, c.Data[(i*7919)%size])
+		}
+	})
+}
+
+func BenchmarkSortedSet(b *testing.B) {
+	type benchCase struct {
+		Name string
+		Size int
+	}
+	var cases []benchCase
+	for _, n := range []int{16, 1024} {
+		cases = append(cases, benchCase{Name: fmt.Sprintf("%d", n), Size: n})
+	}
+	build := func(n int) (*SortedSet[int], map[int]struct{}) {
+		rng := rand.New(rand.NewSource(1))
+		s := &SortedSet[int]{}
+		m := map[int]struct{}{}
+		for s.Len() < n {
+			v := rng.Intn(4 * n)
+			s.Add(v)
+			m[v] = struct{}{}
+		}
+		return s, m
+	}
+	b.Run("Contains", func(b *testing.B) {
+		tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+			s, m := build(c.Size)
+			b.Run("SortedSet", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					s.Contains(i % (4 * c.Size))
+				}
+			})
+			b.Run("map", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_ = m[i%(4*c.Size)]
+				}
+			})
+		})
+	})
+	b.Run("OrderedIteration", func(b *testing.B) {
+		tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+			s, m := build(c.Size)
+			b.Run("SortedSet", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					for v := range s.Range() {
+						_ = v
+					}
+				}
+			})
+			b.Run("map", func(b *testing.B) {
+				keys := make([]int, 0, len(m))
+				for i := 0; i < b.N; i++ {
+					keys = keys[:0]
+					for v := range m {
+						keys = append(keys, v)
+					}
+					sort.Ints(keys)
+				}
+			})
+		})
+	})
+}
+
+func BenchmarkSortedMultiset(b *testing.B) {
+	type benchCase struct {
+		Name     string
+		Distinct int
+	}
+	const size = 1 << 14
+	cases := []benchCase{
+		{Name: "few distinct", Distinct: 16},
+		{Name: "many distinct", Distinct: size / 2},
+	}
+	// Each case reports the bytes a layout takes to hold size elements,
+	// next to the time to build it.
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		rng := rand.New(rand.NewSource(1))
+		values := make([]int, size)
+		for i := range values {
+			values[i] = rng.Intn(c.Distinct)
+		}
+		b.Run("SortedMultiset", func(b *testing.B) {
+			var m *SortedMultiset[int]
+			for i := 0; i < b.N; i++ {
+				m = &SortedMultiset[int]{}
+				for _, v := range values {
+					m.Add(v)
+				}
+			}
+			b.ReportMetric(float64(cap(m.values)+cap(m.counts))*strconv.IntSize/8, "bytes")
+		})
+		b.Run("sorted slice", func(b *testing.B) {
+			var sorted []int
+			for i := 0; i < b.N; i++ {
+				sorted = make([]int, len(values))
+				copy(sorted, values)
+				sort.Ints(sorted)
+			}
+			b.ReportMetric(float64(cap(sorted))*strconv.IntSize/8, "bytes")
+		})
+	})
+}
+
+func BenchmarkAppendSorted(b *testing.B) {
+	type benchCase struct {
+		Name   string
+		Append func(data []int, vals []int) []int
+	}
+	rng := rand.New(rand.NewSource(1))
+	base := make([]int, 1<<14)
+	for i := range base {
+		base[i] = rng.Intn(1 << 30)
+	}
+	sort.Ints(base)
+	vals := make([]int, 1000)
+	for i := range vals {
+		vals[i] = rng.Intn(1 << 30)
+	}
+	cases := []benchCase{
+		{Name: "AppendSorted", Append: func(data []int, vals []int) []int { return AppendSorted(data, vals...) }},
+		{Name: "SortedInsert", Append: func(data []int, vals []int) []int {
+			for _, v := range vals {
+				data = SortedInsert(data, v)
+			}
+			return data
+		}},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		data := make([]int, len(base), len(base)+len(vals))
+		for i := 0; i < b.N; i++ {
+			copy(data, base)
+			c.Append(data[:len(base)], vals)
+		}
+	})
+}
+
+func BenchmarkDisorderProfile(b *testing.B) {
+	type benchCase struct {
+		Name    string
+		Profile func(data []int) Profile
+	}
+	rng := rand.New(rand.NewSource(1))
+	data := make([]int, 1e7)
+	for i := range data {
+		data[i] = i
+		if rng.Intn(1000) == 0 {
+			data[i] = rng.Int()
+		}
+	}
+	// separatePasses computes the same profile the way it would be done
+	// without DisorderProfile, one measure per pass.
+	separatePasses := func(data []int) Profile {
+		p := Profile{Sorted: IsSorted(data), Min: slices.Min(data), Max: slices.Max(data)}
+		for i := 1; i < len(data); i++ {
+			if data[i] < data[i-1] {
+				p.AdjacentInversions++
+			}
+		}
+		p.Runs = int(p.AdjacentInversions) + 1
+		run := 1
+		for i := 1; i < len(data); i++ {
+			if data[i] < data[i-1] {
+				run = 0
+			}
+			run++
+			p.LongestRun = max(p.LongestRun, run)
+		}
+		return p
+	}
+	cases := []benchCase{
+		{Name: "DisorderProfile", Profile: DisorderProfile},
+		{Name: "separate passes", Profile: separatePasses},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		for i := 0; i < b.N; i++ {
+			c.Profile(data)
+		}
+	}, tabletest.Bytes(func(benchCase) int64 { return int64(len(data)) * strconv.IntSize / 8 }))
+}
+
+func BenchmarkIsSortedCollated(b *testing.B) {
+	type benchCase struct {
+		Name  string
+		Check func(data []string) bool
+	}
+	words := []string{"Apfel", "Äpfel", "apfel", "Birne", "Bäume", "Zebra", "Zürich", "Öl", "Übel"}
+	data := make([]string, 1<<12)
+	for i := range data {
+		data[i] = words[i%len(words)] + strconv.Itoa(i)
+	}
+	// Sorted data makes every check compare all neighbors.
+	collate.New(language.German).SortStrings(data)
+	keys := CollationKeys(data, language.German)
+	// The key cases show both what a single check costs including the
+	// keys, and what each check after the first costs.
+	cases := []benchCase{
+		{Name: "per comparison", Check: func(data []string) bool { return IsSortedCollated(data, language.German) }},
+		{Name: "keys", Check: func(data []string) bool { return IsSortedCollationKeys(CollationKeys(data, language.German)) }},
+		{Name: "precomputed keys", Check: func([]string) bool { return IsSortedCollationKeys(keys) }},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		for i := 0; i < b.N; i++ {
+			c.Check(data)
+		}
+	})
+}
+
+func BenchmarkIsSortedIgnoring(b *testing.B) {
+	type benchCase struct {
+		Name string
+		Data []int
+	}
+	const size = 1 << 16
+	dense := make([]int, size)
+	sparse := make([]int, size)
+	for i := range dense {
+		dense[i] = i
+		sparse[i] = -1
+		if i%8 == 0 {
+			sparse[i] = i
+		}
+	}
+	cases := []benchCase{
+		{Name: "no sentinels", Data: dense},
+		{Name: "mostly sentinels", Data: sparse},
+	}
+	isMissing := func(v int) bool { return v == -1 }
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			IsSortedIgnoring(c.Data, isMissing)
+		}
+	}, tabletest.Bytes(func(c benchCase) int64 { return int64(len(c.Data)) * strconv.IntSize / 8 }))
+}
+
+func BenchmarkRadixSortInt64(b *testing.B) {
+	type benchCase struct {
+		Name string
+		Data []int64
+		Sort func([]int64)
+	}
+	rng := rand.New(rand.NewSource(1))
+	inputs := []struct {
+		Name string
+		Make func(n int) []int64
+	}{
+		{Name: "random", Make: func(n int) []int64 {
+			data := make([]int64, n)
+			for i := range data {
+				data[i] = int64(rng.Uint64())
+			}
+			return data
+		}},
+		{Name: "sorted", Make: func(n int) []int64 {
+			data := make([]int64, n)
+			for i := range data {
+				data[i] = int64(i) - int64(n/2)
+			}
+			return data
+		}},
+		{Name: "reversed", Make: func(n int) []int64 {
+			data := make([]int64, n)
+			for i := range data {
+				data[i] = int64(n/2) - int64(i)
+			}
+			return data
+		}},
+	}
+	sorts := []struct {
+		Name string
+		Sort func([]int64)
+	}{
+		{Name: "RadixSortInt64", Sort: RadixSortInt64},
+		{Name: "slices.Sort", Sort: slices.Sort[[]int64]},
+		{Name: "sort.Slice", Sort: func(data []int64) {
+			sort.Slice(data, func(i, j int) bool { return data[i] < data[j] })
+		}},
+	}
+	var cases []benchCase
+	for _, n := range []int{1e5, 1e6, 1e7} {
+		for _, in := range inputs {
+			data := in.Make(n)
+			for _, s := range sorts {
+				cases = append(cases, benchCase{Name: fmt.Sprintf("%s/%s %d", s.Name, in.Name, n), Data: data, Sort: s.Sort})
+			}
+		}
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		data := make([]int64, len(c.Data))
+		for i := 0; i < b.N; i++ {
+			copy(data, c.Data)
+			c.Sort(data)
+		}
+	}, tabletest.Bytes(func(c benchCase) int64 { return int64(len(c.Data)) * 8 }))
+}
+
+// maxIntHeap is a max-heap of ints, for comparing SortedBuffer with a
+// heap keeping the n smallest values.
+type maxIntHeap []int
+
+func (h maxIntHeap) Len() int           { return len(h) }
+func (h maxIntHeap) Less(i, j int) bool { return h[i] > h[j] }
+func (h maxIntHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxIntHeap) Push(x any)        { *h = append(*h, x.(int)) }
+
+func (h *maxIntHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func BenchmarkSortedBuffer(b *testing.B) {
+	type benchCase struct {
+		Name string
+		N    int
+	}
+	rng := rand.New(rand.NewSource(1))
+	stream := make([]int, 1<<16)
+	for i := range stream {
+		stream[i] = rng.Int()
+	}
+	cases := []benchCase{{Name: "10", N: 10}, {Name: "1000", N: 1000}}
+	// Both read the values in sorted order once the stream is done.
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		b.Run("SortedBuffer", func(b *testing.B) {
+			buf := NewSortedBuffer(c.N)
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				for _, v := range stream {
+					buf.Offer(v)
+				}
+				_ = buf.Values()
+			}
+		})
+		b.Run("heap", func(b *testing.B) {
+			h := make(maxIntHeap, 0, c.N)
+			sorted := make([]int, 0, c.N)
+			for i := 0; i < b.N; i++ {
+				h = h[:0]
+				for _, v := range stream {
+					if len(h) < c.N {
+						heap.Push(&h, v)
+					} else if v < h[0] {
+						h[0] = v
+						heap.Fix(&h, 0)
+					}
+				}
+				sorted = append(sorted[:0], h...)
+				slices.Sort(sorted)
+			}
+		})
+	})
+}
+
+func BenchmarkRangeSortedness(b *testing.B) {
+	type benchCase struct {
+		Name string
+		// Prepare returns the function answering queries about data.
+		Prepare func(data []int) func(lo, hi int) bool
+	}
+	rng := rand.New(rand.NewSource(1))
+	data := make([]int, 1e5)
+	for i := range data {
+		data[i] = i
+		if rng.Intn(1000) == 0 {
+			data[i] = 0
+		}
+	}
+	type query struct{ lo, hi int }
+	queries := make([]query, 1e5)
+	for i := range queries {
+		lo := rng.Intn(len(data))
+		queries[i] = query{lo, lo + rng.Intn(len(data)-lo+1)}
+	}
+	// Each iteration prepares once and answers all the queries, so the
+	// RangeSortedness case pays for building it too.
+	cases := []benchCase{
+		{Name: "IsSortedRange", Prepare: func(data []int) func(lo, hi int) bool {
+			return func(lo, hi int) bool { return IsSortedRange(data, lo, hi) }
+		}},
+		{Name: "RangeSortedness", Prepare: func(data []int) func(lo, hi int) bool {
+			return NewRangeSortedness(data).Query
+		}},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		for i := 0; i < b.N; i++ {
+			query := c.Prepare(data)
+			for _, q := range queries {
+				query(q.lo, q.hi)
+			}
+		}
+	})
+}
+
+func BenchmarkInversions(b *testing.B) {
+	type benchCase struct {
+		Name  string
+		Count func(data []int) int64
+	}
+	rng := rand.New(rand.NewSource(1))
+	data := make([]int, 1e5)
+	for i := range data {
+		data[i] = rng.Int()
+	}
+	cases := []benchCase{
+		{Name: "CountInversions", Count: CountInversions},
+		{Name: "InversionsPerElement", Count: func(data []int) int64 {
+			_, total := InversionsPerElement(data)
+			return total
+		}},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			c.Count(data)
+		}
+	})
+}
+
+func BenchmarkDeltaEncode(b *testing.B) {
+	ids := realisticIDs(1e5)
+	deltas, _ := DeltaEncode(ids)
+	b.Run("Encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			DeltaEncode(ids)
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			DeltaDecode(deltas)
+		}
+	})
+}
+
+func BenchmarkBitSetFromSorted(b *testing.B) {
+	type benchCase struct {
+		Name        string
+		Size, Range int
+	}
+	cases := []benchCase{
+		{Name: "Dense", Size: 1e5, Range: 2e5},
+		{Name: "Sparse", Size: 1e3, Range: 1e8},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		rng := rand.New(rand.NewSource(1))
+		data := make([]int, c.Size)
+		for i := range data {
+			data[i] = rng.Intn(c.Range)
+		}
+		slices.Sort(data)
+		b.ReportAllocs()
+		var s *BitSet
+		for i := 0; i < b.N; i++ {
+			s, _ = BitSetFromSorted(data)
+		}
+		// Compare the memory each representation of the set takes.
+		b.ReportMetric(float64(8*len(s.bits)), "bitset-bytes")
+		b.ReportMetric(float64(8*len(data)), "slice-bytes")
+	})
+}
+
+// BenchmarkProgress compares checks without a Progress, with one whose
+// Func is nil, which should cost nothing, and with one that counts calls.
+func BenchmarkProgress(b *testing.B) {
+	type benchCase struct {
+		Name     string
+		Progress []Progress
+	}
+	data := make([]int, 1e6)
+	for i := range data {
+		data[i] = i
+	}
+	text := numberLines(1e5, "")
+	calls := 0
+	cases := []benchCase{
+		{Name: "None"},
+		{Name: "Nil func", Progress: []Progress{{}}},
+		{Name: "Counting", Progress: []Progress{{Func: func(done, total int64) { calls++ }}}},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return "IsSortedCtx/" + c.Name }, func(b *testing.B, c benchCase) {
+		ctx := context.Background()
+		for i := 0; i < b.N; i++ {
+			IsSortedCtx(ctx, data, c.Progress...)
+		}
+	})
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return "CheckReader/" + c.Name }, func(b *testing.B, c benchCase) {
+		var opts ReaderOptions
+		if len(c.Progress) > 0 {
+			opts.Progress = c.Progress[0]
+		}
+		for i := 0; i < b.N; i++ {
+			CheckReader(strings.NewReader(text), opts)
+		}
+	})
+}
+
+// BenchmarkNaturalComparator shows what a comparison under CompareNatural
+// costs, as an example of BenchComparator.
+func BenchmarkNaturalComparator(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	sample := make([]string, 1000)
+	for i := range sample {
+		sample[i] = fmt.Sprintf("release-%d.%d.%d", rng.Intn(3), rng.Intn(20), rng.Intn(200))
+	}
+	tabletest.BenchComparator(b, sample, CompareToLess(CompareNatural))
+}
+
+// BenchmarkIsSortedOrdered compares the generic loop with the specialized
+// ones IsSortedOrdered dispatches to; the Dispatched cases should run
+// about as fast as IsSorted rather than as Generic.
+func BenchmarkIsSortedOrdered(b *testing.B) {
+	type benchCase struct {
+		Name  string
+		Check func() bool
+	}
+	const size = 1 << 16
+	ints := make([]int, size)
+	floats := make([]float64, size)
+	for i := range ints {
+		ints[i] = i
+		floats[i] = float64(i)
+	}
+	cases := []benchCase{
+		{Name: "int/IsSorted", Check: func() bool { return IsSorted(ints) }},
+		{Name: "int/Generic", Check: func() bool { return isSortedGeneric(ints) }},
+		{Name: "int/Dispatched", Check: func() bool { return IsSortedOrdered(ints) }},
+		{Name: "float64/Generic", Check: func() bool { return isSortedGeneric(floats) }},
+		{Name: "float64/Dispatched", Check: func() bool { return IsSortedOrdered(floats) }},
+	}
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		for i := 0; i < b.N; i++ {
+			c.Check()
+		}
+	}, tabletest.Bytes(func(benchCase) int64 { return size * 8 }))
+}
+
+// BenchmarkIsSortedVectorized compares IsSorted with and without the
+// vectorized check, on a slice long enough for it to pay and on slices
+// short enough that it must not cost anything.
+func BenchmarkIsSortedVectorized(b *testing.B) {
+	type benchCase struct {
+		Name       string
+		Data       []int
+		Vectorized bool
+	}
+	sorted := func(n int) []int {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = i
+		}
+		return data
+	}
+	var cases []benchCase
+	for _, n := range []int{8, 64, 10_000_000} {
+		data := sorted(n)
+		cases = append(cases,
+			benchCase{Name: fmt.Sprintf("%d/Scalar", n), Data: data},
+			benchCase{Name: fmt.Sprintf("%d/Vectorized", n), Data: data, Vectorized: true},
+		)
+	}
+	defer SetVectorized(SetVectorized(true))
+	tabletest.RunBenchTable(b, cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+		SetVectorized(c.Vectorized)
+		for i := 0; i < b.N; i++ {
+			IsSorted(c.Data)
+		}
+	}, tabletest.Bytes(func(c benchCase) int64 { return int64(len(c.Data)) * strconv.IntSize / 8 }))
+}
@@ -0,0 +1,228 @@
+// Package benchguard compares benchmarks against a baseline of their
+// ns/op kept in testdata, so CI can flag performance regressions.
+//
+// Benchmarks are registered with Register, usually from an init function
+// of a test file, and run programmatically with testing.Benchmark, so a
+// regular test can guard them:
+//
+//	func init() {
+//		benchguard.Register("IsSorted/Sorted 10k", func(b *testing.B) { ... })
+//	}
+//
+//	func TestBenchmarkBaseline(t *testing.T) {
+//		benchguard.CompareBaseline(t, "testdata/benchguard.json", 25)
+//	}
+//
+// The baseline is refreshed by running the same test with
+// -update-baseline. Registered benchmarks must not call b.Run, as
+// testing.Benchmark does not report on sub-benchmarks.
+package benchguard
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Env is the environment variable that enables CompareBaseline. Timings
+// only compare on the machine the baseline was recorded on, so the
+// comparison is opt-in, e.g. on a dedicated CI runner:
+//
+//	TESTDEMO_BENCHGUARD=1 go test -run TestBenchmarkBaseline .
+const Env = "TESTDEMO_BENCHGUARD"
+
+var updateBaseline = flag.Bool("update-baseline", false, "rewrite the benchguard baselines instead of comparing against them")
+
+// baseline is the file format of a baseline.
+type baseline struct {
+	GOOS       string  `json:"goos"`
+	GOARCH     string  `json:"goarch"`
+	Benchmarks []entry `json:"benchmarks"`
+}
+
+type entry struct {
+	Name    string  `json:"name"`
+	NsPerOp float64 `json:"ns_per_op"`
+}
+
+// reporter is the part of *testing.T and *testing.B benchguard reports
+// to.
+type reporter interface {
+	Helper()
+	Logf(format string, args ...any)
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// registry holds benchmarks by name.
+type registry struct {
+	mu         sync.Mutex
+	benchmarks map[string]func(b *testing.B)
+}
+
+var (
+	defaultRegistry = &registry{}
+	// saved are the paths SaveBaseline already wrote in this process.
+	saved sync.Map
+)
+
+// Register adds a benchmark to the ones SaveBaseline and CompareBaseline
+// run. It panics when name is already registered.
+func Register(name string, fn func(b *testing.B)) {
+	defaultRegistry.register(name, fn)
+}
+
+// SaveBaseline runs the registered benchmarks and writes their ns/op to
+// path. It is meant to be the whole body of a benchmark, which the
+// testing package may call several times: only the first call in a
+// process writes the file.
+func SaveBaseline(b *testing.B, path string) {
+	b.Helper()
+	if _, done := saved.LoadOrStore(path, true); done {
+		return
+	}
+	defaultRegistry.save(b, path)
+}
+
+// CompareBaseline runs the registered benchmarks and fails t listing the
+// ones whose ns/op is more than tolerancePct percent over the baseline
+// in path. With -update-baseline it rewrites path instead. Without
+// TESTDEMO_BENCHGUARD or -update-baseline, t is skipped.
+func CompareBaseline(t *testing.T, path string, tolerancePct float64) {
+	t.Helper()
+	if !*updateBaseline && os.Getenv(Env) == "" {
+		t.Skipf("set %s=1 to compare benchmarks against %s", Env, path)
+	}
+	defaultRegistry.compare(t, path, tolerancePct, *updateBaseline)
+}
+
+func (r *registry) register(name string, fn func(b *testing.B)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.benchmarks[name]; ok {
+		panic(fmt.Sprintf("benchguard: benchmark %q registered twice", name))
+	}
+	if r.benchmarks == nil {
+		r.benchmarks = map[string]func(b *testing.B){}
+	}
+	r.benchmarks[name] = fn
+}
+
+// run runs every benchmark and returns their ns/op by name. Benchmarks
+// that fail are reported on t and left out.
+func (r *registry) run(t reporter) map[string]float64 {
+	t.Helper()
+	r.mu.Lock()
+	benchmarks := make(map[string]func(b *testing.B), len(r.benchmarks))
+	names := make([]string, 0, len(r.benchmarks))
+	for name, fn := range r.benchmarks {
+		benchmarks[name] = fn
+		names = append(names, name)
+	}
+	r.mu.Unlock()
+	sort.Strings(names)
+
+	results := map[string]float64{}
+	for _, name := range names {
+		result := testing.Benchmark(benchmarks[name])
+		if result.N == 0 {
+			t.Errorf("benchguard: benchmark %s failed", name)
+			continue
+		}
+		results[name] = float64(result.T.Nanoseconds()) / float64(result.N)
+	}
+	return results
+}
+
+func (r *registry) save(t reporter, path string) {
+	t.Helper()
+	results := r.run(t)
+	b := baseline{GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+	for name, ns := range results {
+		b.Benchmarks = append(b.Benchmarks, entry{Name: name, NsPerOp: ns})
+	}
+	sort.Slice(b.Benchmarks, func(i, j int) bool { return b.Benchmarks[i].Name < b.Benchmarks[j].Name })
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		t.Fatalf("benchguard: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("benchguard: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		t.Fatalf("benchguard: %v", err)
+		return
+	}
+	t.Logf("benchguard: wrote the baseline of %d benchmarks to %s", len(b.Benchmarks), path)
+}
+
+func (r *registry) compare(t reporter, path string, tolerancePct float64, update bool) {
+	t.Helper()
+	if update {
+		r.save(t, path)
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("benchguard: %v; record a baseline with -update-baseline", err)
+		return
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		t.Fatalf("benchguard: reading %s: %v", path, err)
+		return
+	}
+	if b.GOOS != runtime.GOOS || b.GOARCH != runtime.GOARCH {
+		t.Logf("benchguard: warning: %s was recorded on %s/%s, not %s/%s", path, b.GOOS, b.GOARCH, runtime.GOOS, runtime.GOARCH)
+	}
+	base := map[string]float64{}
+	for _, e := range b.Benchmarks {
+		base[e.Name] = e.NsPerOp
+	}
+	results := r.run(t)
+	for _, name := range sortedKeys(results) {
+		if _, ok := base[name]; !ok {
+			t.Logf("benchguard: %s has no baseline in %s; record one with -update-baseline", name, path)
+		}
+	}
+	if lines := regressions(base, results, tolerancePct); len(lines) > 0 {
+		t.Errorf("benchguard: %d benchmarks regressed by more than %v%% over %s; rerun with -update-baseline if this is expected:\n%s",
+			len(lines), tolerancePct, path, strings.Join(lines, "\n"))
+	}
+}
+
+// regressions returns a line for every benchmark of results more than
+// tolerancePct percent slower than in base, sorted by name.
+func regressions(base, results map[string]float64, tolerancePct float64) []string {
+	var lines []string
+	for _, name := range sortedKeys(results) {
+		was, ok := base[name]
+		if !ok || was <= 0 {
+			continue
+		}
+		now := results[name]
+		if now <= was*(1+tolerancePct/100) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %.1f ns/op, baseline %.1f ns/op (+%.1f%%)", name, now, was, (now/was-1)*100))
+	}
+	return lines
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,232 @@
+package benchguard
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReporter is a reporter recording what is reported to it.
+type fakeReporter struct {
+	logs, errors, fatals []string
+}
+
+func (f *fakeReporter) Helper() {}
+func (f *fakeReporter) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+func (f *fakeReporter) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+func (f *fakeReporter) Fatalf(format string, args ...any) {
+	f.fatals = append(f.fatals, fmt.Sprintf(format, args...))
+}
+
+// costing returns a benchmark whose every op takes at least cost.
+func costing(cost time.Duration) func(b *testing.B) {
+	return func(b *testing.B) {
+		for range b.N {
+			time.Sleep(cost)
+		}
+	}
+}
+
+// fixedIterations makes testing.Benchmark run a fixed number of ops, so
+// the synthetic benchmarks take a known time.
+func fixedIterations(t *testing.T) {
+	t.Helper()
+	f := flag.Lookup("test.benchtime")
+	old := f.Value.String()
+	require.NoError(t, f.Value.Set("5x"))
+	t.Cleanup(func() { require.NoError(t, f.Value.Set(old)) })
+}
+
+// writeBaseline writes a baseline of the given ns/op by name.
+func writeBaseline(t *testing.T, path string, nsPerOp map[string]float64) {
+	t.Helper()
+	b := baseline{GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+	for _, name := range sortedKeys(nsPerOp) {
+		b.Benchmarks = append(b.Benchmarks, entry{Name: name, NsPerOp: nsPerOp[name]})
+	}
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+}
+
+func TestRegressions(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Base      map[string]float64
+		Results   map[string]float64
+		Tolerance float64
+		Expected  []string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, regressions(tc.Base, tc.Results, tc.Tolerance))
+		})
+	}
+	validate(t, testCase{Name: "Within tolerance",
+		Base:      map[string]float64{"a": 100},
+		Results:   map[string]float64{"a": 110},
+		Tolerance: 10,
+	})
+	validate(t, testCase{Name: "Faster",
+		Base:      map[string]float64{"a": 100},
+		Results:   map[string]float64{"a": 10},
+		Tolerance: 0,
+	})
+	validate(t, testCase{Name: "Over tolerance",
+		Base:      map[string]float64{"b": 100, "a": 100},
+		Results:   map[string]float64{"b": 150, "a": 111},
+		Tolerance: 10,
+		Expected: []string{
+			"  a: 111.0 ns/op, baseline 100.0 ns/op (+11.0%)",
+			"  b: 150.0 ns/op, baseline 100.0 ns/op (+50.0%)",
+		},
+	})
+	validate(t, testCase{Name: "No baseline",
+		Base:      map[string]float64{},
+		Results:   map[string]float64{"a": 1000},
+		Tolerance: 10,
+	})
+}
+
+func TestCompare(t *testing.T) {
+	type testCase struct {
+		Name string
+		// Costs are the per-op costs of the benchmarks, and Baseline
+		// their recorded ns/op.
+		Costs             map[string]time.Duration
+		Baseline          map[string]float64
+		ExpectedRegressed []string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			fixedIterations(t)
+			path := filepath.Join(t.TempDir(), "baseline.json")
+			writeBaseline(t, path, tc.Baseline)
+			r := &registry{}
+			for name, cost := range tc.Costs {
+				r.register(name, costing(cost))
+			}
+			f := &fakeReporter{}
+			r.compare(f, path, 50, false)
+			require.Empty(t, f.fatals)
+			if len(tc.ExpectedRegressed) == 0 {
+				require.Empty(t, f.errors)
+			} else {
+				require.Len(t, f.errors, 1)
+				require.True(t, strings.HasPrefix(f.errors[0], fmt.Sprintf("benchguard: %d benchmarks regressed by more than 50%% over %s;", len(tc.ExpectedRegressed), path)), f.errors[0])
+				for _, name := range tc.ExpectedRegressed {
+					require.Contains(t, f.errors[0], "\n  "+name+": ")
+				}
+			}
+			require.Empty(t, f.logs)
+		})
+	}
+	// Sleeping takes at least as long as asked for, so a benchmark is
+	// only ever slower than its cost says, never faster.
+	validate(t, testCase{Name: "Faster than the baseline",
+		Costs:    map[string]time.Duration{"fast": time.Millisecond},
+		Baseline: map[string]float64{"fast": float64(50 * time.Millisecond)},
+	})
+	validate(t, testCase{Name: "Regressed",
+		Costs: map[string]time.Duration{
+			"fast": time.Millisecond,
+			"slow": 4 * time.Millisecond,
+		},
+		Baseline: map[string]float64{
+			"fast": float64(50 * time.Millisecond),
+			"slow": float64(time.Millisecond),
+		},
+		ExpectedRegressed: []string{"slow"},
+	})
+}
+
+func TestCompareLogsNewBenchmarks(t *testing.T) {
+	fixedIterations(t)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	writeBaseline(t, path, map[string]float64{})
+	r := &registry{}
+	r.register("new", costing(0))
+	f := &fakeReporter{}
+	r.compare(f, path, 10, false)
+	require.Equal(t, []string{"benchguard: new has no baseline in " + path + "; record one with -update-baseline"}, f.logs)
+}
+
+func TestCompareWithoutBaseline(t *testing.T) {
+	f := &fakeReporter{}
+	(&registry{}).compare(f, filepath.Join(t.TempDir(), "missing.json"), 10, false)
+	require.Len(t, f.fatals, 1)
+	require.True(t, strings.HasSuffix(f.fatals[0], "; record a baseline with -update-baseline"), f.fatals[0])
+}
+
+func TestCompareReportsFailedBenchmarks(t *testing.T) {
+	fixedIterations(t)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	writeBaseline(t, path, map[string]float64{"broken": 1})
+	r := &registry{}
+	r.register("broken", func(b *testing.B) { b.Fatal("broken on purpose") })
+	f := &fakeReporter{}
+	r.compare(f, path, 10, false)
+	require.Equal(t, []string{"benchguard: benchmark broken failed"}, f.errors)
+}
+
+func TestUpdate(t *testing.T) {
+	fixedIterations(t)
+	path := filepath.Join(t.TempDir(), "testdata", "baseline.json")
+	r := &registry{}
+	r.register("b", costing(2*time.Millisecond))
+	r.register("a", costing(time.Millisecond))
+	f := &fakeReporter{}
+	r.compare(f, path, 10, true)
+	require.Empty(t, f.errors)
+	require.Equal(t, []string{"benchguard: wrote the baseline of 2 benchmarks to " + path}, f.logs)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var b baseline
+	require.NoError(t, json.Unmarshal(data, &b))
+	require.Equal(t, runtime.GOOS, b.GOOS)
+	require.Equal(t, runtime.GOARCH, b.GOARCH)
+	require.Len(t, b.Benchmarks, 2)
+	require.Equal(t, "a", b.Benchmarks[0].Name)
+	require.GreaterOrEqual(t, b.Benchmarks[0].NsPerOp, float64(time.Millisecond))
+	require.Equal(t, "b", b.Benchmarks[1].Name)
+	require.GreaterOrEqual(t, b.Benchmarks[1].NsPerOp, float64(2*time.Millisecond))
+
+	// The new baseline is what the benchmarks compare against next.
+	f = &fakeReporter{}
+	r.compare(f, path, 1000, false)
+	require.Empty(t, f.errors)
+	require.Empty(t, f.fatals)
+}
+
+func TestRegisterTwice(t *testing.T) {
+	r := &registry{}
+	r.register("a", costing(0))
+	require.PanicsWithValue(t, `benchguard: benchmark "a" registered twice`, func() { r.register("a", costing(0)) })
+}
+
+func TestCompareBaselineIsOptIn(t *testing.T) {
+	t.Setenv(Env, "")
+	var skipped bool
+	t.Run("compare", func(t *testing.T) {
+		defer func() { skipped = t.Skipped() }()
+		CompareBaseline(t, filepath.Join(t.TempDir(), "missing.json"), 10)
+	})
+	require.True(t, skipped)
+}
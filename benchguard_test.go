@@ -0,0 +1,43 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/benchguard"
+)
+
+// The IsSorted cases of BenchmarkIsSorted that CI guards against
+// regressions, see TestBenchmarkBaseline.
+func init() {
+	sorted := make([]int, 10000)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	unsortedLast := append([]int(nil), sorted...)
+	unsortedLast[len(unsortedLast)-1] = -1
+	cases := []struct {
+		Name string
+		Data []int
+	}{
+		{Name: "IsSorted/Sorted 10k", Data: sorted},
+		{Name: "IsSorted/Unsorted at end 10k", Data: unsortedLast},
+		{Name: "IsSorted/Unsorted at start 10k", Data: append([]int{1}, sorted...)},
+	}
+	for _, c := range cases {
+		benchguard.Register(c.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				IsSorted(c.Data)
+			}
+		})
+	}
+}
+
+// TestBenchmarkBaseline fails when the benchmarks registered above got
+// more than 25% slower than in testdata/benchguard.json. It only runs
+// with TESTDEMO_BENCHGUARD=1, and rewrites the baseline with
+// -update-baseline:
+//
+//	go test -run TestBenchmarkBaseline -update-baseline .
+func TestBenchmarkBaseline(t *testing.T) {
+	benchguard.CompareBaseline(t, "testdata/benchguard.json", 25)
+}
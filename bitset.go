@@ -0,0 +1,66 @@
+package testdemo
+
+import "math/bits"
+
+// maxBitSetValue is the largest value BitSetFromSorted accepts, so that
+// no input can make it allocate more than 128 MiB.
+const maxBitSetValue = 1<<30 - 1
+
+// BitSet is a set of non-negative ints held as one bit per value up to
+// the largest, so membership is a single lookup. For dense data it is
+// far smaller than the sorted slice it was built from: 1e6 IDs below
+// 2e6 take 250 KB instead of 8 MB.
+type BitSet struct {
+	bits  bitset
+	count int
+}
+
+// BitSetFromSorted returns the set of the values in data, which must be
+// sorted in non-decreasing order; duplicates are allowed and kept once.
+// It returns an *UnsortedError for the first pair out of order, or a
+// *BoundsError for the first value that is negative or above 2³⁰-1.
+func BitSetFromSorted(data []int) (*BitSet, error) {
+	for i, v := range data {
+		if i > 0 && v < data[i-1] {
+			return nil, &UnsortedError{Index: i, Prev: data[i-1], Next: v}
+		}
+		if v < 0 || v > maxBitSetValue {
+			return nil, &BoundsError{Index: i, Value: v, Lo: 0, Hi: maxBitSetValue}
+		}
+	}
+	s := &BitSet{}
+	if len(data) == 0 {
+		return s, nil
+	}
+	s.bits = make(bitset, data[len(data)-1]/64+1)
+	for i, v := range data {
+		if i == 0 || v != data[i-1] {
+			s.bits.set(v)
+			s.count++
+		}
+	}
+	return s, nil
+}
+
+// Contains reports whether v is in the set.
+func (s *BitSet) Contains(v int) bool {
+	return v >= 0 && v/64 < len(s.bits) && s.bits.has(v)
+}
+
+// Count returns the number of values in the set.
+func (s *BitSet) Count() int {
+	return s.count
+}
+
+// ToSortedSlice returns the values in the set in increasing order: the
+// data it was built from, without duplicates.
+func (s *BitSet) ToSortedSlice() []int {
+	out := make([]int, 0, s.count)
+	for i, word := range s.bits {
+		for word != 0 {
+			out = append(out, i*64+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+	return out
+}
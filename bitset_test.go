@@ -0,0 +1,89 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitSetFromSorted(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Array  []int
+		Values []int
+		Err    error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			s, err := BitSetFromSorted(tc.Array)
+			require.Equal(t, tc.Err, err)
+			if err != nil {
+				return
+			}
+			require.Equal(t, tc.Values, s.ToSortedSlice())
+			require.Equal(t, len(tc.Values), s.Count())
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:  []int{},
+		Values: []int{},
+	})
+	validate(t, testCase{Name: "Word boundaries",
+		Array:  []int{0, 63, 64, 127, 128},
+		Values: []int{0, 63, 64, 127, 128},
+	})
+	validate(t, testCase{Name: "Duplicates kept once",
+		Array:  []int{2, 2, 2, 5, 5},
+		Values: []int{2, 5},
+	})
+	validate(t, testCase{Name: "Largest value",
+		Array:  []int{maxBitSetValue},
+		Values: []int{maxBitSetValue},
+	})
+	validate(t, testCase{Name: "Negative",
+		Array: []int{-1, 2},
+		Err:   &BoundsError{Index: 0, Value: -1, Lo: 0, Hi: maxBitSetValue},
+	})
+	validate(t, testCase{Name: "Too large",
+		Array: []int{1, maxBitSetValue + 1},
+		Err:   &BoundsError{Index: 1, Value: maxBitSetValue + 1, Lo: 0, Hi: maxBitSetValue},
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Array: []int{1, 3, 2},
+		Err:   &UnsortedError{Index: 2, Prev: 3, Next: 2},
+	})
+}
+
+func TestBitSetContains(t *testing.T) {
+	s, err := BitSetFromSorted([]int{1, 64, 100})
+	require.NoError(t, err)
+	for v, want := range map[int]bool{-1: false, 0: false, 1: true, 64: true, 65: false, 100: true, 101: false, math.MaxInt: false} {
+		require.Equal(t, want, s.Contains(v), "Contains(%d)", v)
+	}
+}
+
+func TestBitSetRoundTrip(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 200; i++ {
+		data := make([]int, rng.Intn(50))
+		for j := range data {
+			data[j] = rng.Intn(300)
+		}
+		slices.Sort(data)
+		s, err := BitSetFromSorted(data)
+		require.NoError(t, err)
+		want := slices.Compact(slices.Clone(data))
+		got := s.ToSortedSlice()
+		require.ElementsMatch(t, want, got)
+		require.True(t, IsSorted(got))
+		for v := -1; v <= 300; v++ {
+			_, found := slices.BinarySearch(want, v)
+			require.Equal(t, found, s.Contains(v), "Contains(%d) of %v", v, data)
+		}
+	}
+}
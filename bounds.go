@@ -0,0 +1,48 @@
+package testdemo
+
+// IsSortedInBounds reports whether data is sorted in non-decreasing order
+// with every element within [lo, hi].
+func IsSortedInBounds(data []int, lo, hi int) bool {
+	return EnsureSortedInBounds(data, lo, hi) == nil
+}
+
+// EnsureSortedInBounds checks that data is sorted in non-decreasing order
+// with every element within [lo, hi]. It returns nil when it is, and
+// otherwise the violation at the lowest index: a *BoundsError for an
+// element outside the bounds, or an *UnsortedError for an element smaller
+// than the one before it. When an element is both, the *BoundsError is
+// returned.
+//
+// It takes a single pass: only the ends of a sorted run can be outside
+// the bounds if any of it is, so only those are compared against them.
+func EnsureSortedInBounds(data []int, lo, hi int) error {
+	end := len(data)
+	var unsorted *UnsortedError
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			end = i
+			unsorted = &UnsortedError{Index: i, Prev: data[i-1], Next: data[i]}
+			break
+		}
+	}
+	// data[:end] is sorted, so the elements below lo are a prefix of it
+	// and the ones above hi a suffix.
+	if sorted := data[:end]; len(sorted) > 0 {
+		if sorted[0] < lo {
+			return &BoundsError{Index: 0, Value: sorted[0], Lo: lo, Hi: hi}
+		}
+		if sorted[end-1] > hi {
+			i := UpperBound(sorted, hi)
+			return &BoundsError{Index: i, Value: sorted[i], Lo: lo, Hi: hi}
+		}
+	}
+	if unsorted == nil {
+		return nil
+	}
+	// data[end] is less than an element not above hi, so it can only be
+	// below lo.
+	if data[end] < lo {
+		return &BoundsError{Index: end, Value: data[end], Lo: lo, Hi: hi}
+	}
+	return unsorted
+}
@@ -0,0 +1,102 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureSortedInBounds(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Lo, Hi   int
+		Expected error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := EnsureSortedInBounds(tc.Array, tc.Lo, tc.Hi)
+			require.Equal(t, tc.Expected, actual)
+			require.Equal(t, tc.Expected == nil, IsSortedInBounds(tc.Array, tc.Lo, tc.Hi))
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+		Lo:    0,
+		Hi:    10,
+	})
+	validate(t, testCase{Name: "Sorted within bounds",
+		Array: []int{0, 3, 3, 10},
+		Lo:    0,
+		Hi:    10,
+	})
+	validate(t, testCase{Name: "Below lo",
+		Array:    []int{-1, 3, 5},
+		Lo:       0,
+		Hi:       10,
+		Expected: &BoundsError{Index: 0, Value: -1, Lo: 0, Hi: 10},
+	})
+	validate(t, testCase{Name: "Above hi",
+		Array:    []int{1, 3, 11, 12},
+		Lo:       0,
+		Hi:       10,
+		Expected: &BoundsError{Index: 2, Value: 11, Lo: 0, Hi: 10},
+	})
+	validate(t, testCase{Name: "Unsorted within bounds",
+		Array:    []int{1, 5, 3},
+		Lo:       0,
+		Hi:       10,
+		Expected: &UnsortedError{Index: 2, Prev: 5, Next: 3},
+	})
+	validate(t, testCase{Name: "Out of bounds before unsorted",
+		Array:    []int{1, 11, 3},
+		Lo:       0,
+		Hi:       10,
+		Expected: &BoundsError{Index: 1, Value: 11, Lo: 0, Hi: 10},
+	})
+	validate(t, testCase{Name: "Unsorted before out of bounds",
+		Array:    []int{1, 5, 3, 11},
+		Lo:       0,
+		Hi:       10,
+		Expected: &UnsortedError{Index: 2, Prev: 5, Next: 3},
+	})
+	validate(t, testCase{Name: "Unsorted and out of bounds at once",
+		Array:    []int{1, 5, -3},
+		Lo:       0,
+		Hi:       10,
+		Expected: &BoundsError{Index: 2, Value: -3, Lo: 0, Hi: 10},
+	})
+	validate(t, testCase{Name: "Empty bounds",
+		Array:    []int{5},
+		Lo:       6,
+		Hi:       4,
+		Expected: &BoundsError{Index: 0, Value: 5, Lo: 6, Hi: 4},
+	})
+}
+
+func TestEnsureSortedInBoundsAgainstLinearScan(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 2000; i++ {
+		data := make([]int, rng.Intn(10))
+		for j := range data {
+			data[j] = rng.Intn(12) - 1
+		}
+		lo, hi := 0, 9
+
+		var expected error
+		for j, v := range data {
+			if v < lo || v > hi {
+				expected = &BoundsError{Index: j, Value: v, Lo: lo, Hi: hi}
+				break
+			}
+			if j > 0 && v < data[j-1] {
+				expected = &UnsortedError{Index: j, Prev: data[j-1], Next: v}
+				break
+			}
+		}
+		require.Equal(t, expected, EnsureSortedInBounds(data, lo, hi), "%v", data)
+	}
+}
@@ -0,0 +1,60 @@
+package testdemo
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// BucketizeSorted splits the range of data, which must be sorted in
+// non-decreasing order, into the given number of equal-width buckets and
+// counts the elements in each. It returns buckets+1 boundaries, from the
+// minimum to the maximum of data, and buckets counts, which sum to
+// len(data).
+//
+// Bucket i holds the elements in the half-open interval [bounds[i],
+// bounds[i+1]), except the last, which is closed so that it holds the
+// maximum: an element on an inner boundary counts towards the bucket
+// above it. Boundaries are rounded down to integers, so when buckets
+// exceeds max-min some buckets are empty. When every element is equal
+// there is a single bucket, and empty data has none.
+//
+// The counts come from a binary search per boundary, taking O(buckets·log
+// n) time rather than a scan of data. An *UnsortedError is returned if
+// data is not sorted, and an error if buckets is less than 1.
+func BucketizeSorted(data []int, buckets int) (bounds []int, counts []int64, err error) {
+	if buckets < 1 {
+		return nil, nil, fmt.Errorf("bucketize: buckets %d is less than 1", buckets)
+	}
+	if err := checkSorted(context.Background(), data, Order{}); err != nil {
+		return nil, nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil, nil
+	}
+	lo, hi := data[0], data[len(data)-1]
+	if lo == hi {
+		return []int{lo, hi}, []int64{int64(len(data))}, nil
+	}
+	// The span can exceed MaxInt, so the boundaries are offsets from lo
+	// computed in uint64: i*span/buckets, with a 128-bit product.
+	span := uint64(hi) - uint64(lo)
+	bounds = make([]int, buckets+1)
+	for i := range bounds {
+		prodHi, prodLo := bits.Mul64(uint64(i), span)
+		offset, _ := bits.Div64(prodHi, prodLo, uint64(buckets))
+		bounds[i] = int(uint64(lo) + offset)
+	}
+	counts = make([]int64, buckets)
+	start := 0
+	for i := range counts {
+		end := len(data)
+		if i < buckets-1 {
+			end = start + sort.SearchInts(data[start:], bounds[i+1])
+		}
+		counts[i] = int64(end - start)
+		start = end
+	}
+	return bounds, counts, nil
+}
@@ -0,0 +1,118 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketizeSorted(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Data    []int
+		Buckets int
+		Bounds  []int
+		Counts  []int64
+		Err     string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			bounds, counts, err := BucketizeSorted(tc.Data, tc.Buckets)
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Bounds, bounds)
+			require.Equal(t, tc.Counts, counts)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Data:    []int{},
+		Buckets: 3,
+	})
+	validate(t, testCase{Name: "All equal",
+		Data:    []int{5, 5, 5},
+		Buckets: 4,
+		Bounds:  []int{5, 5},
+		Counts:  []int64{3},
+	})
+	validate(t, testCase{Name: "Even split",
+		Data:    []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+		Buckets: 4,
+		Bounds:  []int{0, 2, 4, 6, 8},
+		Counts:  []int64{2, 2, 2, 3},
+	})
+	validate(t, testCase{Name: "Values on inner edges go up",
+		Data:    []int{0, 5, 5, 10},
+		Buckets: 2,
+		Bounds:  []int{0, 5, 10},
+		Counts:  []int64{1, 3},
+	})
+	validate(t, testCase{Name: "Maximum in the last bucket",
+		Data:    []int{0, 10, 10},
+		Buckets: 2,
+		Bounds:  []int{0, 5, 10},
+		Counts:  []int64{1, 2},
+	})
+	validate(t, testCase{Name: "More buckets than values",
+		Data:    []int{0, 1},
+		Buckets: 3,
+		Bounds:  []int{0, 0, 0, 1},
+		Counts:  []int64{0, 0, 2},
+	})
+	validate(t, testCase{Name: "Full int range",
+		Data:    []int{math.MinInt, 0, math.MaxInt},
+		Buckets: 2,
+		Bounds:  []int{math.MinInt, -1, math.MaxInt},
+		Counts:  []int64{1, 2},
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Data:    []int{1, 3, 2},
+		Buckets: 2,
+		Err:     "index 2: 3 followed by 2",
+	})
+	validate(t, testCase{Name: "No buckets",
+		Data:    []int{1, 2},
+		Buckets: 0,
+		Err:     "bucketize: buckets 0 is less than 1",
+	})
+}
+
+func TestBucketizeSortedMatchesScan(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for range 500 {
+		data := make([]int, rng.Intn(200))
+		for i := range data {
+			data[i] = rng.Intn(100) - 50
+		}
+		slices.Sort(data)
+		buckets := 1 + rng.Intn(20)
+		bounds, counts, err := BucketizeSorted(data, buckets)
+		require.NoError(t, err)
+
+		var total int64
+		for _, c := range counts {
+			total += c
+		}
+		require.Equal(t, int64(len(data)), total)
+		if len(data) == 0 {
+			continue
+		}
+		require.Len(t, bounds, len(counts)+1)
+		want := make([]int64, len(counts))
+		for _, v := range data {
+			i := 0
+			for i < len(counts)-1 && v >= bounds[i+1] {
+				i++
+			}
+			want[i]++
+		}
+		require.Equal(t, want, counts, "data=%v buckets=%d", data, buckets)
+	}
+}
@@ -0,0 +1,53 @@
+package testdemo
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// IsSortedBy reports whether data is in non-decreasing order of key, the
+// projection of each element it is sorted on. Abs, Neg and BitCount are
+// ready-made keys for ints.
+func IsSortedBy[T any, K cmp.Ordered](data []T, key func(T) K) bool {
+	if len(data) == 0 {
+		return true
+	}
+	prev := key(data[0])
+	for _, v := range data[1:] {
+		k := key(v)
+		if k < prev {
+			return false
+		}
+		prev = k
+	}
+	return true
+}
+
+// IsSortedByAbs reports whether data is in non-decreasing order of
+// magnitude. It is IsSortedBy(data, Abs), so math.MinInt, whose
+// magnitude no int can hold, sorts after every other value.
+func IsSortedByAbs(data []int) bool {
+	return IsSortedBy(data, Abs)
+}
+
+// Abs returns the magnitude of v. It is a uint64 so that the magnitude of
+// math.MinInt does not overflow.
+func Abs(v int) uint64 {
+	u := uint64(v)
+	if v < 0 {
+		u = -u
+	}
+	return u
+}
+
+// Neg orders ints in reverse, making IsSortedBy check for non-increasing
+// order. It returns ^v, which is -v-1 and orders just like -v, but
+// without -math.MinInt overflowing to itself.
+func Neg(v int) int {
+	return ^v
+}
+
+// BitCount returns the number of one bits in v, in two's complement.
+func BitCount(v int) int {
+	return bits.OnesCount64(uint64(v))
+}
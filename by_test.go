@@ -0,0 +1,60 @@
+package testdemo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedByAbs(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := IsSortedByAbs(tc.Array)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Mixed signs by magnitude",
+		// Not sorted by value, but sorted by magnitude.
+		Array:    []int{0, -1, 2, -3, 3},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Sorted by value, not magnitude",
+		Array:    []int{-3, -1, 2},
+		Expected: false,
+	})
+	validate(t, testCase{Name: "MinInt is the largest magnitude",
+		Array:    []int{-1, math.MaxInt, math.MinInt},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "MinInt before MaxInt",
+		Array:    []int{math.MinInt, math.MaxInt},
+		Expected: false,
+	})
+}
+
+func TestAbs(t *testing.T) {
+	require.Equal(t, uint64(0), Abs(0))
+	require.Equal(t, uint64(5), Abs(-5))
+	require.Equal(t, uint64(math.MaxInt), Abs(math.MaxInt))
+	require.Equal(t, uint64(math.MaxInt)+1, Abs(math.MinInt))
+}
+
+func TestIsSortedByPresets(t *testing.T) {
+	require.True(t, IsSortedBy([]int{math.MaxInt, 3, 0, -1, math.MinInt}, Neg))
+	require.False(t, IsSortedBy([]int{math.MinInt, math.MaxInt}, Neg))
+	require.True(t, IsSortedBy([]int{0, 8, 3, 7, -1}, BitCount))
+	require.False(t, IsSortedBy([]int{3, 4}, BitCount))
+	require.True(t, IsSortedBy([]string{"b", "aa", "ccc"}, func(s string) int { return len(s) }))
+}
@@ -0,0 +1,38 @@
+package testdemo
+
+import (
+	"bytes"
+	"slices"
+)
+
+// IsSortedBytes reports whether data is sorted in non-decreasing
+// lexicographic order, as bytes.Compare orders it: byte by byte, with a
+// key before every longer key it is a prefix of, and nil equal to an
+// empty slice. The keys are compared in place, never copied.
+func IsSortedBytes(data [][]byte) bool {
+	for i := 1; i < len(data); i++ {
+		if bytes.Compare(data[i-1], data[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsStrictlySortedBytes is IsSortedBytes that also rejects equal keys,
+// such as nil next to an empty slice.
+func IsStrictlySortedBytes(data [][]byte) bool {
+	for i := 1; i < len(data); i++ {
+		if bytes.Compare(data[i-1], data[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchBytes returns the index of the first key in data not less than
+// key, or len(data) when there is none. data must be sorted as
+// IsSortedBytes checks.
+func SearchBytes(data [][]byte, key []byte) int {
+	i, _ := slices.BinarySearchFunc(data, key, bytes.Compare)
+	return i
+}
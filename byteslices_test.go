@@ -0,0 +1,85 @@
+package testdemo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedBytes(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    [][]byte
+		Sorted   bool
+		Strictly bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Sorted, IsSortedBytes(tc.Array), "sorted")
+			require.Equal(t, tc.Strictly, IsStrictlySortedBytes(tc.Array), "strictly sorted")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    [][]byte{},
+		Sorted:   true,
+		Strictly: true,
+	})
+	validate(t, testCase{Name: "Prefix before longer key",
+		Array:    [][]byte{[]byte("a"), []byte("ab"), []byte("abc"), []byte("b")},
+		Sorted:   true,
+		Strictly: true,
+	})
+	validate(t, testCase{Name: "Longer key before prefix",
+		Array: [][]byte{[]byte("ab"), []byte("a")},
+	})
+	validate(t, testCase{Name: "Nil equals empty",
+		Array:  [][]byte{nil, {}, nil},
+		Sorted: true,
+	})
+	validate(t, testCase{Name: "Nil before nonempty",
+		Array:    [][]byte{nil, {0}},
+		Sorted:   true,
+		Strictly: true,
+	})
+	validate(t, testCase{Name: "Bytes compare unsigned",
+		Array:    [][]byte{{0x7f}, {0x80}, {0xff}},
+		Sorted:   true,
+		Strictly: true,
+	})
+	validate(t, testCase{Name: "Duplicates",
+		Array:  [][]byte{[]byte("k"), []byte("k")},
+		Sorted: true,
+	})
+}
+
+func TestSearchBytes(t *testing.T) {
+	data := [][]byte{nil, []byte("a"), []byte("ab"), []byte("ab"), []byte("b")}
+	require.Equal(t, 0, SearchBytes(data, nil))
+	require.Equal(t, 0, SearchBytes(data, []byte{}))
+	require.Equal(t, 1, SearchBytes(data, []byte("a")))
+	require.Equal(t, 2, SearchBytes(data, []byte("aa")))
+	require.Equal(t, 2, SearchBytes(data, []byte("ab")))
+	require.Equal(t, 4, SearchBytes(data, []byte("abc")))
+	require.Equal(t, 5, SearchBytes(data, []byte("c")))
+	require.Equal(t, 0, SearchBytes(nil, []byte("a")))
+}
+
+func TestBytesLargeKeysAreNotCopied(t *testing.T) {
+	const size = 4 << 20
+	data := make([][]byte, 3)
+	for i := range data {
+		// The keys share a multi-megabyte prefix and differ in the last byte.
+		data[i] = bytes.Repeat([]byte{'x'}, size)
+		data[i][size-1] = byte('a' + i)
+	}
+	key := bytes.Clone(data[1])
+	allocs := testing.AllocsPerRun(10, func() {
+		require.True(t, IsSortedBytes(data))
+		require.True(t, IsStrictlySortedBytes(data))
+		require.Equal(t, 1, SearchBytes(data, key))
+	})
+	require.Zero(t, allocs)
+}
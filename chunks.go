@@ -0,0 +1,39 @@
+package testdemo
+
+import "fmt"
+
+// IsSortedChunks reports whether every chunk of data, taken chunkSize
+// elements at a time, is sorted on its own. The last chunk may be
+// shorter. Order across chunks is not checked; see
+// AreChunkBoundariesSorted. It panics if chunkSize is not positive.
+func IsSortedChunks(data []int, chunkSize int) bool {
+	checkChunkSize(chunkSize)
+	for start := 0; start < len(data); start += chunkSize {
+		end := min(start+chunkSize, len(data))
+		if !IsSorted(data[start:end]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AreChunkBoundariesSorted reports whether the last element of each chunk
+// of data, taken chunkSize elements at a time, is not greater than the
+// first element of the next chunk. The last chunk may be shorter. Order
+// within chunks is not checked; see IsSortedChunks. Both together are
+// IsSorted. It panics if chunkSize is not positive.
+func AreChunkBoundariesSorted(data []int, chunkSize int) bool {
+	checkChunkSize(chunkSize)
+	for first := chunkSize; first < len(data); first += chunkSize {
+		if data[first-1] > data[first] {
+			return false
+		}
+	}
+	return true
+}
+
+func checkChunkSize(chunkSize int) {
+	if chunkSize <= 0 {
+		panic(fmt.Sprintf("testdemo: chunk size %d is not positive", chunkSize))
+	}
+}
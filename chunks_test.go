@@ -0,0 +1,86 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkChecks(t *testing.T) {
+	type testCase struct {
+		Name       string
+		Array      []int
+		ChunkSize  int
+		Chunks     bool
+		Boundaries bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Chunks, IsSortedChunks(tc.Array, tc.ChunkSize), "IsSortedChunks")
+			require.Equal(t, tc.Boundaries, AreChunkBoundariesSorted(tc.Array, tc.ChunkSize), "AreChunkBoundariesSorted")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:      []int{},
+		ChunkSize:  3,
+		Chunks:     true,
+		Boundaries: true,
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array:      []int{1, 2, 3, 4, 5, 6},
+		ChunkSize:  2,
+		Chunks:     true,
+		Boundaries: true,
+	})
+	validate(t, testCase{Name: "Chunks sorted, boundaries not",
+		Array:      []int{4, 5, 6, 1, 2, 3},
+		ChunkSize:  3,
+		Chunks:     true,
+		Boundaries: false,
+	})
+	validate(t, testCase{Name: "Boundaries sorted, chunks not",
+		Array:      []int{1, 0, 2, 2, 5, 3},
+		ChunkSize:  3,
+		Chunks:     false,
+		Boundaries: true,
+	})
+	validate(t, testCase{Name: "Partial last chunk unsorted",
+		Array:      []int{1, 2, 3, 5, 4},
+		ChunkSize:  3,
+		Chunks:     false,
+		Boundaries: true,
+	})
+	validate(t, testCase{Name: "Partial last chunk below boundary",
+		Array:      []int{1, 2, 3, 0},
+		ChunkSize:  3,
+		Chunks:     true,
+		Boundaries: false,
+	})
+	validate(t, testCase{Name: "Chunk larger than data",
+		Array:      []int{2, 1},
+		ChunkSize:  10,
+		Chunks:     false,
+		Boundaries: true,
+	})
+}
+
+func TestChunkChecksTogetherAreIsSorted(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 1000; i++ {
+		data := make([]int, rng.Intn(12))
+		for j := range data {
+			data[j] = rng.Intn(4)
+		}
+		size := rng.Intn(5) + 1
+		require.Equal(t, IsSorted(data), IsSortedChunks(data, size) && AreChunkBoundariesSorted(data, size),
+			"%v chunk size %d", data, size)
+	}
+}
+
+func TestChunkChecksPanicOnBadSize(t *testing.T) {
+	require.PanicsWithValue(t, "testdemo: chunk size 0 is not positive", func() { IsSortedChunks([]int{1}, 0) })
+	require.PanicsWithValue(t, "testdemo: chunk size -1 is not positive", func() { AreChunkBoundariesSorted([]int{1}, -1) })
+}
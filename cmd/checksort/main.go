@@ -0,0 +1,105 @@
+// Command checksort checks whether a file is sorted, like sort -c.
+//
+// Usage:
+//
+//	checksort [-k column] [-t delimiter] [-n] [-r] [-header] [file]
+//
+// Without -k whole lines are compared. With -k the input is read as CSV
+// records split by -t (default ","), quoted fields included, and the
+// given 1-based column is compared. It reads the file, or stdin when
+// there is none or it is "-". It exits 0 when the input is sorted, 1 when
+// it is not, printing the line number and text of the first line out of
+// order to stderr, and 2 on bad usage or unreadable input.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/StevenACoffman/testdemo"
+)
+
+const (
+	exitSorted   = 0
+	exitUnsorted = 1
+	exitError    = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stderr io.Writer) int {
+	flags := flag.NewFlagSet("checksort", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		opts      testdemo.CSVOptions
+		delimiter string
+	)
+	flags.IntVar(&opts.Column, "k", 0, "compare the given 1-based `column` instead of whole lines")
+	flags.StringVar(&delimiter, "t", ",", "field `delimiter` for -k, a single character or \\t")
+	flags.BoolVar(&opts.Numeric, "n", false, "compare keys as numbers")
+	flags.BoolVar(&opts.Reverse, "r", false, "check for descending order")
+	flags.BoolVar(&opts.Header, "header", false, "skip the first line")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: checksort [-k column] [-t delimiter] [-n] [-r] [-header] [file]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSorted
+		}
+		return exitError
+	}
+	if flags.NArg() > 1 {
+		fmt.Fprintln(stderr, "checksort: at most one file can be checked")
+		flags.Usage()
+		return exitError
+	}
+	if delimiter == `\t` {
+		delimiter = "\t"
+	}
+	comma, size := utf8.DecodeRuneInString(delimiter)
+	if size == 0 || size != len(delimiter) {
+		fmt.Fprintf(stderr, "checksort: the delimiter must be a single character, got %q\n", delimiter)
+		return exitError
+	}
+	opts.Comma = comma
+
+	source, r := "-", stdin
+	if path := flags.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "checksort: %v\n", err)
+			return exitError
+		}
+		defer f.Close()
+		source, r = path, f
+	}
+
+	var err error
+	if opts.Column > 0 {
+		err = testdemo.CheckCSVColumn(r, opts)
+	} else {
+		err = testdemo.CheckLines(r, opts.LineOptions)
+	}
+	var unsorted *testdemo.UnsortedError
+	switch {
+	case err == nil:
+		return exitSorted
+	case errors.As(err, &unsorted):
+		text := unsorted.Text
+		if text == "" {
+			text = fmt.Sprint(unsorted.Next)
+		}
+		fmt.Fprintf(stderr, "checksort: %s:%d: disorder: %s\n", source, unsorted.Line, text)
+		return exitUnsorted
+	default:
+		fmt.Fprintf(stderr, "checksort: %s: %v\n", source, err)
+		return exitError
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"people.csv": "id,name,age\n3,\"Adams, Al\",40\n1,\"Brown, Bo\",9\n2,\"Brown, Cy\",100\n",
+		"words.txt":  "apple\nbanana\ncherry\n",
+		"scores.tsv": "name\tscore\nx\t30\ny\t20\nz\t25\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	type testCase struct {
+		Name           string
+		Args           []string
+		Stdin          string
+		ExpectedCode   int
+		ExpectedStderr string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			var stderr strings.Builder
+			code := run(tc.Args, strings.NewReader(tc.Stdin), &stderr)
+			require.Equal(t, tc.ExpectedCode, code, stderr.String())
+			if tc.ExpectedStderr != "" || code != 2 {
+				require.Equal(t, tc.ExpectedStderr, stderr.String())
+			}
+		})
+	}
+	validate(t, testCase{Name: "Whole lines",
+		Args:         []string{path("words.txt")},
+		ExpectedCode: 0,
+	})
+	validate(t, testCase{Name: "Whole lines reversed",
+		Args:           []string{"-r", path("words.txt")},
+		ExpectedCode:   1,
+		ExpectedStderr: "checksort: " + path("words.txt") + ":2: disorder: banana\n",
+	})
+	validate(t, testCase{Name: "Stdin",
+		Stdin:          "b\na\n",
+		ExpectedCode:   1,
+		ExpectedStderr: "checksort: -:2: disorder: a\n",
+	})
+	validate(t, testCase{Name: "Header would violate ordering",
+		Args:           []string{"-k", "2", path("people.csv")},
+		ExpectedCode:   1,
+		ExpectedStderr: "checksort: " + path("people.csv") + ":2: disorder: 3,\"Adams, Al\",40\n",
+	})
+	validate(t, testCase{Name: "Quoted column with header",
+		Args:         []string{"-k", "2", "--header", path("people.csv")},
+		ExpectedCode: 0,
+	})
+	validate(t, testCase{Name: "Numeric column",
+		Args:           []string{"-k", "3", "-n", "-header", path("people.csv")},
+		ExpectedCode:   1,
+		ExpectedStderr: "checksort: " + path("people.csv") + ":3: disorder: 1,\"Brown, Bo\",9\n",
+	})
+	validate(t, testCase{Name: "Byte-wise column",
+		Args:           []string{"-k", "3", "-header", path("people.csv")},
+		ExpectedCode:   1,
+		ExpectedStderr: "checksort: " + path("people.csv") + ":4: disorder: 2,\"Brown, Cy\",100\n",
+	})
+	validate(t, testCase{Name: "Tab delimiter",
+		Args:           []string{"-k", "2", "-t", `\t`, "-n", "-r", "--header", path("scores.tsv")},
+		ExpectedCode:   1,
+		ExpectedStderr: "checksort: " + path("scores.tsv") + ":4: disorder: z\t25\n",
+	})
+	validate(t, testCase{Name: "Invalid number",
+		Args:           []string{"-k", "2", "-n", path("people.csv")},
+		ExpectedCode:   2,
+		ExpectedStderr: "checksort: " + path("people.csv") + ": line 1: invalid number \"name\": strconv.ParseFloat: parsing \"name\": invalid syntax\n",
+	})
+	validate(t, testCase{Name: "Bad delimiter",
+		Args:           []string{"-k", "1", "-t", "ab"},
+		ExpectedCode:   2,
+		ExpectedStderr: "checksort: the delimiter must be a single character, got \"ab\"\n",
+	})
+	validate(t, testCase{Name: "Too many files",
+		Args:         []string{path("words.txt"), path("people.csv")},
+		ExpectedCode: 2,
+	})
+	validate(t, testCase{Name: "Missing file",
+		Args:           []string{path("missing.csv")},
+		ExpectedCode:   2,
+		ExpectedStderr: "checksort: open " + path("missing.csv") + ": no such file or directory\n",
+	})
+}
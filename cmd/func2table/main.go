@@ -0,0 +1,722 @@
+// Command func2table folds function-per-test tests, like those in
+// function_per_test.go, into table-driven tests in the Symflower style of
+// symflower_test.go.
+//
+// Usage:
+//
+//	func2table [-dir directory] [-out file] [-delete]
+//
+// It scans the test files of the package in the directory, "." by
+// default, for test functions of the arrange-act-assert shape: local
+// variables declared with :=, one call of the function under test, and a
+// final require.Equal(t, expected, actual), where every variable is used
+// once and nothing but that call is called. Tests calling the same
+// function with the same types are consolidated into one test with a
+// testCase per original, named after it. The result is gofmt-ed and goes
+// to func2table_test.go in the directory unless -out is given.
+//
+// Every test function it could not convert is listed on stdout with the
+// reason. With -delete a file whose test functions were all converted,
+// and which declares nothing else, is removed; other files are listed as
+// kept.
+//
+// It exits 0 when at least one test was converted, 1 when none was or the
+// package cannot be read, and 2 on bad usage.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// requirePath is the import path of the package the tests assert with.
+const requirePath = "github.com/stretchr/testify/require"
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("func2table", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", ".", "`directory` of the package to scan")
+	out := flags.String("out", "func2table_test.go", "test `file` to write, relative to -dir")
+	del := flags.Bool("delete", false, "remove files whose tests were all converted")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: func2table [-dir directory] [-out file] [-delete]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() != 0 || !strings.HasSuffix(*out, "_test.go") {
+		flags.Usage()
+		return exitUsage
+	}
+	outPath := filepath.Join(*dir, *out)
+
+	pkg, err := load(*dir, filepath.Base(outPath))
+	if err != nil {
+		fmt.Fprintln(stderr, "func2table:", err)
+		return exitError
+	}
+	result := pkg.convert()
+	for _, s := range result.Skipped {
+		fmt.Fprintln(stdout, s)
+	}
+	if len(result.Converted) == 0 {
+		fmt.Fprintln(stderr, "func2table: no test functions could be converted")
+		return exitError
+	}
+	src, err := pkg.generate(result.Converted)
+	if err != nil {
+		fmt.Fprintln(stderr, "func2table:", err)
+		return exitError
+	}
+	if err := os.WriteFile(outPath, src, 0o644); err != nil {
+		fmt.Fprintln(stderr, "func2table:", err)
+		return exitError
+	}
+	if !*del {
+		return exitOK
+	}
+	for _, f := range pkg.Files {
+		if len(f.tests()) == 0 {
+			continue
+		}
+		if reason := f.keep(result.Converted); reason != "" {
+			fmt.Fprintf(stdout, "kept %s: %s\n", f.Name, reason)
+			continue
+		}
+		if err := os.Remove(filepath.Join(*dir, f.Name)); err != nil {
+			fmt.Fprintln(stderr, "func2table:", err)
+			return exitError
+		}
+		fmt.Fprintf(stdout, "removed %s\n", f.Name)
+	}
+	return exitOK
+}
+
+// sourceFile is a parsed test file of the package.
+type sourceFile struct {
+	Name string
+	Src  []byte
+	AST  *ast.File
+}
+
+// text returns the source of node.
+func (f *sourceFile) text(fset *token.FileSet, node ast.Node) string {
+	tf := fset.File(f.AST.Pos())
+	return string(f.Src[tf.Offset(node.Pos()):tf.Offset(node.End())])
+}
+
+// tests returns the test functions f declares.
+func (f *sourceFile) tests() []*ast.FuncDecl {
+	var fns []*ast.FuncDecl
+	for _, decl := range f.AST.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && isTestName(fn.Name.Name) {
+			fns = append(fns, fn)
+		}
+	}
+	return fns
+}
+
+// keep returns why f has to be kept when converted are the tests that
+// were converted, or "" when it can be removed.
+func (f *sourceFile) keep(converted []*conversion) string {
+	done := map[*ast.FuncDecl]bool{}
+	for _, c := range converted {
+		done[c.Fn] = true
+	}
+	tests := f.tests()
+	n := 0
+	for _, fn := range tests {
+		if done[fn] {
+			n++
+		}
+	}
+	if n < len(tests) {
+		return fmt.Sprintf("%d of %d tests converted", n, len(tests))
+	}
+	for _, decl := range f.AST.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+		if fn, ok := decl.(*ast.FuncDecl); ok && done[fn] {
+			continue
+		}
+		return "it declares more than tests"
+	}
+	return ""
+}
+
+// pkg is the package func2table converts the tests of.
+type pkg struct {
+	Fset  *token.FileSet
+	Name  string
+	Types *types.Package
+	Info  *types.Info
+	// Files are the test files of the package, in name order.
+	Files []*sourceFile
+	// Skipped lists the test files of another package, such as an
+	// external test package.
+	Skipped []string
+}
+
+// load parses and type-checks the package in dir, leaving out the test
+// file called out. Type errors, such as in imports that cannot be
+// found, do not stop it: tests whose types are unknown are not
+// converted.
+func load(dir, out string) (*pkg, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{Fset: token.NewFileSet()}
+	var files []*ast.File
+	var tests []*sourceFile
+	for _, e := range entries { // ReadDir sorts by name
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || name == out {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(p.Fset, name, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(name, "_test.go") {
+			p.Name = file.Name.Name
+			files = append(files, file)
+			continue
+		}
+		tests = append(tests, &sourceFile{Name: name, Src: src, AST: file})
+	}
+	for _, f := range tests {
+		if p.Name == "" {
+			p.Name = f.AST.Name.Name
+		}
+		if f.AST.Name.Name != p.Name {
+			p.Skipped = append(p.Skipped, fmt.Sprintf("%s: package %s is not %s", f.Name, f.AST.Name.Name, p.Name))
+			continue
+		}
+		files = append(files, f.AST)
+		p.Files = append(p.Files, f)
+	}
+	if p.Name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	p.Info = &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Uses:  map[*ast.Ident]types.Object{},
+		Defs:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(p.Fset, "source", nil),
+		Error:    func(error) {},
+	}
+	if exports, err := exportData(dir); err == nil {
+		conf.Importer = importer.ForCompiler(p.Fset, "gc", func(path string) (io.ReadCloser, error) {
+			file, ok := exports[path]
+			if !ok {
+				return nil, fmt.Errorf("no export data for %s", path)
+			}
+			return os.Open(file)
+		})
+	}
+	p.Types, _ = conf.Check(p.Name, p.Fset, files, p.Info)
+	return p, nil
+}
+
+// exportData returns the export data files of the packages that the
+// package in dir and its tests depend on, keyed by import path. It runs
+// go list, which builds them, and is much faster than type-checking the
+// dependencies from source once they are in the build cache.
+func exportData(dir string) (map[string]string, error) {
+	cmd := exec.Command("go", "list", "-e", "-export", "-deps", "-test", "-f", "{{if .Export}}{{.ImportPath}}={{.Export}}{{end}}", ".")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	exports := map[string]string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		path, file, ok := strings.Cut(line, "=")
+		if ok && !strings.Contains(path, " ") { // leave out test variants
+			exports[path] = file
+		}
+	}
+	return exports, nil
+}
+
+// conversion is a test function of the arrange-act-assert shape.
+type conversion struct {
+	File *sourceFile
+	Fn   *ast.FuncDecl
+	// Call is the call of the function under test, and Args the
+	// expressions its arguments come from, with the variables holding
+	// them replaced by their values.
+	Call *ast.CallExpr
+	Args []ast.Expr
+	// ArgNames are the names of the variables holding the arguments, or
+	// "" for arguments given directly.
+	ArgNames []string
+	Params   []types.Type
+	Expected ast.Expr
+	Result   types.Type
+}
+
+// result lists the tests convert converted and the reasons for the ones
+// it did not.
+type result struct {
+	Converted []*conversion
+	Skipped   []string
+}
+
+func (p *pkg) convert() result {
+	res := result{Skipped: p.Skipped}
+	for _, f := range p.Files {
+		for _, fn := range f.tests() {
+			c, reason := p.match(f, fn)
+			if reason != "" {
+				pos := p.Fset.Position(fn.Pos())
+				res.Skipped = append(res.Skipped, fmt.Sprintf("%s:%d: %s: %s", f.Name, pos.Line, fn.Name.Name, reason))
+				continue
+			}
+			res.Converted = append(res.Converted, c)
+		}
+	}
+	return res
+}
+
+// match returns fn as a conversion, or why it does not have the
+// arrange-act-assert shape.
+func (p *pkg) match(f *sourceFile, fn *ast.FuncDecl) (*conversion, string) {
+	params := fn.Type.Params.List
+	if fn.Type.TypeParams != nil || fn.Type.Results != nil || len(params) != 1 || len(params[0].Names) != 1 {
+		return nil, "it is not a func(t *testing.T)"
+	}
+	tName := params[0].Names[0].Name
+	stmts := fn.Body.List
+	if len(stmts) == 0 {
+		return nil, "it is empty"
+	}
+
+	// Arrange: every statement but the last declares one variable.
+	values := map[string]ast.Expr{}
+	uses := map[string]int{}
+	for _, stmt := range stmts[:len(stmts)-1] {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return nil, fmt.Sprintf("line %d is not a single := declaration", p.Fset.Position(stmt.Pos()).Line)
+		}
+		id, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok || id.Name == "_" || values[id.Name] != nil {
+			return nil, fmt.Sprintf("line %d does not declare a new variable", p.Fset.Position(stmt.Pos()).Line)
+		}
+		values[id.Name] = assign.Rhs[0]
+	}
+	resolve := func(e ast.Expr) (ast.Expr, string) {
+		if id, ok := e.(*ast.Ident); ok && values[id.Name] != nil {
+			uses[id.Name]++
+			return values[id.Name], id.Name
+		}
+		return e, ""
+	}
+
+	// Assert: require.Equal(t, expected, actual).
+	stmt, ok := stmts[len(stmts)-1].(*ast.ExprStmt)
+	var assert *ast.CallExpr
+	if ok {
+		assert, _ = stmt.X.(*ast.CallExpr)
+	}
+	if assert == nil || !p.isRequireEqual(assert.Fun) {
+		return nil, "it does not end with require.Equal"
+	}
+	if len(assert.Args) != 3 || !isIdent(assert.Args[0], tName) {
+		return nil, fmt.Sprintf("require.Equal is not called with %s, expected and actual only", tName)
+	}
+	expected, _ := resolve(assert.Args[1])
+	actual, _ := resolve(assert.Args[2])
+
+	// Act: one call of the function under test.
+	call, ok := actual.(*ast.CallExpr)
+	if !ok || p.Info.Types[call.Fun].IsType() {
+		return nil, "the actual value is not the result of a call"
+	}
+	if _, ok := p.Info.Uses[calledName(call.Fun)].(*types.Func); !ok {
+		return nil, "it does not call a declared function"
+	}
+	sig, ok := p.Info.TypeOf(call.Fun).(*types.Signature)
+	if !ok || sig.Results().Len() != 1 {
+		return nil, "the function under test does not return one value"
+	}
+	if sig.Variadic() {
+		return nil, "the function under test is variadic"
+	}
+	c := &conversion{File: f, Fn: fn, Call: call, Expected: expected}
+	for i, arg := range call.Args {
+		value, name := resolve(arg)
+		c.Args = append(c.Args, value)
+		c.ArgNames = append(c.ArgNames, name)
+		c.Params = append(c.Params, sig.Params().At(i).Type())
+	}
+	exprs := append([]ast.Expr{c.Expected, c.Call.Fun}, c.Args...)
+	for _, e := range exprs {
+		if reason := p.checkMovable(e, values, tName); reason != "" {
+			return nil, reason
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if uses[name] != 1 {
+			return nil, fmt.Sprintf("%s is not used exactly once", name)
+		}
+	}
+	c.Result = types.Default(p.Info.TypeOf(c.Expected))
+	if c.Result == nil || c.Result == types.Typ[types.Invalid] || c.Result == types.Typ[types.UntypedNil] {
+		return nil, "the type of the expected value is not known"
+	}
+	for _, param := range c.Params {
+		if param == types.Typ[types.Invalid] {
+			return nil, "the parameter types of the function under test are not known"
+		}
+	}
+	return c, ""
+}
+
+// checkMovable returns why e cannot be moved into a testCase, or "" when
+// it can: it must not use local variables, t or call functions.
+func (p *pkg) checkMovable(e ast.Expr, locals map[string]ast.Expr, tName string) string {
+	reason := ""
+	ast.Inspect(e, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.Ident:
+			if locals[n.Name] != nil || n.Name == tName {
+				reason = fmt.Sprintf("%s is used in another variable or the call", n.Name)
+			}
+		case *ast.CallExpr:
+			if !p.Info.Types[n.Fun].IsType() {
+				reason = "it calls more than the function under test"
+			}
+		case *ast.FuncLit:
+			reason = "it declares a function literal"
+		case *ast.SelectorExpr:
+			ast.Inspect(n.X, func(node ast.Node) bool {
+				if reason != "" {
+					return false
+				}
+				if id, ok := node.(*ast.Ident); ok && (locals[id.Name] != nil || id.Name == tName) {
+					reason = fmt.Sprintf("%s is used in another variable or the call", id.Name)
+				}
+				return true
+			})
+			return false
+		}
+		return reason == ""
+	})
+	return reason
+}
+
+// isRequireEqual reports whether fun is testify's require.Equal.
+func (p *pkg) isRequireEqual(fun ast.Expr) bool {
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Equal" {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	pkgName, ok := p.Info.Uses[x].(*types.PkgName)
+	if !ok {
+		return x.Name == "require"
+	}
+	return pkgName.Imported().Path() == requirePath
+}
+
+// calledName returns the identifier naming the function fun calls.
+func calledName(fun ast.Expr) *ast.Ident {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	case *ast.IndexExpr:
+		return calledName(f.X)
+	case *ast.IndexListExpr:
+		return calledName(f.X)
+	}
+	return nil
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// group is the conversions consolidated into one test.
+type group struct {
+	Key   string
+	Tests []*conversion
+}
+
+// groups returns converted grouped by the function they call and the
+// types of its arguments and expected value, in the order of the first
+// test of each group.
+func (p *pkg) groups(converted []*conversion) []*group {
+	var groups []*group
+	byKey := map[string]*group{}
+	for _, c := range converted {
+		key := c.File.text(p.Fset, c.Call.Fun)
+		for _, t := range append(c.Params, c.Result) {
+			key += "," + t.String()
+		}
+		g := byKey[key]
+		if g == nil {
+			g = &group{Key: key}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Tests = append(g.Tests, c)
+	}
+	return groups
+}
+
+// generate returns the gofmt-ed source of the test file holding the
+// consolidated tests.
+func (p *pkg) generate(converted []*conversion) ([]byte, error) {
+	imports := map[string]string{"testing": "testing", requirePath: "require"}
+	qualifier := func(other *types.Package) string {
+		if other == nil || other == p.Types || other.Path() == p.Types.Path() {
+			return ""
+		}
+		imports[other.Path()] = other.Name()
+		return other.Name()
+	}
+	taken := map[string]bool{}
+	if p.Types != nil {
+		for _, name := range p.Types.Scope().Names() {
+			taken[name] = true
+		}
+	}
+
+	var body bytes.Buffer
+	for _, g := range p.groups(converted) {
+		first := g.Tests[0]
+		for _, c := range g.Tests {
+			p.collectImports(c, imports)
+		}
+		prefix, names := caseNames(g.Tests)
+		name := "Test" + prefix
+		if fn := calledName(first.Call.Fun).Name; !strings.Contains(prefix, fn) {
+			name += fn
+		}
+		for taken[name] {
+			name += "Table"
+		}
+		taken[name] = true
+		fields := fieldNames(first.ArgNames)
+
+		files := []string{}
+		for _, c := range g.Tests {
+			if len(files) == 0 || files[len(files)-1] != c.File.Name {
+				files = append(files, c.File.Name)
+			}
+		}
+		fmt.Fprintf(&body, "\n// %s consolidates the tests of %s from %s.\n", name, first.File.text(p.Fset, first.Call.Fun), strings.Join(files, ", "))
+		fmt.Fprintf(&body, "func %s(t *testing.T) {\n\ttype testCase struct {\n\t\tName string\n", name)
+		for i, field := range fields {
+			fmt.Fprintf(&body, "\t\t%s %s\n", field, types.TypeString(first.Params[i], qualifier))
+		}
+		fmt.Fprintf(&body, "\t\tExpected %s\n\t}\n", types.TypeString(first.Result, qualifier))
+		body.WriteString("\tvalidate := func(t *testing.T, tc testCase) {\n\t\tt.Helper()\n\t\tt.Run(tc.Name, func(t *testing.T) {\n\t\t\tt.Helper()\n")
+		args := make([]string, len(fields))
+		for i, field := range fields {
+			args[i] = "tc." + field
+		}
+		fmt.Fprintf(&body, "\t\t\tactual := %s(%s)\n", first.File.text(p.Fset, first.Call.Fun), strings.Join(args, ", "))
+		fmt.Fprintf(&body, "\t\t\t%s.Equal(t, tc.Expected, actual)\n\t\t})\n\t}\n", imports[requirePath])
+		for i, c := range g.Tests {
+			if c.Fn.Doc != nil {
+				for _, comment := range c.Fn.Doc.List {
+					fmt.Fprintf(&body, "\t%s\n", comment.Text)
+				}
+			}
+			fmt.Fprintf(&body, "\tvalidate(t, testCase{Name: %q,\n", names[i])
+			for j, field := range fields {
+				fmt.Fprintf(&body, "\t\t%s: %s,\n", field, c.File.text(p.Fset, c.Args[j]))
+			}
+			fmt.Fprintf(&body, "\t\tExpected: %s,\n\t})\n", c.File.text(p.Fset, c.Expected))
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", p.Name)
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, ip := range paths {
+		if name := imports[ip]; name != importName(ip) {
+			fmt.Fprintf(&buf, "\t%s %q\n", name, ip)
+		} else {
+			fmt.Fprintf(&buf, "\t%q\n", ip)
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// collectImports adds the packages the expressions moved out of c use,
+// and require, to imports.
+func (p *pkg) collectImports(c *conversion, imports map[string]string) {
+	nodes := []ast.Node{c.Fn.Body.List[len(c.Fn.Body.List)-1], c.Call.Fun, c.Expected}
+	for _, arg := range c.Args {
+		nodes = append(nodes, arg)
+	}
+	for _, node := range nodes {
+		ast.Inspect(node, func(node ast.Node) bool {
+			if id, ok := node.(*ast.Ident); ok {
+				if pkgName, ok := p.Info.Uses[id].(*types.PkgName); ok {
+					imports[pkgName.Imported().Path()] = pkgName.Name()
+				}
+			}
+			return true
+		})
+	}
+}
+
+// fieldNames returns the testCase field names of the arguments, named
+// after the variables that held them in the first test of a group.
+func fieldNames(argNames []string) []string {
+	fields := make([]string, len(argNames))
+	seen := map[string]bool{"Name": true, "Expected": true}
+	for i, name := range argNames {
+		field := ""
+		if name != "" {
+			field = strings.ToUpper(name[:1]) + name[1:]
+		}
+		if field == "" || seen[field] || !ast.IsExported(field) {
+			field = fmt.Sprintf("Arg%d", i+1)
+		}
+		seen[field] = true
+		fields[i] = field
+	}
+	return fields
+}
+
+// caseNames returns the words the names of tests start with, which the
+// consolidated test is named after, and the case name of each test: the
+// rest of its name as a sentence, such as "Empty is sorted" for
+// TestPerFunctionEmptyIsSorted next to TestPerFunctionOneElementIsSorted.
+func caseNames(tests []*conversion) (string, []string) {
+	words := make([][]string, len(tests))
+	for i, c := range tests {
+		words[i] = splitWords(strings.TrimPrefix(c.Fn.Name.Name, "Test"))
+	}
+	common := 0
+	if len(tests) > 1 {
+		common = len(words[0]) - 1
+		for _, w := range words[1:] {
+			common = min(common, len(w)-1)
+			for j := 0; j < common; j++ {
+				if w[j] != words[0][j] {
+					common = j
+					break
+				}
+			}
+		}
+	}
+	names := make([]string, len(tests))
+	for i, w := range words {
+		rest := w[common:]
+		for j := 1; j < len(rest); j++ {
+			if !isAcronym(rest[j]) {
+				rest[j] = strings.ToLower(rest[j])
+			}
+		}
+		names[i] = strings.Join(rest, " ")
+		if names[i] == "" {
+			names[i] = tests[i].Fn.Name.Name
+		}
+	}
+	return strings.Join(words[0][:common], ""), names
+}
+
+// splitWords splits a CamelCase name into its words, keeping acronyms
+// such as JSON in one word and digits with the word before them.
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		r, prev := runes[i], runes[i-1]
+		if !unicode.IsUpper(r) {
+			continue
+		}
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+func isAcronym(word string) bool {
+	return len(word) > 1 && strings.ToUpper(word) == word
+}
+
+// isTestName reports whether name is the name of a test function.
+func isTestName(name string) bool {
+	rest, ok := strings.CutPrefix(name, "Test")
+	return ok && (rest == "" || !unicode.IsLower([]rune(rest)[0]))
+}
+
+// importName returns the name a package is imported as by default: the
+// last element of its path, or the one before a major version suffix
+// such as v2.
+func importName(p string) string {
+	name := path.Base(p)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(p))
+	}
+	return name
+}
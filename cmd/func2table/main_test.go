@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// copyDir copies the files of the package in testdata/name into a new
+// directory and returns it. The repository's go.mod and go.sum go with
+// them, so go list finds the dependencies there as well.
+func copyDir(t *testing.T, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, file := range []string{"go.mod", "go.sum"} {
+		b, err := os.ReadFile(filepath.Join("..", "..", file))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), b, 0o644))
+	}
+	entries, err := os.ReadDir(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join("testdata", name, e.Name()))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, e.Name()), b, 0o644))
+	}
+	return dir
+}
+
+// checkGolden compares actual with the golden file testdata/name, or
+// rewrites it with -update.
+func checkGolden(t *testing.T, name, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0o644))
+		return
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), actual, "rerun with -update if the change is intended")
+}
+
+// vetWith runs go vet on the package in testdata/name with each file in
+// files replacing the one of the same name, or removing it when the name
+// maps to "". It checks that the generated tests compile.
+func vetWith(t *testing.T, name string, files map[string]string) {
+	t.Helper()
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	replace := map[string]string{}
+	for file, with := range files {
+		target, err := filepath.Abs(filepath.Join("testdata", name, file))
+		require.NoError(t, err)
+		replace[target] = with
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{"Replace": replace})
+	require.NoError(t, err)
+	overlayFile := filepath.Join(t.TempDir(), "overlay.json")
+	require.NoError(t, os.WriteFile(overlayFile, overlay, 0o644))
+	out, err := exec.Command(goTool, "vet", "-overlay", overlayFile, "./"+filepath.ToSlash(filepath.Join("testdata", name))).CombinedOutput()
+	require.NoError(t, err, "%s", out)
+}
+
+func TestRun(t *testing.T) {
+	type testCase struct {
+		Name string
+		// Dir is the package in testdata to run on, and Golden the
+		// prefix of the golden files of the output file and stdout.
+		Dir    string
+		Golden string
+		Args   []string
+		// ExpectedRemoved are the files -delete removes.
+		ExpectedRemoved []string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			dir := copyDir(t, tc.Dir)
+			var stdout, stderr strings.Builder
+			code := run(append([]string{"-dir", dir}, tc.Args...), &stdout, &stderr)
+			require.Equal(t, exitOK, code, stderr.String())
+			require.Empty(t, stderr.String())
+			checkGolden(t, tc.Golden+".stdout.golden", stdout.String())
+			out := filepath.Join(dir, "func2table_test.go")
+			src, err := os.ReadFile(out)
+			require.NoError(t, err)
+			checkGolden(t, tc.Golden+".go.golden", string(src))
+
+			// Running again gives the same file, as the output is left
+			// out of the scan.
+			if len(tc.ExpectedRemoved) == 0 {
+				require.Equal(t, exitOK, run(append([]string{"-dir", dir}, tc.Args...), &stdout, &stderr))
+				again, err := os.ReadFile(out)
+				require.NoError(t, err)
+				require.Equal(t, string(src), string(again))
+			}
+
+			files := map[string]string{"func2table_test.go": out}
+			for _, name := range tc.ExpectedRemoved {
+				require.NoFileExists(t, filepath.Join(dir, name))
+				files[name] = ""
+			}
+			vetWith(t, tc.Dir, files)
+		})
+	}
+	validate(t, testCase{Name: "Function per test",
+		Dir:    "perfunction",
+		Golden: "perfunction",
+	})
+	validate(t, testCase{Name: "Function per test deleted",
+		Dir:             "perfunction",
+		Golden:          "perfunction_delete",
+		Args:            []string{"-delete"},
+		ExpectedRemoved: []string{"function_per_test.go"},
+	})
+	validate(t, testCase{Name: "Mixed",
+		Dir:    "mixed",
+		Golden: "mixed",
+		Args:   []string{"-delete"},
+	})
+}
+
+func TestRunNothingToConvert(t *testing.T) {
+	dir := t.TempDir()
+	src := "package x\n\nimport \"testing\"\n\nfunc TestX(t *testing.T) { t.Log(1) }\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x_test.go"), []byte(src), 0o644))
+	var stdout, stderr strings.Builder
+	require.Equal(t, exitError, run([]string{"-dir", dir, "-delete"}, &stdout, &stderr))
+	require.Equal(t, "x_test.go:5: TestX: it does not end with require.Equal\n", stdout.String())
+	require.Equal(t, "func2table: no test functions could be converted\n", stderr.String())
+	require.FileExists(t, filepath.Join(dir, "x_test.go"))
+	require.NoFileExists(t, filepath.Join(dir, "func2table_test.go"))
+}
+
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr strings.Builder
+	require.Equal(t, exitUsage, run([]string{"-out", "table.go"}, &stdout, &stderr))
+	require.Contains(t, stderr.String(), "usage: func2table")
+	stderr.Reset()
+	require.Equal(t, exitError, run([]string{"-dir", filepath.Join(t.TempDir(), "missing")}, &stdout, &stderr))
+	require.Contains(t, stderr.String(), "func2table: ")
+}
+
+func TestCaseNames(t *testing.T) {
+	require.Equal(t, []string{"Per", "Function", "JSON", "Key2", "Is", "Sorted"}, splitWords("PerFunctionJSONKey2IsSorted"))
+	require.Equal(t, []string{"A"}, splitWords("A"))
+
+	tests := func(names ...string) []*conversion {
+		var cs []*conversion
+		for _, name := range names {
+			cs = append(cs, &conversion{Fn: &ast.FuncDecl{Name: ast.NewIdent(name)}})
+		}
+		return cs
+	}
+	prefix, names := caseNames(tests("TestSortEmpty", "TestSortUTF8Runes"))
+	require.Equal(t, "Sort", prefix)
+	require.Equal(t, []string{"Empty", "UTF8 runes"}, names)
+
+	// A name is never shortened to nothing.
+	prefix, names = caseNames(tests("TestSort", "TestSortEmpty"))
+	require.Equal(t, "", prefix)
+	require.Equal(t, []string{"Sort", "Sort empty"}, names)
+
+	prefix, names = caseNames(tests("TestSortEmpty"))
+	require.Equal(t, "", prefix)
+	require.Equal(t, []string{"Sort empty"}, names)
+}
@@ -0,0 +1,12 @@
+package mixed_test
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/cmd/func2table/testdata/mixed"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalUpper(t *testing.T) {
+	require.Equal(t, "A", mixed.Upper("a"))
+}
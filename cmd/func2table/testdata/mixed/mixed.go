@@ -0,0 +1,17 @@
+package mixed
+
+import "strings"
+
+// Upper returns s in upper case.
+func Upper(s string) string { return strings.ToUpper(s) }
+
+// Sum returns the sum of values.
+func Sum(values []int64, start int64) int64 {
+	for _, v := range values {
+		start += v
+	}
+	return start
+}
+
+// Join joins parts with sep.
+func Join(sep string, parts ...string) string { return strings.Join(parts, sep) }
@@ -0,0 +1,59 @@
+package mixed
+
+import (
+	r "github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+// TestSumOfNothing starts from the start value.
+func TestSumOfNothing(t *testing.T) {
+	values := []int64(nil)
+	actual := Sum(values, 7)
+	expected := int64(7)
+	r.Equal(t, expected, actual)
+}
+
+func TestSumNearTheTop(t *testing.T) {
+	r.Equal(t, int64(math.MaxInt64), Sum([]int64{math.MaxInt64 - 1}, 1))
+}
+
+func TestUpperASCII(t *testing.T) {
+	s := "abc"
+	r.Equal(t, "ABC", Upper(s))
+}
+
+func TestUpperJSONKey(t *testing.T) {
+	key := `"id"`
+	r.Equal(t, `"ID"`, Upper(key))
+}
+
+func TestUpperTwice(t *testing.T) {
+	s := "abc"
+	r.Equal(t, "ABC", Upper(Upper(s)))
+}
+
+func TestUpperReused(t *testing.T) {
+	s := "ABC"
+	r.Equal(t, s, Upper(s))
+}
+
+func TestJoinVariadic(t *testing.T) {
+	r.Equal(t, "a,b", Join(",", "a", "b"))
+}
+
+func TestSumLog(t *testing.T) {
+	actual := Sum(nil, 1)
+	t.Log(actual)
+	r.Equal(t, int64(1), actual)
+}
+
+func TestSumMessage(t *testing.T) {
+	r.Equal(t, int64(1), Sum(nil, 1), "sum")
+}
+
+func TestSumUsesTB(t *testing.T) {
+	r.Equal(t, int64(len(t.Name())), Sum(nil, int64(len(t.Name()))))
+}
+
+func helper() int64 { return 1 }
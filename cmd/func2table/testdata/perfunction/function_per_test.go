@@ -0,0 +1,32 @@
+package testdemo
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestPerFunctionEmptyIsSorted(t *testing.T) {
+	data := []int(nil)
+	actual := IsSorted(data)
+	expected := true
+	require.Equal(t, expected, actual)
+}
+func TestPerFunctionOneElementIsSorted(t *testing.T) {
+	data := []int{0}
+	actual := IsSorted(data)
+	expected := true
+	require.Equal(t, expected, actual)
+}
+func TestPerFunctionUnsortedIsNotSorted(t *testing.T) {
+	data := []int{0, math.MinInt}
+	actual := IsSorted(data)
+	expected := false
+	require.Equal(t, expected, actual)
+}
+func TestPerFunctionTwoEqualIsSorted(t *testing.T) {
+	data := []int{0, 0}
+	actual := IsSorted(data)
+	expected := true
+	require.Equal(t, expected, actual)
+}
@@ -0,0 +1,11 @@
+package testdemo
+
+// IsSorted reports whether data is sorted in non-decreasing order.
+func IsSorted(data []int) bool {
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+// Command issorted checks whether whitespace-separated numbers are sorted.
+//
+// Usage:
+//
+//	issorted [-desc] [-strict] [-float] [-quiet] [file ...]
+//
+// It reads the named files, or stdin when there are none ("-" also means
+// stdin), one value at a time. It exits 0 when every input is sorted, 1
+// when one is not, printing where the first offending pair is to stderr
+// unless -quiet is given, and 2 on bad usage or unreadable input.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/StevenACoffman/testdemo"
+)
+
+const (
+	exitSorted   = 0
+	exitUnsorted = 1
+	exitError    = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("issorted", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		opts  testdemo.ReaderOptions
+		quiet bool
+	)
+	flags.BoolVar(&opts.Descending, "desc", false, "check for descending order")
+	flags.BoolVar(&opts.Strict, "strict", false, "treat equal neighbors as out of order")
+	flags.BoolVar(&opts.Float, "float", false, "parse values as floating point numbers")
+	flags.BoolVar(&quiet, "quiet", false, "do not report where the input is unsorted")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: issorted [-desc] [-strict] [-float] [-quiet] [file ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSorted
+		}
+		return exitError
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	code := exitSorted
+	for _, path := range paths {
+		err := check(path, stdin, opts)
+		var unsorted *testdemo.UnsortedError
+		switch {
+		case err == nil:
+		case errors.As(err, &unsorted):
+			if !quiet {
+				fmt.Fprintf(stderr, "issorted: %v\n", err)
+			}
+			if code == exitSorted {
+				code = exitUnsorted
+			}
+		default:
+			fmt.Fprintf(stderr, "issorted: %v\n", err)
+			code = exitError
+		}
+	}
+	return code
+}
+
+// check checks the file at path, or stdin for "-".
+func check(path string, stdin io.Reader, opts testdemo.ReaderOptions) error {
+	source, r := "stdin", stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		source, r = path, f
+	}
+	err := testdemo.CheckReader(r, opts)
+	var unsorted *testdemo.UnsortedError
+	if errors.As(err, &unsorted) {
+		unsorted.Source = source
+		return unsorted
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	return nil
+}
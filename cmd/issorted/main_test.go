@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"sorted.txt":   "1 2\n2 3\n",
+		"unsorted.txt": "1\n5\n4\n",
+		"desc.txt":     "3 2 2 1\n",
+		"floats.txt":   "0.1 0.2\n0.15\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	type testCase struct {
+		Name           string
+		Args           []string
+		Stdin          string
+		ExpectedCode   int
+		ExpectedStderr string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			var stdout, stderr strings.Builder
+			code := run(tc.Args, strings.NewReader(tc.Stdin), &stdout, &stderr)
+			require.Equal(t, tc.ExpectedCode, code, stderr.String())
+			require.Empty(t, stdout.String())
+			require.Equal(t, tc.ExpectedStderr, stderr.String())
+		})
+	}
+	validate(t, testCase{Name: "Sorted stdin",
+		Stdin:        "1 2 3\n",
+		ExpectedCode: 0,
+	})
+	validate(t, testCase{Name: "Unsorted stdin",
+		Stdin:          "1 3 2\n",
+		ExpectedCode:   1,
+		ExpectedStderr: "issorted: stdin: line 1, index 2: 3 followed by 2\n",
+	})
+	validate(t, testCase{Name: "Quiet",
+		Args:         []string{"-quiet"},
+		Stdin:        "1 3 2\n",
+		ExpectedCode: 1,
+	})
+	validate(t, testCase{Name: "Sorted file",
+		Args:         []string{path("sorted.txt")},
+		ExpectedCode: 0,
+	})
+	validate(t, testCase{Name: "Strict flips duplicates",
+		Args:           []string{"-strict", path("sorted.txt")},
+		ExpectedCode:   1,
+		ExpectedStderr: "issorted: " + path("sorted.txt") + ": line 2, index 2: 2 followed by 2\n",
+	})
+	validate(t, testCase{Name: "Every file is checked",
+		Args:           []string{path("unsorted.txt"), path("sorted.txt"), "-"},
+		Stdin:          "2 1",
+		ExpectedCode:   1,
+		ExpectedStderr: "issorted: " + path("unsorted.txt") + ": line 3, index 2: 5 followed by 4\nissorted: stdin: line 1, index 1: 2 followed by 1\n",
+	})
+	validate(t, testCase{Name: "Descending",
+		Args:         []string{"-desc", path("desc.txt")},
+		ExpectedCode: 0,
+	})
+	validate(t, testCase{Name: "Descending strict",
+		Args:           []string{"-desc", "-strict", path("desc.txt")},
+		ExpectedCode:   1,
+		ExpectedStderr: "issorted: " + path("desc.txt") + ": line 1, index 2: 2 followed by 2\n",
+	})
+	validate(t, testCase{Name: "Floats",
+		Args:           []string{"-float", path("floats.txt")},
+		ExpectedCode:   1,
+		ExpectedStderr: "issorted: " + path("floats.txt") + ": line 2, index 2: 0.2 followed by 0.15\n",
+	})
+	validate(t, testCase{Name: "Floats without -float",
+		Args:           []string{path("floats.txt")},
+		ExpectedCode:   2,
+		ExpectedStderr: "issorted: " + path("floats.txt") + ": line 1: invalid number \"0.1\": strconv.ParseInt: parsing \"0.1\": invalid syntax\n",
+	})
+	validate(t, testCase{Name: "Missing file",
+		Args:           []string{path("missing.txt")},
+		ExpectedCode:   2,
+		ExpectedStderr: "issorted: open " + path("missing.txt") + ": no such file or directory\n",
+	})
+	validate(t, testCase{Name: "Error wins over unsorted",
+		Args:           []string{"-quiet", path("unsorted.txt"), path("missing.txt")},
+		ExpectedCode:   2,
+		ExpectedStderr: "issorted: open " + path("missing.txt") + ": no such file or directory\n",
+	})
+}
+
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr strings.Builder
+	code := run([]string{"-bogus"}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr.String(), "usage: issorted")
+
+	stderr.Reset()
+	code = run([]string{"-h"}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, 0, code)
+	require.Contains(t, stderr.String(), "-strict")
+}
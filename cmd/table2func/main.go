@@ -0,0 +1,451 @@
+// Command table2func turns table-driven tests into one test function per
+// table row, in the style of function_per_test.go.
+//
+// Usage:
+//
+//	table2func [-out file] file_test.go
+//
+// A table-driven test is a test function that declares a []struct table
+// literal and ranges over it once, like those in std_go_test.go. Every
+// row becomes a test function that declares the row as the loop variable
+// and runs the loop body, named after the original test and either the
+// row's name field, when the struct has a string field called name or
+// desc, or else the row's values. Comments on a row become the doc
+// comment of its function, and other comments are kept where they were.
+// Test functions that do not fit the pattern, or whose loop body uses
+// break, continue or goto, are left out.
+//
+// The output goes to the input file with _test.go replaced by
+// _pergen_test.go unless -out is given. It is gofmt-ed and regenerating
+// it from the same input writes the same bytes, so it can be kept up to
+// date with go generate:
+//
+//	//go:generate go run ./cmd/table2func std_go_test.go
+//
+// It exits 0 on success, 1 when the input cannot be read, parsed or holds
+// no table-driven tests, and 2 on bad usage.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// maxCaseName is the longest case name derived from a row, so a row of
+// long slices does not make an unreadable function name.
+const maxCaseName = 40
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("table2func", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	out := flags.String("out", "", "test `file` to write (default: the input with _test.go replaced by _pergen_test.go)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: table2func [-out file] file_test.go")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() != 1 || !strings.HasSuffix(flags.Arg(0), "_test.go") {
+		flags.Usage()
+		return exitUsage
+	}
+	in := flags.Arg(0)
+	if *out == "" {
+		*out = strings.TrimSuffix(in, "_test.go") + "_pergen_test.go"
+	}
+
+	src, err := os.ReadFile(in)
+	if err != nil {
+		fmt.Fprintln(stderr, "table2func:", err)
+		return exitError
+	}
+	gen, err := generate(filepath.Base(in), src)
+	if err != nil {
+		fmt.Fprintln(stderr, "table2func:", err)
+		return exitError
+	}
+	if err := os.WriteFile(*out, gen, 0o644); err != nil {
+		fmt.Fprintln(stderr, "table2func:", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// table is a table-driven test found in a test function.
+type table struct {
+	// Decl is the statement declaring the table, and Lit its value.
+	Decl ast.Stmt
+	Lit  *ast.CompositeLit
+	// Loop is the range statement over the table, whose value is named
+	// Row.
+	Loop *ast.RangeStmt
+	Row  string
+}
+
+// generate returns the gofmt-ed source of the per-row test file for the
+// test file src, which is called name.
+func generate(name string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	tf := fset.File(file.Pos())
+	text := func(from, to token.Pos) string {
+		return string(src[tf.Offset(from):tf.Offset(to)])
+	}
+
+	var funcs bytes.Buffer
+	names := map[string]bool{}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "Test") {
+			continue
+		}
+		tab, ok := findTable(fn)
+		if !ok {
+			continue
+		}
+		elem := tab.Lit.Type.(*ast.ArrayType).Elt.(*ast.StructType)
+		comments := rowComments(fset, file, tab.Lit)
+		for i, row := range tab.Lit.Elts {
+			fname := fn.Name.Name + "_" + caseName(text, elem, row, i)
+			if names[fname] {
+				fname = fmt.Sprintf("%s_%d", fname, i+1)
+			}
+			names[fname] = true
+
+			fmt.Fprintf(&funcs, "\n// %s is case %d of %s.\n", fname, i+1, fn.Name.Name)
+			for _, c := range comments[i] {
+				fmt.Fprintln(&funcs, c)
+			}
+			if fn.Doc != nil {
+				funcs.WriteString("//\n")
+				for _, c := range fn.Doc.List {
+					fmt.Fprintln(&funcs, c.Text)
+				}
+			}
+			value := text(row.Pos(), row.End())
+			if lit, ok := row.(*ast.CompositeLit); ok && lit.Type == nil {
+				value = text(elem.Pos(), elem.End()) + value
+			}
+			fmt.Fprintf(&funcs, "func %s%s {\n", fname, text(fn.Type.Params.Pos(), fn.Type.End()))
+			for _, part := range []string{
+				text(fn.Body.Lbrace+1, tab.Decl.Pos()),
+				tab.Row + " := " + value,
+				text(tab.Decl.End(), tab.Loop.Pos()),
+				text(tab.Loop.Body.Lbrace+1, tab.Loop.Body.Rbrace),
+				text(tab.Loop.End(), fn.Body.Rbrace),
+			} {
+				if part = strings.TrimSpace(part); part != "" {
+					fmt.Fprintln(&funcs, part)
+				}
+			}
+			funcs.WriteString("}\n")
+		}
+	}
+	if funcs.Len() == 0 {
+		return nil, fmt.Errorf("%s: no table-driven tests found", name)
+	}
+
+	used, err := usedPackages(funcs.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by table2func from %s; DO NOT EDIT.\n\n", name)
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, "//go:build ") {
+				fmt.Fprintf(&buf, "%s\n\n", c.Text)
+			}
+		}
+	}
+	fmt.Fprintf(&buf, "package %s\n\n", file.Name.Name)
+	buf.WriteString("import (\n")
+	for _, spec := range file.Imports {
+		if name := importName(spec); used[name] || name == "_" || name == "." {
+			fmt.Fprintf(&buf, "\t%s\n", text(spec.Pos(), spec.End()))
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(funcs.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// findTable returns the table-driven test in fn, if it has one: a
+// []struct literal declared in fn's body and only used by a later range
+// statement over it whose body does not branch out of the loop.
+func findTable(fn *ast.FuncDecl) (table, bool) {
+	stmts := fn.Body.List
+	for i, stmt := range stmts {
+		name, lit := tableDecl(stmt)
+		if lit == nil || uses(fn.Body, name) != 2 {
+			continue
+		}
+		for _, later := range stmts[i+1:] {
+			loop, ok := later.(*ast.RangeStmt)
+			if !ok {
+				continue
+			}
+			if x, ok := loop.X.(*ast.Ident); !ok || x.Name != name {
+				continue
+			}
+			row, ok := loop.Value.(*ast.Ident)
+			if !ok || row.Name == "_" || loop.Key != nil && !isBlank(loop.Key) || branches(loop.Body) {
+				return table{}, false
+			}
+			return table{Decl: stmt, Lit: lit, Loop: loop, Row: row.Name}, true
+		}
+	}
+	return table{}, false
+}
+
+// tableDecl returns the name and value of the table stmt declares, or a
+// nil literal when stmt does not declare a []struct literal.
+func tableDecl(stmt ast.Stmt) (string, *ast.CompositeLit) {
+	var (
+		name  *ast.Ident
+		value ast.Expr
+	)
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+			return "", nil
+		}
+		spec := gen.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || len(spec.Values) != 1 || spec.Type != nil {
+			return "", nil
+		}
+		name, value = spec.Names[0], spec.Values[0]
+	case *ast.AssignStmt:
+		if s.Tok != token.DEFINE || len(s.Lhs) != 1 || len(s.Rhs) != 1 {
+			return "", nil
+		}
+		name, _ = s.Lhs[0].(*ast.Ident)
+		value = s.Rhs[0]
+	}
+	lit, ok := value.(*ast.CompositeLit)
+	if name == nil || !ok {
+		return "", nil
+	}
+	arr, ok := lit.Type.(*ast.ArrayType)
+	if !ok || arr.Len != nil {
+		return "", nil
+	}
+	if _, ok := arr.Elt.(*ast.StructType); !ok {
+		return "", nil
+	}
+	return name.Name, lit
+}
+
+// uses returns how many identifiers in body are called name.
+func uses(body *ast.BlockStmt, name string) int {
+	n := 0
+	ast.Inspect(body, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Ident); ok && id.Name == name {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
+func isBlank(x ast.Expr) bool {
+	id, ok := x.(*ast.Ident)
+	return ok && id.Name == "_"
+}
+
+// branches reports whether body has a break, continue or goto outside
+// function literals. Some of them would no longer compile once body is
+// not a loop body, so tables with any are left out.
+func branches(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BranchStmt:
+			if n.Tok != token.FALLTHROUGH {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// rowComments returns the comments between the rows of lit, each given
+// to the row on whose last line it starts or else to the row after it.
+// Comments inside a row stay part of its text.
+func rowComments(fset *token.FileSet, file *ast.File, lit *ast.CompositeLit) [][]string {
+	rows := lit.Elts
+	comments := make([][]string, len(rows))
+	for _, group := range file.Comments {
+		if group.Pos() < lit.Lbrace || group.End() > lit.Rbrace || len(rows) == 0 {
+			continue
+		}
+		i := sort.Search(len(rows), func(i int) bool { return rows[i].Pos() > group.Pos() })
+		if i > 0 && rows[i-1].End() > group.Pos() {
+			continue
+		}
+		line := fset.Position(group.Pos()).Line
+		if i == len(rows) || i > 0 && fset.Position(rows[i-1].End()).Line == line {
+			i--
+		}
+		for _, c := range group.List {
+			comments[i] = append(comments[i], c.Text)
+		}
+	}
+	return comments
+}
+
+// caseName derives the part of a generated function's name that tells
+// the rows of a table apart: the value of the row's name field when elem
+// has one, or else the row's values. i is the index of row.
+func caseName(text func(from, to token.Pos) string, elem *ast.StructType, row ast.Expr, i int) string {
+	var s string
+	if lit, ok := row.(*ast.CompositeLit); ok && len(lit.Elts) > 0 {
+		s = text(lit.Elts[0].Pos(), lit.Elts[len(lit.Elts)-1].End())
+		if v := nameField(elem, lit); v != "" {
+			s = v
+		}
+	} else {
+		s = text(row.Pos(), row.End())
+	}
+	name := identifier(s)
+	if len(name) > maxCaseName {
+		name = name[:maxCaseName]
+	}
+	if name == "" {
+		name = "Case" + strconv.Itoa(i+1)
+	}
+	return name
+}
+
+// nameField returns the value lit gives the string field of elem called
+// name or desc, in any case, or "" when there is none or it is not a
+// string literal.
+func nameField(elem *ast.StructType, lit *ast.CompositeLit) string {
+	var fields []string
+	for _, field := range elem.Fields.List {
+		typ, _ := field.Type.(*ast.Ident)
+		for _, n := range field.Names {
+			if typ != nil && typ.Name == "string" && (strings.EqualFold(n.Name, "name") || strings.EqualFold(n.Name, "desc")) {
+				fields = append(fields, n.Name)
+			} else {
+				fields = append(fields, "")
+			}
+		}
+	}
+	for i, elt := range lit.Elts {
+		field, value := "", elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && slices.Contains(fields, key.Name) {
+				field = key.Name
+			}
+			value = kv.Value
+		} else if i < len(fields) {
+			field = fields[i]
+		}
+		if bl, ok := value.(*ast.BasicLit); ok && field != "" && bl.Kind == token.STRING {
+			s, err := strconv.Unquote(bl.Value)
+			if err == nil {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// identifier turns s into the tail of a Go identifier: the ASCII letters
+// and digits of s, with each run of them starting in upper case and a
+// minus sign before a digit spelled Neg.
+func identifier(s string) string {
+	var sb strings.Builder
+	upper := true
+	for i, r := range s {
+		switch {
+		case r == '-' && i+1 < len(s) && '0' <= s[i+1] && s[i+1] <= '9':
+			sb.WriteString("Neg")
+			upper = true
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			sb.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return sb.String()
+}
+
+// usedPackages returns the names selected from in funcs, the source of
+// the generated functions, so only the imports they need are kept.
+func usedPackages(funcs []byte) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", append([]byte("package p\n"), funcs...), 0)
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %w", err)
+	}
+	used := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				used[x.Name] = true
+			}
+		}
+		return true
+	})
+	return used, nil
+}
+
+// importName returns the name spec binds, which is _ or . for imports
+// kept for their side effects or dot-imported, taking the last element of an
+// import path that does not set one as the package name, or the one
+// before a major version suffix such as v2.
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	p, _ := strconv.Unquote(spec.Path.Value)
+	name := path.Base(p)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(p))
+	}
+	return name
+}
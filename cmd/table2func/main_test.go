@@ -0,0 +1,302 @@
+package main
+
+import (
+	"encoding/json"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRunOnStdGo generates the per-row tests of a copy of std_go_test.go
+// and compiles and runs them in place of std_go_pergen_test.go.
+func TestRunOnStdGo(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("..", "..", "std_go_test.go"))
+	require.NoError(t, err)
+	dir := t.TempDir()
+	in := filepath.Join(dir, "std_go_test.go")
+	require.NoError(t, os.WriteFile(in, src, 0o644))
+
+	var stderr strings.Builder
+	require.Equal(t, exitOK, run([]string{in}, &stderr), stderr.String())
+	out := filepath.Join(dir, "std_go_pergen_test.go")
+	first, err := os.ReadFile(out)
+	require.NoError(t, err)
+	for _, name := range []string{
+		"TestStdGoIsSorted_IntNilTrue",
+		"TestStdGoIsSorted_Int0True",
+		"TestStdGoIsSorted_Int0MathMinIntFalse",
+		"TestStdGoIsSorted_Int00True",
+	} {
+		require.Contains(t, string(first), "\nfunc "+name+"(t *testing.T) {\n")
+	}
+	formatted, err := format.Source(first)
+	require.NoError(t, err)
+	require.Equal(t, string(formatted), string(first), "output is not gofmt-ed")
+
+	require.Equal(t, exitOK, run([]string{in}, &stderr), stderr.String())
+	second, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, string(first), string(second))
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	target, err := filepath.Abs(filepath.Join("..", "..", "std_go_pergen_test.go"))
+	require.NoError(t, err)
+	overlay, err := json.Marshal(map[string]map[string]string{"Replace": {target: out}})
+	require.NoError(t, err)
+	overlayFile := filepath.Join(dir, "overlay.json")
+	require.NoError(t, os.WriteFile(overlayFile, overlay, 0o644))
+	cmd := exec.Command(goTool, "test", "-overlay", overlayFile, "-count=1", "-run", "^TestStdGoIsSorted_", ".")
+	cmd.Dir = filepath.Join("..", "..")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s", output)
+}
+
+func TestGenerate(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Src      string
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := generate("x_test.go", []byte(tc.Src))
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, string(actual))
+		})
+	}
+	validate(t, testCase{Name: "Name field and comments",
+		Src: `//go:build linux
+
+package x
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUpper checks strings.ToUpper.
+func TestUpper(t *testing.T) {
+	cases := []struct {
+		name, in string
+		want     string
+	}{
+		// Empty strings stay empty.
+		{"empty", "", ""},
+		{name: "two words", in: "a b", want: "A B"}, // spaces are kept
+		{"two words", "é", "É"},
+	}
+	for _, tc := range cases {
+		// Compare the whole string.
+		if got := strings.ToUpper(tc.in); got != tc.want {
+			t.Errorf("got %q", got)
+		}
+	}
+}
+
+func TestNoTable(t *testing.T) {}
+`,
+		Expected: `// Code generated by table2func from x_test.go; DO NOT EDIT.
+
+//go:build linux
+
+package x
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUpper_Empty is case 1 of TestUpper.
+// Empty strings stay empty.
+//
+// TestUpper checks strings.ToUpper.
+func TestUpper_Empty(t *testing.T) {
+	tc := struct {
+		name, in string
+		want     string
+	}{"empty", "", ""}
+	// Compare the whole string.
+	if got := strings.ToUpper(tc.in); got != tc.want {
+		t.Errorf("got %q", got)
+	}
+}
+
+// TestUpper_TwoWords is case 2 of TestUpper.
+// spaces are kept
+//
+// TestUpper checks strings.ToUpper.
+func TestUpper_TwoWords(t *testing.T) {
+	tc := struct {
+		name, in string
+		want     string
+	}{name: "two words", in: "a b", want: "A B"}
+	// Compare the whole string.
+	if got := strings.ToUpper(tc.in); got != tc.want {
+		t.Errorf("got %q", got)
+	}
+}
+
+// TestUpper_TwoWords_3 is case 3 of TestUpper.
+//
+// TestUpper checks strings.ToUpper.
+func TestUpper_TwoWords_3(t *testing.T) {
+	tc := struct {
+		name, in string
+		want     string
+	}{"two words", "é", "É"}
+	// Compare the whole string.
+	if got := strings.ToUpper(tc.in); got != tc.want {
+		t.Errorf("got %q", got)
+	}
+}
+`,
+	})
+	validate(t, testCase{Name: "Values and unused imports",
+		Src: `package x
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestOther(t *testing.T) {
+	if !strings.HasPrefix("ab", "a") {
+		t.Fail()
+	}
+}
+
+func TestSorted(t *testing.T) {
+	setup := 1
+	var tests = []struct {
+		in   []int
+		want bool
+	}{
+		{[]int{-1, math.MaxInt}, true},
+		{},
+	}
+	for _, test := range tests {
+		if sort.IntsAreSorted(test.in) != test.want {
+			t.Error(setup)
+		}
+	}
+	t.Log("done")
+}
+`,
+		Expected: `// Code generated by table2func from x_test.go; DO NOT EDIT.
+
+package x
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// TestSorted_IntNeg1MathMaxIntTrue is case 1 of TestSorted.
+func TestSorted_IntNeg1MathMaxIntTrue(t *testing.T) {
+	setup := 1
+	test := struct {
+		in   []int
+		want bool
+	}{[]int{-1, math.MaxInt}, true}
+	if sort.IntsAreSorted(test.in) != test.want {
+		t.Error(setup)
+	}
+	t.Log("done")
+}
+
+// TestSorted_Case2 is case 2 of TestSorted.
+func TestSorted_Case2(t *testing.T) {
+	setup := 1
+	test := struct {
+		in   []int
+		want bool
+	}{}
+	if sort.IntsAreSorted(test.in) != test.want {
+		t.Error(setup)
+	}
+	t.Log("done")
+}
+`,
+	})
+}
+
+func TestGenerateLeavesOutOtherTests(t *testing.T) {
+	for name, src := range map[string]string{
+		"No table": "package x\n\nimport \"testing\"\n\nfunc TestX(t *testing.T) { t.Log(1) }\n",
+		"Index used": `package x
+
+import "testing"
+
+func TestX(t *testing.T) {
+	tests := []struct{ in int }{{1}}
+	for i, test := range tests {
+		t.Log(i, test)
+	}
+}
+`,
+		"Continue": `package x
+
+import "testing"
+
+func TestX(t *testing.T) {
+	tests := []struct{ in int }{{1}}
+	for _, test := range tests {
+		if test.in == 0 {
+			continue
+		}
+	}
+}
+`,
+		"Table used again": `package x
+
+import "testing"
+
+func TestX(t *testing.T) {
+	tests := []struct{ in int }{{1}}
+	for _, test := range tests {
+		t.Log(test)
+	}
+	t.Log(len(tests))
+}
+`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := generate("x_test.go", []byte(src))
+			require.EqualError(t, err, "x_test.go: no table-driven tests found")
+		})
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var stderr strings.Builder
+	require.Equal(t, exitUsage, run([]string{"main.go"}, &stderr))
+	require.Contains(t, stderr.String(), "usage: table2func")
+	stderr.Reset()
+	require.Equal(t, exitError, run([]string{filepath.Join(t.TempDir(), "missing_test.go")}, &stderr))
+	require.Contains(t, stderr.String(), "table2func: ")
+}
+
+// TestGeneratedFileIsUpToDate fails when std_go_test.go changed without
+// std_go_pergen_test.go being regenerated with go generate.
+func TestGeneratedFileIsUpToDate(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("..", "..", "std_go_test.go"))
+	require.NoError(t, err)
+	expected, err := generate("std_go_test.go", src)
+	require.NoError(t, err)
+	actual, err := os.ReadFile(filepath.Join("..", "..", "std_go_pergen_test.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual), "run go generate in the repository root")
+}
@@ -0,0 +1,52 @@
+package testdemo
+
+import (
+	"bytes"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// IsSortedCollated reports whether data is in non-decreasing order under
+// the collation rules of the language tag, the way user-visible lists are
+// sorted: in German "ä" sorts with "a", while in Swedish it sorts after
+// "z". opts adjust the rules, such as collate.IgnoreCase.
+//
+// Each comparison collates both strings from scratch. To check the same
+// data more than once, compute its CollationKeys once instead.
+func IsSortedCollated(data []string, tag language.Tag, opts ...collate.Option) bool {
+	c := collate.New(tag, opts...)
+	for i := 1; i < len(data); i++ {
+		if c.CompareString(data[i-1], data[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CollationKeys returns the collation key of each element of data under
+// the rules of tag and opts. Keys compare with bytes.Compare exactly as
+// their strings collate, so IsSortedCollationKeys of the keys is
+// IsSortedCollated of data, without collating anything again.
+func CollationKeys(data []string, tag language.Tag, opts ...collate.Option) [][]byte {
+	c := collate.New(tag, opts...)
+	// A Buffer keeps every key it returns until it is reset, so all the
+	// keys can share its storage.
+	var buf collate.Buffer
+	keys := make([][]byte, len(data))
+	for i, s := range data {
+		keys[i] = c.KeyFromString(&buf, s)
+	}
+	return keys
+}
+
+// IsSortedCollationKeys reports whether keys, as returned by
+// CollationKeys, are in non-decreasing order.
+func IsSortedCollationKeys(keys [][]byte) bool {
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func TestIsSortedCollated(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []string
+		Tag      language.Tag
+		Opts     []collate.Option
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, IsSortedCollated(tc.Data, tc.Tag, tc.Opts...), "IsSortedCollated")
+			keys := CollationKeys(tc.Data, tc.Tag, tc.Opts...)
+			require.Equal(t, tc.Expected, IsSortedCollationKeys(keys), "IsSortedCollationKeys")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Data:     []string{},
+		Tag:      language.German,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "German umlaut with a",
+		Data:     []string{"Apfel", "Äpfel", "Zebra"},
+		Tag:      language.German,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "German umlaut after z",
+		Data:     []string{"Apfel", "Zebra", "Äpfel"},
+		Tag:      language.German,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Swedish umlaut with a",
+		Data:     []string{"Apfel", "Äpfel", "Zebra"},
+		Tag:      language.Swedish,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Swedish umlaut after z",
+		Data:     []string{"Apfel", "Zebra", "Äpfel"},
+		Tag:      language.Swedish,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Case sensitive",
+		Data:     []string{"B", "b"},
+		Tag:      language.English,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Case insensitive",
+		Data:     []string{"B", "b", "B"},
+		Tag:      language.English,
+		Opts:     []collate.Option{collate.IgnoreCase},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Byte order is not collation order",
+		Data:     []string{"a", "B", "c"},
+		Tag:      language.English,
+		Expected: true,
+	})
+}
@@ -0,0 +1,54 @@
+package testdemo
+
+// IsSortedCompareFunc reports whether data is in non-decreasing order of
+// compare, a three-way comparator in the style of cmp.Compare and
+// slices.SortFunc: negative when a sorts before b, positive when after,
+// and zero when they are equal.
+func IsSortedCompareFunc[T any](data []T, compare func(a, b T) int) bool {
+	for i := 1; i < len(data); i++ {
+		if compare(data[i-1], data[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchCompareFunc is Search for data sorted in non-decreasing order of
+// compare: it reports whether target is in data, along with the index of
+// its first occurrence when it is, and where it would be inserted when
+// it is not.
+func SearchCompareFunc[T any](data []T, target T, compare func(a, b T) int) (int, bool) {
+	lo, hi := 0, len(data)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if compare(data[mid], target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(data) && compare(data[lo], target) == 0
+}
+
+// LessToCompare turns a less function into a three-way comparator for
+// slices.SortFunc and the CompareFunc functions here. Elements neither
+// less than the other compare equal. The comparator calls less up to
+// twice.
+func LessToCompare[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// CompareToLess turns a three-way comparator, such as cmp.Compare, into
+// a less function for sort.Slice, MergeSortFunc and the like.
+func CompareToLess[T any](compare func(a, b T) int) func(a, b T) bool {
+	return func(a, b T) bool { return compare(a, b) < 0 }
+}
@@ -0,0 +1,121 @@
+package testdemo
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+type version struct {
+	Major, Minor int
+}
+
+// compareVersions returns exactly -1, 0 or 1, as hand-written
+// comparators often do.
+func compareVersions(a, b version) int {
+	switch {
+	case a.Major != b.Major:
+		if a.Major < b.Major {
+			return -1
+		}
+		return 1
+	case a.Minor < b.Minor:
+		return -1
+	case a.Minor > b.Minor:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Less orders versions as compareVersions does.
+func (v version) Less(w version) bool {
+	return compareVersions(v, w) < 0
+}
+
+func TestIsSortedCompareFunc(t *testing.T) {
+	require.True(t, IsSortedCompareFunc([]int{}, cmp.Compare[int]))
+	require.True(t, IsSortedCompareFunc([]int{1, 2, 2, 3}, cmp.Compare[int]))
+	require.False(t, IsSortedCompareFunc([]int{1, 3, 2}, cmp.Compare[int]))
+	require.True(t, IsSortedCompareFunc([]string{"a", "ab", "b"}, cmp.Compare[string]))
+	require.False(t, IsSortedCompareFunc([]string{"b", "a"}, cmp.Compare[string]))
+	require.True(t, IsSortedCompareFunc([]version{{1, 2}, {1, 10}, {2, 0}}, compareVersions))
+	require.False(t, IsSortedCompareFunc([]version{{1, 10}, {1, 2}}, compareVersions))
+}
+
+func TestSearchCompareFunc(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Data   []int
+		Target int
+		Index  int
+		Found  bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			index, found := SearchCompareFunc(tc.Data, tc.Target, cmp.Compare[int])
+			require.Equal(t, tc.Index, index)
+			require.Equal(t, tc.Found, found)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Data:   []int{},
+		Target: 3,
+	})
+	validate(t, testCase{Name: "First of duplicates",
+		Data:   []int{1, 3, 3, 3, 5},
+		Target: 3,
+		Index:  1,
+		Found:  true,
+	})
+	validate(t, testCase{Name: "Missing",
+		Data:   []int{1, 3, 5},
+		Target: 4,
+		Index:  2,
+	})
+	validate(t, testCase{Name: "Past the end",
+		Data:   []int{1, 3, 5},
+		Target: 9,
+		Index:  3,
+	})
+
+	versions := []version{{1, 2}, {1, 10}, {2, 0}}
+	index, found := SearchCompareFunc(versions, version{1, 10}, compareVersions)
+	require.Equal(t, 1, index)
+	require.True(t, found)
+	index, found = SearchCompareFunc([]string{"a", "c"}, "b", cmp.Compare[string])
+	require.Equal(t, 1, index)
+	require.False(t, found)
+}
+
+func TestCompareAdaptersRoundTrip(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	less := func(a, b int) bool { return a < b }
+	compare := LessToCompare(less)
+	roundTripLess := CompareToLess(compare)
+	roundTripCompare := LessToCompare(CompareToLess(cmp.Compare[int]))
+	for range 1000 {
+		a, b := rng.Intn(20)-10, rng.Intn(20)-10
+		require.Equal(t, cmp.Compare(a, b), compare(a, b), "a=%d b=%d", a, b)
+		require.Equal(t, less(a, b), roundTripLess(a, b), "a=%d b=%d", a, b)
+		require.Equal(t, cmp.Compare(a, b), roundTripCompare(a, b), "a=%d b=%d", a, b)
+	}
+
+	versionLess := CompareToLess(compareVersions)
+	for range 100 {
+		data := make([]version, rng.Intn(30))
+		for i := range data {
+			data[i] = version{rng.Intn(3), rng.Intn(3)}
+		}
+		byCompare := slices.Clone(data)
+		slices.SortStableFunc(byCompare, compareVersions)
+		byLess := MergeSortFunc(data, versionLess)
+		require.Equal(t, byCompare, byLess)
+		require.True(t, IsSortedCompareFunc(byLess, LessToCompare(versionLess)))
+	}
+}
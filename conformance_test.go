@@ -0,0 +1,125 @@
+package testdemo
+
+import (
+	"cmp"
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/conformance"
+	"github.com/StevenACoffman/testdemo/internal/testcases"
+	"github.com/stretchr/testify/require"
+)
+
+// notIsSorted are the exported IsSorted* functions over []int that answer
+// a different question than IsSorted, and so are left out of
+// isSortedImpls.
+var notIsSorted = map[string]string{
+	"IsSortedByAbs":   "orders by absolute value",
+	"IsSortedByRanks": "checks the ranks against data, not data itself",
+}
+
+// isSortedImpls are the implementations that must agree with IsSorted on
+// every input. The exported ones are keyed by their name, which
+// TestIsSortedImplsCoverThePackage relies on.
+func isSortedImpls() map[string]func([]int) bool {
+	impls := map[string]func([]int) bool{
+		"IsSorted":        IsSorted,
+		"scalar loop":     func(data []int) bool { return firstUnsortedScalar(data) < 0 },
+		"IsSortedOrdered": IsSortedOrdered[int],
+		"IsSortedFunc": func(data []int) bool {
+			return IsSortedFunc(data, func(a, b int) bool { return a < b })
+		},
+		"IsSortedCompareFunc": func(data []int) bool { return IsSortedCompareFunc(data, cmp.Compare[int]) },
+		"IsSortedBy":          func(data []int) bool { return IsSortedBy(data, func(v int) int { return v }) },
+		"IsSortedInt64": func(data []int) bool {
+			wide := make([]int64, len(data))
+			for i, v := range data {
+				wide[i] = int64(v)
+			}
+			return IsSortedInt64(wide)
+		},
+		"IsSortedRange":    func(data []int) bool { return IsSortedRange(data, 0, len(data)) },
+		"IsSortedInBounds": func(data []int) bool { return IsSortedInBounds(data, math.MinInt, math.MaxInt) },
+		"IsSortedChunks": func(data []int) bool {
+			return IsSortedChunks(data, 8) && AreChunkBoundariesSorted(data, 8)
+		},
+		"IsSortedIgnoring": func(data []int) bool {
+			return IsSortedIgnoring(data, func(int) bool { return false })
+		},
+		"IsSortedConsistent": func(data []int) bool {
+			sorted, err := IsSortedConsistent(data)
+			return sorted && err == nil
+		},
+		"IsSortedCtx": func(data []int) bool {
+			sorted, err := IsSortedCtx(context.Background(), data)
+			return sorted && err == nil
+		},
+		"sort.IntsAreSorted": sort.IntsAreSorted,
+		"slices.IsSorted":    slices.IsSorted[[]int],
+	}
+	if haveVector {
+		impls["vectorized loop"] = func(data []int) bool { return firstUnsortedVector(data) < 0 }
+	}
+	return impls
+}
+
+func TestIsSortedConformance(t *testing.T) {
+	var corpus [][]int
+	for _, c := range testcases.Cases() {
+		corpus = append(corpus, c.Data)
+	}
+	conformance.RunConformance(t, isSortedImpls(), corpus)
+}
+
+// TestIsSortedImplsCoverThePackage fails when an exported IsSorted*
+// function taking a []int is neither in isSortedImpls nor in
+// notIsSorted, so a new implementation cannot miss the conformance test.
+func TestIsSortedImplsCoverThePackage(t *testing.T) {
+	paths, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	impls := isSortedImpls()
+	var missing []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		require.NoError(t, err)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "IsSorted") || !takesInts(fn) {
+				continue
+			}
+			name := fn.Name.Name
+			if _, ok := impls[name]; ok {
+				continue
+			}
+			if _, ok := notIsSorted[name]; ok {
+				continue
+			}
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	require.Empty(t, missing, "add these to isSortedImpls, or to notIsSorted if they are not IsSorted")
+}
+
+// takesInts reports whether fn has a []int parameter.
+func takesInts(fn *ast.FuncDecl) bool {
+	for _, field := range fn.Type.Params.List {
+		if arr, ok := field.Type.(*ast.ArrayType); ok && arr.Len == nil {
+			if ident, ok := arr.Elt.(*ast.Ident); ok && ident.Name == "int" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package testdemo
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+)
+
+// ErrConcurrentMutation is returned by IsSortedConsistent when data
+// changed while it was being checked.
+var ErrConcurrentMutation = errors.New("data changed while it was being checked")
+
+// mutationSamples is how many positions IsSortedConsistent reads twice.
+const mutationSamples = 64
+
+// IsSortedConsistent is IsSorted for data that another goroutine may be
+// writing to, where a plain check can answer for a mix of old and new
+// values that was never the data. It reads each element once, comparing
+// that one read with the one before it, and keeps the values it read at a
+// few random positions; once done, it reads those again and returns
+// ErrConcurrentMutation if any changed.
+//
+// The detection is best-effort, not a guarantee: writes that miss the
+// sampled positions, or put back the value they found, go unnoticed, so
+// it catches wholesale rewrites of data but not a single changed element.
+// Reading data while it is written is still a data race, which the race
+// detector reports.
+func IsSortedConsistent(data []int) (bool, error) {
+	type sample struct{ index, value int }
+	var samples []sample
+	if len(data) > 0 {
+		positions := make([]int, mutationSamples)
+		for i := range positions {
+			positions[i] = rand.IntN(len(data))
+		}
+		slices.Sort(positions)
+		samples = make([]sample, len(positions))
+		for i, p := range positions {
+			samples[i].index = p
+		}
+	}
+	sorted, next, prev := true, 0, 0
+	for i := range data {
+		v := data[i]
+		for next < len(samples) && samples[next].index == i {
+			samples[next].value = v
+			next++
+		}
+		if i > 0 && v < prev {
+			sorted = false
+			break
+		}
+		prev = v
+	}
+	// Only the positions read before any early exit were recorded.
+	for _, s := range samples[:next] {
+		if data[s.index] != s.value {
+			return false, ErrConcurrentMutation
+		}
+	}
+	return sorted, nil
+}
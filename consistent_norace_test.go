@@ -0,0 +1,49 @@
+//go:build !race
+
+// The test here writes to data while IsSortedConsistent reads it, which is
+// the race it detects and the race detector reports too.
+
+package testdemo
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedConsistentDetectsMutation(t *testing.T) {
+	data := make([]int, 1<<22)
+	for i := range data {
+		data[i] = i
+	}
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Shift every element up, keeping data sorted, so only the
+		// mutation check can tell.
+		for !stop.Load() {
+			for i := range data {
+				data[i]++
+			}
+		}
+	}()
+	defer func() {
+		stop.Store(true)
+		wg.Wait()
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, err := IsSortedConsistent(data)
+		if err != nil {
+			require.ErrorIs(t, err, ErrConcurrentMutation)
+			return
+		}
+		require.True(t, time.Now().Before(deadline), "no mutation detected")
+	}
+}
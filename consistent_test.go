@@ -0,0 +1,57 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedConsistent(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			got, err := IsSortedConsistent(tc.Array)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, got)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Single",
+		Array:    []int{1},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array:    []int{1, 2, 2, 3},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Array: []int{1, 3, 2, 4},
+	})
+}
+
+func TestIsSortedConsistentNoFalsePositives(t *testing.T) {
+	data := make([]int, 1<<16)
+	for i := range data {
+		data[i] = i / 3
+	}
+	for i := 0; i < 200; i++ {
+		got, err := IsSortedConsistent(data)
+		require.NoError(t, err)
+		require.True(t, got)
+	}
+	data[len(data)/2] = -1
+	for i := 0; i < 200; i++ {
+		got, err := IsSortedConsistent(data)
+		require.NoError(t, err)
+		require.False(t, got)
+	}
+}
@@ -0,0 +1,37 @@
+package testdemo
+
+import "fmt"
+
+// maxCountingRange is the most distinct values CountingSort allocates a
+// count for, 128 MiB of counts on 64-bit platforms.
+const maxCountingRange = 1 << 24
+
+// CountingSort returns a sorted copy of data, every element of which must
+// be within [lo, hi]. It counts how often each value occurs, taking
+// O(n + hi-lo) time and a count per value in the range, so it beats
+// comparison sorts for small ranges such as bytes or ports. It returns a
+// *BoundsError for the first element outside the range, and an error if
+// lo > hi or the range holds more than 2²⁴ values.
+func CountingSort(data []int, lo, hi int) ([]int, error) {
+	if lo > hi {
+		return nil, fmt.Errorf("counting sort: empty range [%d, %d]", lo, hi)
+	}
+	// hi-lo can overflow an int, but not a uint64.
+	if span := uint64(hi) - uint64(lo); span >= maxCountingRange {
+		return nil, fmt.Errorf("counting sort: range [%d, %d] holds more than %d values", lo, hi, maxCountingRange)
+	}
+	counts := make([]int, hi-lo+1)
+	for i, v := range data {
+		if v < lo || v > hi {
+			return nil, &BoundsError{Index: i, Value: v, Lo: lo, Hi: hi}
+		}
+		counts[v-lo]++
+	}
+	out := make([]int, 0, len(data))
+	for offset, c := range counts {
+		for range c {
+			out = append(out, lo+offset)
+		}
+	}
+	return out, nil
+}
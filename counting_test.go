@@ -0,0 +1,91 @@
+package testdemo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountingSort(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Lo, Hi   int
+		Expected []int
+		Err      string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := CountingSort(tc.Array, tc.Lo, tc.Hi)
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		Lo:       0,
+		Hi:       255,
+		Expected: []int{},
+	})
+	validate(t, testCase{Name: "Bytes",
+		Array:    []int{255, 0, 7, 7, 3},
+		Lo:       0,
+		Hi:       255,
+		Expected: []int{0, 3, 7, 7, 255},
+	})
+	validate(t, testCase{Name: "Negative range",
+		Array:    []int{-3, -10, -5, -3},
+		Lo:       -10,
+		Hi:       -1,
+		Expected: []int{-10, -5, -3, -3},
+	})
+	validate(t, testCase{Name: "Single value range",
+		Array:    []int{4, 4, 4},
+		Lo:       4,
+		Hi:       4,
+		Expected: []int{4, 4, 4},
+	})
+	validate(t, testCase{Name: "Out of range",
+		Array: []int{1, 2, 300, -1},
+		Lo:    0,
+		Hi:    255,
+		Err:   "index 2: 300 is outside [0, 255]",
+	})
+	validate(t, testCase{Name: "Empty range",
+		Array: []int{1},
+		Lo:    2,
+		Hi:    1,
+		Err:   "counting sort: empty range [2, 1]",
+	})
+	validate(t, testCase{Name: "Absurd range",
+		Array: []int{1},
+		Lo:    math.MinInt,
+		Hi:    math.MaxInt,
+		Err:   fmt.Sprintf("counting sort: range [%d, %d] holds more than 16777216 values", math.MinInt, math.MaxInt),
+	})
+}
+
+func TestCountingSortProperties(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 200; i++ {
+		lo := rng.Intn(100) - 50
+		hi := lo + rng.Intn(20)
+		data := make([]int, rng.Intn(50))
+		for j := range data {
+			data[j] = lo + rng.Intn(hi-lo+1)
+		}
+		sorted, err := CountingSort(data, lo, hi)
+		require.NoError(t, err)
+		require.True(t, IsSorted(sorted), "%v", sorted)
+		require.True(t, IsPermutationOf(data, sorted), "%v %v", data, sorted)
+	}
+}
@@ -0,0 +1,54 @@
+package testdemo
+
+import (
+	"fmt"
+	"math"
+)
+
+// DeltaEncode encodes data, which must be sorted in non-decreasing order,
+// as the differences between consecutive elements, which are small for
+// dense data and so compress well, as with varints. The first delta is
+// the first element itself, as its two's complement uint64. It returns an
+// *UnsortedError for the first pair out of order when data is not sorted.
+// The differences are taken in uint64, so no span of values overflows.
+func DeltaEncode(data []int) ([]uint64, error) {
+	deltas := make([]uint64, len(data))
+	for i, v := range data {
+		if i == 0 {
+			deltas[0] = uint64(v)
+			continue
+		}
+		if v < data[i-1] {
+			return nil, &UnsortedError{Index: i, Prev: data[i-1], Next: v}
+		}
+		deltas[i] = uint64(v) - uint64(data[i-1])
+	}
+	return deltas, nil
+}
+
+// DeltaDecode reverses DeltaEncode. It returns an error, rather than
+// wrapping around to negative values, when the deltas add up past
+// math.MaxInt, as only deltas DeltaEncode did not produce can, or when
+// the first one is outside the range of int, as deltas encoded where int
+// is 64 bits can be where it is 32.
+func DeltaDecode(deltas []uint64) ([]int, error) {
+	data := make([]int, len(deltas))
+	for i, d := range deltas {
+		if i == 0 {
+			// d is the two's complement of an int64, which only fits in
+			// an int where int is 64 bits or the value is small enough.
+			v := int64(d)
+			if int64(int(v)) != v {
+				return nil, fmt.Errorf("index 0: value %d overflows int", v)
+			}
+			data[0] = int(v)
+			continue
+		}
+		prev := data[i-1]
+		if d > uint64(math.MaxInt)-uint64(prev) {
+			return nil, fmt.Errorf("index %d: delta %d after %d overflows int", i, d, prev)
+		}
+		data[i] = int(uint64(prev) + d)
+	}
+	return data, nil
+}
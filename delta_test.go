@@ -0,0 +1,92 @@
+package testdemo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeltaEncode(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Array  []int
+		Deltas []uint64
+		Err    error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			deltas, err := DeltaEncode(tc.Array)
+			require.Equal(t, tc.Err, err)
+			require.Equal(t, tc.Deltas, deltas)
+			if err != nil {
+				return
+			}
+			data, err := DeltaDecode(deltas)
+			require.NoError(t, err)
+			require.Equal(t, tc.Array, data)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:  []int{},
+		Deltas: []uint64{},
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array:  []int{5, 7, 7, 10},
+		Deltas: []uint64{5, 2, 0, 3},
+	})
+	validate(t, testCase{Name: "Negative first value",
+		Array:  []int{-2, 1},
+		Deltas: []uint64{math.MaxUint64 - 1, 3},
+	})
+	validate(t, testCase{Name: "Near MaxInt64",
+		Array:  []int{math.MaxInt - 2, math.MaxInt - 1, math.MaxInt},
+		Deltas: []uint64{math.MaxInt - 2, 1, 1},
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Array: []int{1, 4, 3},
+		Err:   &UnsortedError{Index: 2, Prev: 4, Next: 3},
+	})
+}
+
+func TestDeltaDecodeOverflow(t *testing.T) {
+	_, err := DeltaDecode([]uint64{math.MaxInt - 1, 1, 1})
+	require.EqualError(t, err, fmt.Sprintf("index 2: delta 1 after %d overflows int", math.MaxInt))
+	_, err = DeltaDecode([]uint64{uint64(1), math.MaxUint64})
+	require.EqualError(t, err, "index 1: delta 18446744073709551615 after 1 overflows int")
+}
+
+// realisticIDs returns n sorted IDs as a database hands them out: large,
+// dense and with the odd gap where rows were deleted.
+func realisticIDs(n int) []int {
+	rng := rand.New(rand.NewSource(1))
+	ids := make([]int, n)
+	id := 1 << 30
+	for i := range ids {
+		id++
+		if rng.Intn(10) == 0 {
+			id += rng.Intn(1000)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func TestDeltaEncodeSize(t *testing.T) {
+	ids := realisticIDs(1e5)
+	deltas, err := DeltaEncode(ids)
+	require.NoError(t, err)
+	var raw, encoded []byte
+	for i, v := range ids {
+		raw = binary.AppendUvarint(raw, uint64(v))
+		encoded = binary.AppendUvarint(encoded, deltas[i])
+	}
+	t.Logf("%d IDs: %d bytes fixed-width, %d bytes as varints, %d bytes as delta varints",
+		len(ids), 8*len(ids), len(raw), len(encoded))
+	require.Less(t, len(encoded), len(raw)/3)
+}
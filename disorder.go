@@ -0,0 +1,49 @@
+package testdemo
+
+// Profile summarizes how far a slice is from sorted in non-decreasing
+// order. See DisorderProfile.
+type Profile struct {
+	// Sorted reports whether the slice is sorted, which is when it has no
+	// more than one run.
+	Sorted bool
+	// Runs is the number of maximal non-decreasing runs, zero for an
+	// empty slice.
+	Runs int
+	// LongestRun is the length of the longest of those runs.
+	LongestRun int
+	// AdjacentInversions is the number of descents, neighbors where the
+	// second is smaller than the first. It is always Runs-1 for a
+	// non-empty slice, and a lower bound on the number of inversions,
+	// which counts every out-of-order pair rather than neighbors only.
+	// Like the other inversion counts, it is an int64.
+	AdjacentInversions int64
+	// Min and Max are the smallest and largest elements, zero for an
+	// empty slice.
+	Min, Max int
+}
+
+// DisorderProfile computes the Profile of data in a single pass without
+// allocating, so it is a cheaper way to get several of its measures of
+// huge slices than computing each on its own.
+func DisorderProfile(data []int) Profile {
+	if len(data) == 0 {
+		return Profile{Sorted: true}
+	}
+	p := Profile{Runs: 1, Min: data[0], Max: data[0]}
+	run := 1
+	for i := 1; i < len(data); i++ {
+		v := data[i]
+		if v < data[i-1] {
+			p.AdjacentInversions++
+			p.Runs++
+			p.LongestRun = max(p.LongestRun, run)
+			run = 0
+		}
+		run++
+		p.Min = min(p.Min, v)
+		p.Max = max(p.Max, v)
+	}
+	p.LongestRun = max(p.LongestRun, run)
+	p.Sorted = p.Runs == 1
+	return p
+}
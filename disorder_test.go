@@ -0,0 +1,82 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisorderProfile(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Expected Profile
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := DisorderProfile(tc.Array)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		Expected: Profile{Sorted: true},
+	})
+	validate(t, testCase{Name: "Single element",
+		Array:    []int{-4},
+		Expected: Profile{Sorted: true, Runs: 1, LongestRun: 1, Min: -4, Max: -4},
+	})
+	validate(t, testCase{Name: "Sorted with duplicates",
+		Array:    []int{1, 1, 2, 3},
+		Expected: Profile{Sorted: true, Runs: 1, LongestRun: 4, Min: 1, Max: 3},
+	})
+	validate(t, testCase{Name: "Reversed",
+		Array:    []int{3, 2, 1},
+		Expected: Profile{Runs: 3, LongestRun: 1, AdjacentInversions: 2, Min: 1, Max: 3},
+	})
+	validate(t, testCase{Name: "Longest run last",
+		Array:    []int{5, 1, 2, 3, 4},
+		Expected: Profile{Runs: 2, LongestRun: 4, AdjacentInversions: 1, Min: 1, Max: 5},
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array:    []int{0, math.MinInt, math.MaxInt},
+		Expected: Profile{Runs: 2, LongestRun: 2, AdjacentInversions: 1, Min: math.MinInt, Max: math.MaxInt},
+	})
+}
+
+func TestDisorderProfileAgainstSeparatePasses(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]int, rng.Intn(30)+1)
+		for j := range data {
+			data[j] = rng.Intn(10)
+		}
+		p := DisorderProfile(data)
+
+		require.Equal(t, IsSorted(data), p.Sorted, "%v", data)
+		require.Equal(t, slices.Min(data), p.Min, "%v", data)
+		require.Equal(t, slices.Max(data), p.Max, "%v", data)
+		runs, longest, start := 0, 0, 0
+		for end := 1; end <= len(data); end++ {
+			if end == len(data) || data[end] < data[end-1] {
+				require.True(t, IsSorted(data[start:end]))
+				runs++
+				longest = max(longest, end-start)
+				start = end
+			}
+		}
+		require.Equal(t, runs, p.Runs, "%v", data)
+		require.Equal(t, int64(runs-1), p.AdjacentInversions, "%v", data)
+		require.Equal(t, longest, p.LongestRun, "%v", data)
+	}
+}
+
+func TestDisorderProfileDoesNotAllocate(t *testing.T) {
+	data := []int{3, 1, 2, 5, 4}
+	require.Zero(t, testing.AllocsPerRun(100, func() { DisorderProfile(data) }))
+}
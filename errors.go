@@ -0,0 +1,60 @@
+package testdemo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsorted matches every *UnsortedError with errors.Is, so callers can
+// tell a violation from other failures without extracting it.
+var ErrUnsorted = errors.New("unsorted")
+
+// UnsortedError describes the first pair of elements found out of order.
+type UnsortedError struct {
+	// Index is the position of Next in the input, counting from zero.
+	Index int
+	// Prev and Next are the offending pair: Prev comes right before Next
+	// but should not.
+	Prev, Next any
+	// Line is the 1-based line Next was read from, or 0 when the input
+	// has no lines.
+	Line int
+	// Text is the raw input Next was taken from, such as the whole CSV
+	// record, when that is more than Next itself.
+	Text string
+	// Source names the input, such as a file name, when it is known.
+	Source string
+	// Len is the number of elements in the input when it is known up
+	// front, as for a slice, and 0 otherwise.
+	Len int
+}
+
+func (e *UnsortedError) Error() string {
+	where := fmt.Sprintf("index %d", e.Index)
+	if e.Line > 0 {
+		where = fmt.Sprintf("line %d, %s", e.Line, where)
+	}
+	if e.Source != "" {
+		where = e.Source + ": " + where
+	}
+	return fmt.Sprintf("%s: %v followed by %v", where, e.Prev, e.Next)
+}
+
+// Is reports whether target is ErrUnsorted.
+func (e *UnsortedError) Is(target error) bool {
+	return target == ErrUnsorted
+}
+
+// BoundsError describes the first element found outside the bounds a
+// check expects.
+type BoundsError struct {
+	// Index is the position of Value in the input, counting from zero.
+	Index int
+	Value int
+	// Lo and Hi are the inclusive bounds Value is outside of.
+	Lo, Hi int
+}
+
+func (e *BoundsError) Error() string {
+	return fmt.Sprintf("index %d: %d is outside [%d, %d]", e.Index, e.Value, e.Lo, e.Hi)
+}
@@ -0,0 +1,19 @@
+//go:build !tinygo && !noreflect
+
+package testdemo
+
+import "encoding/json"
+
+// MarshalJSON encodes e as an object with the fields index, prev and
+// next, and when they are known, len, source and line. The names are
+// part of the API and will not change.
+func (e *UnsortedError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Index  int    `json:"index"`
+		Prev   any    `json:"prev"`
+		Next   any    `json:"next"`
+		Len    int    `json:"len,omitempty"`
+		Source string `json:"source,omitempty"`
+		Line   int    `json:"line,omitempty"`
+	}{e.Index, e.Prev, e.Next, e.Len, e.Source, e.Line})
+}
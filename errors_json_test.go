@@ -0,0 +1,37 @@
+//go:build !tinygo && !noreflect
+
+package testdemo
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsortedErrorJSON(t *testing.T) {
+	errs := []*UnsortedError{
+		{Index: 2, Prev: 3, Next: 2},
+		{Index: 2, Prev: 3, Next: 2, Len: 3, Source: "ids"},
+		{Index: 1, Prev: "b", Next: "a", Line: 2, Source: "names.txt", Text: "a,1"},
+		{Index: 4, Prev: 1.5, Next: -0.25, Line: 3},
+	}
+	var got bytes.Buffer
+	for _, err := range errs {
+		b, merr := json.Marshal(err)
+		require.NoError(t, merr)
+		got.Write(b)
+		got.WriteByte('\n')
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "unsorted_error.golden.json"))
+	require.NoError(t, err)
+	require.Equal(t, string(want), got.String())
+
+	// Inside other values the error keeps its shape.
+	b, err := json.Marshal(map[string]error{"violation": errs[0]})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"violation": {"index": 2, "prev": 3, "next": 2}}`, string(b))
+}
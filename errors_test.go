@@ -0,0 +1,63 @@
+package testdemo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrUnsorted(t *testing.T) {
+	err := error(&UnsortedError{Index: 1, Prev: 2, Next: 1})
+	require.ErrorIs(t, err, ErrUnsorted)
+	for i := 0; i < 3; i++ {
+		err = fmt.Errorf("layer %d: %w", i, err)
+		require.ErrorIs(t, err, ErrUnsorted)
+		var unsorted *UnsortedError
+		require.ErrorAs(t, err, &unsorted)
+		require.Equal(t, 1, unsorted.Index)
+	}
+	require.NotErrorIs(t, errors.New("unsorted"), ErrUnsorted)
+	require.NotErrorIs(t, ErrTruncated, ErrUnsorted)
+}
+
+func TestCheckersReturnUnsortedError(t *testing.T) {
+	dir := t.TempDir()
+	unsortedPath := writeTestFile(t, dir, "unsorted.txt", "1\n3\n2\n")
+	fixture, err := os.ReadFile(filepath.Join("testdata", "sortedfile", "unsorted_payload.srtd"))
+	require.NoError(t, err)
+
+	for name, check := range map[string]func() error{
+		"EnsureSorted": func() error { return EnsureSorted([]int{2, 1}) },
+		"CheckReader float": func() error {
+			return CheckReader(strings.NewReader("1.5 0.5"), ReaderOptions{Float: true})
+		},
+		"CheckLines":     func() error { return CheckLines(strings.NewReader("b\na\n"), LineOptions{}) },
+		"CheckCSVColumn": func() error { return CheckCSVColumn(strings.NewReader("b\na\n"), CSVOptions{Column: 1}) },
+		"ReadSortedFile": func() error {
+			_, err := ReadSortedFile(bytes.NewReader(fixture))
+			return err
+		},
+		"MergeFiles": func() error { return MergeFiles(filepath.Join(dir, "out.txt"), unsortedPath) },
+		"CheckUUIDs": func() error {
+			return CheckUUIDs([]string{
+				"00000000-0000-0000-0000-000000000002",
+				"00000000-0000-0000-0000-000000000001",
+			}, UUIDOptions{})
+		},
+		"CheckSortedCtx": func() error {
+			return CheckSortedCtx(context.Background(), []int{1, 1}, Order{Strict: true})
+		},
+	} {
+		err := check()
+		require.ErrorIs(t, err, ErrUnsorted, name)
+		var unsorted *UnsortedError
+		require.ErrorAs(t, err, &unsorted, name)
+	}
+}
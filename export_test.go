@@ -0,0 +1,6 @@
+package testdemo
+
+// Test helpers of the package, exported for the tests in testdemo_test.
+type Version = version
+
+var CompareVersions = compareVersions
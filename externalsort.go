@@ -0,0 +1,224 @@
+package testdemo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+)
+
+// ExternalSortStats describes what an ExternalSort did.
+type ExternalSortStats struct {
+	// Values is how many values were sorted.
+	Values int64
+	// Runs is how many sorted runs the input was split into, each sorted
+	// in memory and written to its own temporary file before the merge.
+	Runs int
+}
+
+// mergeFanIn is how many runs ExternalSort merges at once, and so the
+// most run files it holds open.
+const mergeFanIn = 64
+
+// ExternalSort sorts the whitespace-separated integers in the file at
+// inPath, which may be too large for memory, into the file at outPath,
+// one per line. It reads the input in runs of at most memBudget values,
+// sorts each and writes it to a temporary file, then merges the runs as
+// MergeFiles does, mergeFanIn at a time: while there are more runs than
+// that, it merges the oldest of them into a new run, so that however
+// large the input, only so many files are open at once. The temporary
+// files are kept in a directory next to outPath, removed whether or not
+// the sort succeeds, and outPath is only replaced, with a file of mode
+// 0o644, once the output is complete.
+//
+// An optional Progress is reported to in bytes: those of the input read
+// into runs, then those of the runs read back by the merge passes,
+// scaled to the size of the input, so that the total is twice that size.
+func ExternalSort(outPath, inPath string, memBudget int, progress ...Progress) (ExternalSortStats, error) {
+	var stats ExternalSortStats
+	if memBudget < 1 {
+		return stats, fmt.Errorf("external sort: memory budget %d is less than one value", memBudget)
+	}
+	in, err := os.Open(inPath)
+	if err != nil {
+		return stats, err
+	}
+	defer in.Close()
+	dir, err := os.MkdirTemp(filepath.Dir(outPath), ".externalsort-*")
+	if err != nil {
+		return stats, err
+	}
+	defer os.RemoveAll(dir)
+
+	size := remainingSize(in)
+	total := int64(-1)
+	if size >= 0 {
+		total = 2 * size
+	}
+	report := firstProgress(progress).reporter(total)
+	counted := &countingReader{r: in}
+	tok := newTokenizer(counted)
+	run := make([]int64, 0, min(memBudget, 1<<16))
+	var runs []string
+	var runSizes []int64
+	for done := false; !done; {
+		run = run[:0]
+		for len(run) < memBudget {
+			if !tok.Scan() {
+				done = true
+				break
+			}
+			v, err := strconv.ParseInt(tok.Text(), 10, 64)
+			if err != nil {
+				return stats, fmt.Errorf("%s: line %d: invalid number %q: %w", inPath, tok.line, tok.Text(), err)
+			}
+			run = append(run, v)
+			report.update(counted.n)
+		}
+		if err := tok.Err(); err != nil {
+			return stats, fmt.Errorf("%s: %w", inPath, err)
+		}
+		if len(run) == 0 {
+			break
+		}
+		stats.Values += int64(len(run))
+		slices.Sort(run)
+		path, n, err := writeRun(dir, run)
+		if err != nil {
+			return stats, err
+		}
+		runs = append(runs, path)
+		runSizes = append(runSizes, n)
+		stats.Runs++
+	}
+
+	mergeBytes := plannedMergeBytes(runSizes)
+	// done, once the input is read, is where the merge starts from.
+	done, merged := counted.n, int64(0)
+	if size >= 0 {
+		done = size
+	}
+	mergeDone := func() int64 {
+		if size >= 0 && mergeBytes > 0 {
+			// size*merged can overflow, but the quotient is at most size.
+			hi, lo := bits.Mul64(uint64(size), uint64(merged))
+			q, _ := bits.Div64(hi, lo, uint64(mergeBytes))
+			return done + int64(q)
+		}
+		return done + merged
+	}
+	onRead := func(n int64) {
+		merged += n
+		report.update(mergeDone())
+	}
+	for len(runs) > mergeFanIn {
+		path, err := mergeIntoRun(dir, runs[:mergeFanIn], onRead)
+		if err != nil {
+			return stats, err
+		}
+		runs = append(runs[mergeFanIn:], path)
+	}
+	err = writeFileAtomic(outPath, func(w io.Writer) error {
+		return mergeRunFiles(w, runs, onRead)
+	})
+	if err == nil {
+		if size >= 0 {
+			report.finish(total)
+		} else {
+			report.finish(mergeDone())
+		}
+	}
+	return stats, err
+}
+
+// plannedMergeBytes returns how many bytes the merge passes of
+// ExternalSort read back from runs of the given sizes. A merged run is
+// written in the same format as the runs it merges, so its size is the
+// sum of theirs.
+func plannedMergeBytes(sizes []int64) int64 {
+	sizes = slices.Clone(sizes)
+	var total int64
+	for len(sizes) > mergeFanIn {
+		var merged int64
+		for _, n := range sizes[:mergeFanIn] {
+			merged += n
+		}
+		total += merged
+		sizes = append(sizes[mergeFanIn:], merged)
+	}
+	for _, n := range sizes {
+		total += n
+	}
+	return total
+}
+
+// mergeIntoRun merges the runs at paths into a new run file in dir,
+// removes them, and returns the new run's path.
+func mergeIntoRun(dir string, paths []string, onRead func(n int64)) (string, error) {
+	f, err := os.CreateTemp(dir, "run-*")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	bw := bufio.NewWriter(f)
+	if err := mergeRunFiles(bw, paths, onRead); err != nil {
+		return "", err
+	}
+	if err := bw.Flush(); err != nil {
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return "", err
+		}
+	}
+	return f.Name(), nil
+}
+
+// mergeRunFiles opens the runs at paths and merges them to w, closing
+// them again before it returns.
+func mergeRunFiles(w io.Writer, paths []string, onRead func(n int64)) error {
+	sources := make([]*intSource, 0, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		sources = append(sources, newIntSource(f, path))
+	}
+	return mergeSources(w, sources, onRead)
+}
+
+// writeRun writes the sorted run to a new file in dir, one value per
+// line, and returns its path and size. The file is closed again.
+func writeRun(dir string, run []int64) (path string, size int64, err error) {
+	f, err := os.CreateTemp(dir, "run-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	bw := bufio.NewWriter(f)
+	var line []byte
+	for _, v := range run {
+		line = strconv.AppendInt(line[:0], v, 10)
+		line = append(line, '\n')
+		bw.Write(line)
+		size += int64(len(line))
+	}
+	// bufio.Writer keeps the first write error and returns it here.
+	if err := bw.Flush(); err != nil {
+		return "", 0, err
+	}
+	if err := f.Close(); err != nil {
+		return "", 0, err
+	}
+	return f.Name(), size, nil
+}
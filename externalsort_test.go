@@ -0,0 +1,102 @@
+package testdemo
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalSort(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Size      int
+		MemBudget int
+		Runs      int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			dir := t.TempDir()
+			rng := rand.New(rand.NewSource(1))
+			data := make([]int, tc.Size)
+			var sb strings.Builder
+			for i := range data {
+				data[i] = rng.Intn(1000) - 500
+				fmt.Fprintf(&sb, "%d ", data[i])
+			}
+			in := writeTestFile(t, dir, "in.txt", sb.String())
+			out := filepath.Join(dir, "out.txt")
+
+			stats, err := ExternalSort(out, in, tc.MemBudget)
+			require.NoError(t, err)
+			require.Equal(t, ExternalSortStats{Values: int64(tc.Size), Runs: tc.Runs}, stats)
+
+			f, err := os.Open(out)
+			require.NoError(t, err)
+			defer f.Close()
+			sorted, err := IsSortedReader(f)
+			require.NoError(t, err)
+			require.True(t, sorted)
+			require.True(t, IsPermutationOf(data, readInts(t, out)))
+			requireOnlyFiles(t, dir, "in.txt", "out.txt")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		MemBudget: 10,
+	})
+	validate(t, testCase{Name: "One run",
+		Size:      10,
+		MemBudget: 10,
+		Runs:      1,
+	})
+	validate(t, testCase{Name: "Many runs",
+		Size:      1000,
+		MemBudget: 64,
+		Runs:      16,
+	})
+	validate(t, testCase{Name: "A run per value",
+		Size:      50,
+		MemBudget: 1,
+		Runs:      50,
+	})
+	validate(t, testCase{Name: "More runs than the fan-in",
+		Size:      3*mergeFanIn + 5,
+		MemBudget: 1,
+		Runs:      3*mergeFanIn + 5,
+	})
+}
+
+func TestExternalSortErrors(t *testing.T) {
+	dir := t.TempDir()
+	// The bad value comes after two full runs have been written.
+	in := writeTestFile(t, dir, "in.txt", "5 4 3 2 1\nx\n")
+	out := filepath.Join(dir, "out.txt")
+	_, err := ExternalSort(out, in, 2)
+	require.EqualError(t, err, in+`: line 2: invalid number "x": strconv.ParseInt: parsing "x": invalid syntax`)
+	requireOnlyFiles(t, dir, "in.txt")
+
+	_, err = ExternalSort(out, in, 0)
+	require.EqualError(t, err, "external sort: memory budget 0 is less than one value")
+	_, err = ExternalSort(out, filepath.Join(dir, "missing.txt"), 2)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	requireOnlyFiles(t, dir, "in.txt")
+}
+
+// requireOnlyFiles checks that dir holds just the named files, so that
+// nothing temporary was left behind.
+func requireOnlyFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	require.ElementsMatch(t, names, got)
+}
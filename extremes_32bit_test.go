@@ -0,0 +1,117 @@
+//go:build 386 || arm || mips || mipsle
+
+package testdemo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file are the equivalents of extremes_64bit_test.go
+// where int is 32 bits: the same inputs at the ends of int, with the
+// results a 32-bit int gives.
+
+func TestDeltaEncodeExtremes(t *testing.T) {
+	deltas, err := DeltaEncode([]int{math.MinInt, math.MaxInt})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0xffffffff80000000, math.MaxUint32}, deltas)
+	data, err := DeltaDecode(deltas)
+	require.NoError(t, err)
+	require.Equal(t, []int{math.MinInt, math.MaxInt}, data)
+
+	// A first value encoded where int is 64 bits may not fit.
+	_, err = DeltaDecode([]uint64{1 << 63, math.MaxUint64, 0})
+	require.EqualError(t, err, "index 0: value -9223372036854775808 overflows int")
+}
+
+func TestMaxGapExtremes(t *testing.T) {
+	gap, index, err := MaxGapSorted([]int{math.MinInt, math.MaxInt})
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MaxUint32), gap, "the whole int range fits in an int64")
+	require.Equal(t, 0, index)
+
+	gap, index, err = MaxGapSorted([]int{math.MinInt + 1, -1, 0, math.MaxInt - 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MaxInt-1), gap)
+	require.Equal(t, 0, index)
+
+	require.Equal(t, int64(math.MaxInt)+1, MaxGapAny([]int{math.MaxInt, 0, math.MinInt}))
+	require.Equal(t, int64(math.MaxUint32), MaxGapAny([]int{math.MaxInt, math.MinInt}))
+}
+
+func TestProgressionExtremes(t *testing.T) {
+	stride, ok := IsArithmeticProgression([]int{math.MinInt, math.MaxInt})
+	require.True(t, ok, "the stride fits in an int64")
+	require.Equal(t, int64(math.MaxUint32), stride)
+	require.Equal(t, -1, NearestProgressionViolation([]int{math.MinInt, math.MaxInt}))
+}
+
+func TestMedianExtremes(t *testing.T) {
+	// float64 holds every 32-bit int exactly.
+	median, err := Median([]int{math.MaxInt, math.MinInt})
+	require.NoError(t, err)
+	require.Equal(t, -0.5, median)
+
+	// The sum overflows int.
+	median, err = Median([]int{math.MaxInt - 4095, math.MaxInt - 8191})
+	require.NoError(t, err)
+	require.Equal(t, float64(math.MaxInt-6143), median)
+}
+
+func TestWriteSortedFileExtremes(t *testing.T) {
+	// No 32-bit values are spread widely enough for fixed-width to win:
+	// each of these takes 5 bytes as a varint rather than 8.
+	var buf bytes.Buffer
+	require.NoError(t, WriteSortedFile(&buf, []int{math.MinInt, 0, math.MaxInt}))
+	require.Equal(t, byte(sortedFileDelta), buf.Bytes()[5])
+	require.Equal(t, sortedFileHeader+3*5, buf.Len())
+	data, err := ReadSortedFile(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []int{math.MinInt, 0, math.MaxInt}, data)
+}
+
+func TestReadSortedFileOverflow(t *testing.T) {
+	// file is a sorted file as a 64-bit machine would write it.
+	file := func(delta bool, lo, hi int64, values ...int64) *bytes.Buffer {
+		var buf bytes.Buffer
+		buf.WriteString(sortedFileMagic)
+		buf.WriteByte(sortedFileVersion)
+		if delta {
+			buf.WriteByte(sortedFileDelta)
+		} else {
+			buf.WriteByte(0)
+		}
+		buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(values))))
+		buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(lo)))
+		buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(hi)))
+		m := NewMonotoneWriter(&buf)
+		for _, v := range values {
+			if delta {
+				require.NoError(t, m.WriteValue(v))
+			} else {
+				buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(v)))
+			}
+		}
+		return &buf
+	}
+	big := int64(math.MaxInt) + 1
+
+	_, err := ReadSortedFile(file(false, 0, big, 0, big))
+	require.ErrorIs(t, err, ErrOverflow)
+	require.EqualError(t, err, "sorted file value overflows int: header max is 2147483648")
+	_, err = ReadSortedFile(file(false, -big-1, 0, -big-1, 0))
+	require.EqualError(t, err, "sorted file value overflows int: header min is -2147483649")
+
+	// With a header that fits, the payload is checked too, rather than
+	// truncated to values that seem out of order.
+	_, err = ReadSortedFile(file(false, 0, 1, 0, big, big+1))
+	require.ErrorIs(t, err, ErrOverflow)
+	require.EqualError(t, err, "sorted file value overflows int: value 1 is 2147483648")
+	_, err = ReadSortedFile(file(true, 0, 1, 0, 1<<40))
+	require.ErrorIs(t, err, ErrOverflow)
+	require.EqualError(t, err, "sorted file value overflows int: value 1 is 1099511627776")
+}
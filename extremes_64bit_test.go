@@ -0,0 +1,62 @@
+//go:build !386 && !arm && !mips && !mipsle
+
+package testdemo
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file pin down behavior at the ends of a 64-bit int.
+// extremes_32bit_test.go has their equivalents where int is 32 bits.
+
+func TestDeltaEncodeExtremes(t *testing.T) {
+	deltas, err := DeltaEncode([]int{math.MinInt, math.MaxInt})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1 << 63, math.MaxUint64}, deltas)
+	_, err = DeltaDecode([]uint64{1 << 63, math.MaxUint64, 0})
+	require.NoError(t, err)
+}
+
+func TestMaxGapExtremes(t *testing.T) {
+	gap, index, err := MaxGapSorted([]int{math.MinInt, math.MaxInt})
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MaxInt64), gap, "the whole int range saturates")
+	require.Equal(t, 0, index)
+
+	gap, index, err = MaxGapSorted([]int{math.MinInt + 1, -1, 0, math.MaxInt - 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MaxInt64-1), gap)
+	require.Equal(t, 0, index)
+
+	require.Equal(t, int64(math.MaxInt64), MaxGapAny([]int{math.MaxInt, 0, math.MinInt}))
+	require.Equal(t, int64(math.MaxInt64), MaxGapAny([]int{math.MaxInt, math.MinInt}))
+}
+
+func TestProgressionExtremes(t *testing.T) {
+	_, ok := IsArithmeticProgression([]int{math.MinInt, math.MaxInt})
+	require.False(t, ok, "the stride overflows int64")
+	require.Equal(t, 1, NearestProgressionViolation([]int{math.MinInt, math.MaxInt}))
+}
+
+func TestMedianExtremes(t *testing.T) {
+	// float64 rounds math.MaxInt up to 1<<63, the negation of MinInt.
+	median, err := Median([]int{math.MaxInt, math.MinInt})
+	require.NoError(t, err)
+	require.Equal(t, 0.0, median)
+
+	// The sum overflows int.
+	median, err = Median([]int{math.MaxInt - 4095, math.MaxInt - 8191})
+	require.NoError(t, err)
+	require.Equal(t, float64(1<<63-6144), median)
+}
+
+func TestWriteSortedFileExtremes(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSortedFile(&buf, []int{math.MinInt, 0, math.MaxInt}))
+	require.Equal(t, byte(0), buf.Bytes()[5], "widely spread data should be fixed-width")
+	require.Equal(t, sortedFileHeader+3*8, buf.Len())
+}
@@ -1,6 +1,7 @@
 package testdemo
 
 import (
+	"github.com/StevenACoffman/testdemo/internal/testcases"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -17,4 +18,8 @@ func TestIsSortedF(t *testing.T) {
 	f([]int{0}, true)
 	f([]int{0, 1}, true)  // actually true, but we want to see failures
 	f([]int{1, 0}, false) // actually false, but we want to see failures
+
+	for _, tc := range testcases.Cases() {
+		f(tc.Data, tc.Sorted)
+	}
 }
@@ -0,0 +1,57 @@
+package testdemo
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrFrozen is returned by FrozenSorted.Append once the container is
+// frozen.
+var ErrFrozen = errors.New("append to frozen container")
+
+// FrozenSorted is an append-only container that stays sorted in
+// non-decreasing order by rejecting any value less than the last one.
+// Once frozen it rejects every value. The zero value is an empty, unfrozen
+// container.
+//
+// It is not safe for concurrent use: calls must not overlap, though
+// handing it from one goroutine to another, as through a channel, is fine.
+type FrozenSorted struct {
+	values []int
+	frozen bool
+}
+
+// Append adds v after the values already appended. It returns ErrFrozen
+// once the container is frozen, and an *UnsortedError holding the last
+// value, v and the current length, as the index v would have had, when v
+// is less than the last value.
+func (f *FrozenSorted) Append(v int) error {
+	if f.frozen {
+		return ErrFrozen
+	}
+	if n := len(f.values); n > 0 && v < f.values[n-1] {
+		return &UnsortedError{Index: n, Prev: f.values[n-1], Next: v}
+	}
+	f.values = append(f.values, v)
+	return nil
+}
+
+// Freeze stops any more values being appended and returns a copy of the
+// values, in order. It can be called again to get another copy.
+func (f *FrozenSorted) Freeze() []int {
+	f.frozen = true
+	return slices.Clone(f.values)
+}
+
+// Len returns the number of values appended.
+func (f *FrozenSorted) Len() int {
+	return len(f.values)
+}
+
+// Last returns the last value appended, or false when there is none.
+func (f *FrozenSorted) Last() (int, bool) {
+	if len(f.values) == 0 {
+		return 0, false
+	}
+	return f.values[len(f.values)-1], true
+}
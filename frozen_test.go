@@ -0,0 +1,77 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrozenSorted(t *testing.T) {
+	var f FrozenSorted
+	_, ok := f.Last()
+	require.False(t, ok)
+	require.NoError(t, f.Append(1))
+	require.NoError(t, f.Append(3))
+	require.NoError(t, f.Append(3))
+
+	err := f.Append(2)
+	require.Equal(t, &UnsortedError{Index: 3, Prev: 3, Next: 2}, err)
+	require.EqualError(t, err, "index 3: 3 followed by 2")
+	require.Equal(t, 3, f.Len())
+
+	last, ok := f.Last()
+	require.True(t, ok)
+	require.Equal(t, 3, last)
+
+	out := f.Freeze()
+	require.Equal(t, []int{1, 3, 3}, out)
+	require.ErrorIs(t, f.Append(4), ErrFrozen)
+	out[0] = 9
+	require.Equal(t, []int{1, 3, 3}, f.Freeze(), "the frozen values must be a copy")
+}
+
+func TestFrozenSortedRandomStreams(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 200; i++ {
+		var f FrozenSorted
+		appended, rejected := rng.Intn(50), 0
+		v := 0
+		for j := 0; j < appended; j++ {
+			if rng.Intn(3) == 0 {
+				v -= rng.Intn(3) // sometimes out of order
+			} else {
+				v += rng.Intn(3)
+			}
+			if f.Append(v) != nil {
+				rejected++
+			}
+		}
+		out := f.Freeze()
+		require.True(t, IsSorted(out), "%v", out)
+		require.Len(t, out, appended-rejected)
+	}
+}
+
+// TestFrozenSortedHandOff is the documented usage from more than one
+// goroutine, one at a time; go test -race checks it needs no more.
+func TestFrozenSortedHandOff(t *testing.T) {
+	handoff := make(chan *FrozenSorted)
+	done := make(chan []int)
+	go func() {
+		f := <-handoff
+		for v := 10; v < 20; v++ {
+			require.NoError(t, f.Append(v))
+		}
+		done <- f.Freeze()
+	}()
+	f := &FrozenSorted{}
+	for v := 0; v < 10; v++ {
+		require.NoError(t, f.Append(v))
+	}
+	handoff <- f
+	out := <-done
+	require.Len(t, out, 20)
+	require.True(t, IsSorted(out))
+	require.ErrorIs(t, f.Append(20), ErrFrozen)
+}
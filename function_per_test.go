@@ -2,6 +2,7 @@ package testdemo
 
 import (
 	"github.com/stretchr/testify/require"
+	"math"
 	"testing"
 )
 
@@ -18,7 +19,7 @@ func TestPerFunctionOneElementIsSorted(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 func TestPerFunctionUnsortedIsNotSorted(t *testing.T) {
-	data := []int{0, -9223372036854775808}
+	data := []int{0, math.MinInt}
 	actual := IsSorted(data)
 	expected := false
 	require.Equal(t, expected, actual)
@@ -0,0 +1,208 @@
+package testdemo
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/fuzzdata"
+	"github.com/StevenACoffman/testdemo/internal/gen"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate go run ./internal/corpus2table -corpus testdata/fuzz/FuzzIsSorted -out generated_cases_test.go
+
+func FuzzIsSorted(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil))
+	f.Add(fuzzdata.Bytes([]int{0, math.MinInt}))
+	f.Add(fuzzdata.Bytes([]int{0, 0}))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		data := fuzzdata.Ints(b)
+		require.Equal(t, sort.IntsAreSorted(data), IsSorted(data))
+
+		// Fuzzed slices are mostly too short for IsSorted to take the
+		// vectorized path, so both paths are run directly.
+		want := firstUnsortedScalar(data)
+		require.Equal(t, sort.IntsAreSorted(data), want < 0)
+		if haveVector {
+			require.Equal(t, want, firstUnsortedVector(data))
+		}
+	})
+}
+
+func FuzzInterpolationSearch(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil), int64(0))
+	f.Add(fuzzdata.Bytes([]int{math.MinInt, 0, math.MaxInt}), int64(1))
+	f.Add(fuzzdata.Bytes([]int{5, 5, 5}), int64(5))
+	f.Add(fuzzdata.Bytes([]int{1, 2, 3, 1000000}), int64(3))
+	f.Fuzz(func(t *testing.T, b []byte, target int64) {
+		data := fuzzdata.Ints(b)
+		sort.Ints(data)
+		wantIndex, wantFound := Search(data, int(target))
+		index, found := InterpolationSearch(data, int(target))
+		require.Equal(t, wantFound, found)
+		require.Equal(t, wantIndex, index)
+	})
+}
+
+func FuzzSetOps(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil), uint8(0))
+	f.Add(fuzzdata.Bytes([]int{1, 2, 3, 1, 2, 3}), uint8(1))
+	f.Add(fuzzdata.Bytes([]int{5, -1, 5, 0, 9, -1, 2, 2}), uint8(7))
+	f.Fuzz(func(t *testing.T, b []byte, k uint8) {
+		// Deal the values round-robin into between one and eight lists.
+		lists := make([][]int, int(k)%8+1)
+		for i, v := range fuzzdata.Ints(b) {
+			lists[i%len(lists)] = append(lists[i%len(lists)], v)
+		}
+		in := make([]map[int]bool, len(lists))
+		union := map[int]bool{}
+		for i, l := range lists {
+			sort.Ints(l)
+			in[i] = map[int]bool{}
+			for _, v := range l {
+				in[i][v] = true
+				union[v] = true
+			}
+		}
+
+		want := make([]int, 0, len(union))
+		for v := range union {
+			want = append(want, v)
+		}
+		sort.Ints(want)
+		require.Equal(t, want, UnionSortedK(lists...))
+
+		// The symmetric difference is checked between the first two lists,
+		// the second being empty when there is only one.
+		second, inSecond := []int(nil), map[int]bool{}
+		if len(lists) > 1 {
+			second, inSecond = lists[1], in[1]
+		}
+		var wantSym []int
+		for _, v := range want {
+			if in[0][v] != inSecond[v] {
+				wantSym = append(wantSym, v)
+			}
+		}
+		require.Equal(t, wantSym, SymmetricDifferenceSorted(lists[0], second))
+	})
+}
+
+func FuzzIsPermutationOf(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil), fuzzdata.Bytes(nil), int64(0))
+	f.Add(fuzzdata.Bytes([]int{1, 2, 2}), fuzzdata.Bytes([]int{2, 1, 2}), int64(1))
+	f.Add(fuzzdata.Bytes([]int{1, 2, 2}), fuzzdata.Bytes([]int{1, 1, 2}), int64(2))
+	f.Fuzz(func(t *testing.T, ab, bb []byte, seed int64) {
+		a, b := fuzzdata.Ints(ab), fuzzdata.Ints(bb)
+		require.Equal(t, IsPermutationOf(a, b), IsPermutationOf(b, a))
+		require.Equal(t, IsPermutationOf(a, b), IsPermutationOfComparable(a, b))
+
+		require.True(t, IsPermutationOf(a, gen.ShuffleSeeded(a, seed)))
+	})
+}
+
+func FuzzArgsort(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil))
+	f.Add(fuzzdata.Bytes([]int{3, 1, 2, 1}))
+	f.Add(fuzzdata.Bytes([]int{math.MaxInt, math.MinInt, 0}))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		data := fuzzdata.Ints(b)
+		perm := Argsort(data)
+		require.NoError(t, VerifyArgsort(data, perm))
+
+		sorted, err := ApplyPermutation(data, perm)
+		require.NoError(t, err)
+		require.True(t, IsSorted(sorted))
+		for i := 1; i < len(perm); i++ {
+			if sorted[i-1] == sorted[i] {
+				require.Less(t, perm[i-1], perm[i], "equal values must keep their order")
+			}
+		}
+	})
+}
+
+func FuzzRadixSortInt64(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil))
+	f.Add(fuzzdata.Bytes([]int{math.MaxInt, math.MinInt, 0, -1}))
+	f.Add(fuzzdata.Bytes([]int{256, 1, 255, -256}))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		ints := fuzzdata.Ints(b)
+		data := make([]int64, len(ints))
+		for i, v := range ints {
+			data[i] = int64(v)
+		}
+		RadixSortInt64(data)
+
+		sorted := make([]int, len(data))
+		for i, v := range data {
+			sorted[i] = int(v)
+		}
+		require.True(t, IsSorted(sorted))
+		require.True(t, IsPermutationOf(ints, sorted))
+	})
+}
+
+func FuzzDeltaEncode(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil))
+	f.Add(fuzzdata.Bytes([]int{math.MinInt, math.MaxInt}))
+	f.Add(fuzzdata.Bytes([]int{3, 1, 2}))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		data := fuzzdata.Ints(b)
+		sort.Ints(data)
+		deltas, err := DeltaEncode(data)
+		require.NoError(t, err)
+		decoded, err := DeltaDecode(deltas)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	})
+}
+
+func FuzzMonotoneStream(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil))
+	f.Add(fuzzdata.Bytes([]int{math.MinInt, math.MaxInt}))
+	f.Add(fuzzdata.Bytes([]int{3, 1, 2}))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		data := fuzzdata.Ints(b)
+		sort.Ints(data)
+		var buf bytes.Buffer
+		w := NewMonotoneWriter(&buf)
+		for _, v := range data {
+			require.NoError(t, w.WriteValue(int64(v)))
+		}
+		got, err := readAllValues(NewMonotoneReader(&buf))
+		require.NoError(t, err)
+		require.Len(t, got, len(data))
+		for i, v := range got {
+			require.Equal(t, int64(data[i]), v)
+		}
+
+		// Whatever the input, reading it as a stream must not panic, and
+		// whatever it reads must be in order.
+		got, _ = readAllValues(NewMonotoneReader(bytes.NewReader(b)))
+		for i := 1; i < len(got); i++ {
+			require.LessOrEqual(t, got[i-1], got[i])
+		}
+	})
+}
+
+// FuzzValidateLess checks that ValidateLess accepts a correct comparator
+// on any int32 values, and that it rejects the overflowing a-b < 0 one
+// whenever the int32 extremes are among them.
+func FuzzValidateLess(f *testing.F) {
+	f.Add(fuzzdata.Bytes(nil))
+	f.Add(fuzzdata.Bytes([]int{1, -1, 0}))
+	f.Add(fuzzdata.Bytes([]int{math.MaxInt32, 5, math.MinInt32}))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var values []int32
+		for _, v := range fuzzdata.Ints(b) {
+			values = append(values, int32(v))
+		}
+		require.NoError(t, ValidateLess(values, func(a, b int32) bool { return a < b }))
+
+		// Put the extremes first so the size cap cannot drop them.
+		values = append([]int32{math.MinInt32, 0, math.MaxInt32}, values...)
+		require.Error(t, ValidateLess(values, overflowLess))
+	})
+}
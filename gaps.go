@@ -0,0 +1,80 @@
+package testdemo
+
+import "math"
+
+// MaxGapSorted returns the largest difference data[i+1]-data[i] between
+// consecutive elements of data, which must be sorted in non-decreasing
+// order, and the index i of the first pair with it. It returns an
+// *UnsortedError, found in the same pass, when data is not sorted, and a
+// gap of 0 at index -1 when data has fewer than two elements.
+//
+// The differences are taken in uint64, so extreme values do not
+// overflow; a gap larger than math.MaxInt64, which only the span of
+// nearly the whole int range can produce, is reported as math.MaxInt64.
+func MaxGapSorted(data []int) (gap int64, index int, err error) {
+	var widest uint64
+	index = -1
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			return 0, -1, &UnsortedError{Index: i, Prev: data[i-1], Next: data[i]}
+		}
+		if d := uint64(data[i]) - uint64(data[i-1]); d > widest || index < 0 {
+			widest, index = d, i-1
+		}
+	}
+	return saturateGap(widest), index, nil
+}
+
+// MaxGapAny returns the largest gap between consecutive elements of data
+// once sorted, without sorting it: it spreads the elements across at
+// most len(data) equal-width buckets, each narrower than the largest gap
+// must be, so that gap falls between the largest element of one nonempty
+// bucket and the smallest of the next. It takes O(n) time and space, and
+// saturates at math.MaxInt64 like MaxGapSorted.
+func MaxGapAny(data []int) int64 {
+	if len(data) < 2 {
+		return 0
+	}
+	lo, hi := data[0], data[0]
+	for _, v := range data[1:] {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	span := uint64(hi) - uint64(lo)
+	if span == 0 {
+		return 0
+	}
+	// The len(data)-1 gaps add up to span, so the largest is at least
+	// ceil(span/(len(data)-1)); elements sharing a bucket of that width
+	// are closer together than that.
+	width := (span-1)/uint64(len(data)-1) + 1
+	type bucket struct {
+		lo, hi uint64
+		used   bool
+	}
+	buckets := make([]bucket, span/width+1)
+	for _, v := range data {
+		off := uint64(v) - uint64(lo)
+		b := &buckets[off/width]
+		if !b.used {
+			*b = bucket{lo: off, hi: off, used: true}
+			continue
+		}
+		b.lo, b.hi = min(b.lo, off), max(b.hi, off)
+	}
+	var widest, prev uint64
+	for _, b := range buckets {
+		if !b.used {
+			continue
+		}
+		widest = max(widest, b.lo-prev)
+		prev = b.hi
+	}
+	return saturateGap(widest)
+}
+
+func saturateGap(gap uint64) int64 {
+	if gap > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(gap)
+}
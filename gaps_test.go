@@ -0,0 +1,86 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxGapSorted(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Array []int
+		Gap   int64
+		Index int
+		Err   error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			gap, index, err := MaxGapSorted(tc.Array)
+			require.Equal(t, tc.Err, err)
+			require.Equal(t, tc.Gap, gap, "gap")
+			require.Equal(t, tc.Index, index, "index")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+		Index: -1,
+	})
+	validate(t, testCase{Name: "Single",
+		Array: []int{7},
+		Index: -1,
+	})
+	validate(t, testCase{Name: "All equal",
+		Array: []int{3, 3, 3},
+		Index: 0,
+	})
+	validate(t, testCase{Name: "First widest wins",
+		Array: []int{1, 4, 5, 8},
+		Gap:   3,
+		Index: 0,
+	})
+	validate(t, testCase{Name: "Across zero",
+		Array: []int{-5, 5, 6},
+		Gap:   10,
+		Index: 0,
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Array: []int{1, 5, 2},
+		Index: -1,
+		Err:   &UnsortedError{Index: 2, Prev: 5, Next: 2},
+	})
+}
+
+func TestMaxGapAny(t *testing.T) {
+	require.Equal(t, int64(0), MaxGapAny(nil))
+	require.Equal(t, int64(0), MaxGapAny([]int{4}))
+	require.Equal(t, int64(0), MaxGapAny([]int{4, 4, 4}))
+	require.Equal(t, int64(6), MaxGapAny([]int{9, 1, 3}))
+
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]int, rng.Intn(20))
+		for j := range data {
+			switch rng.Intn(4) {
+			case 0:
+				data[j] = rng.Intn(10)
+			case 1:
+				data[j] = math.MaxInt - rng.Intn(10)
+			case 2:
+				data[j] = math.MinInt + rng.Intn(10)
+			default:
+				data[j] = int(rng.Uint64())
+			}
+		}
+		sorted := slices.Clone(data)
+		slices.Sort(sorted)
+		want, _, err := MaxGapSorted(sorted)
+		require.NoError(t, err)
+		require.Equal(t, want, MaxGapAny(data), "%v", data)
+	}
+}
@@ -0,0 +1,42 @@
+// Code generated by corpus2table from testdata/fuzz/FuzzIsSorted; DO NOT EDIT.
+
+//go:build !386 && !arm && !mips && !mipsle
+
+package testdemo
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestCorpus4769189969e5ef7dIsSorted is testdata/fuzz/FuzzIsSorted/4769189969e5ef7d.
+func TestCorpus4769189969e5ef7dIsSorted(t *testing.T) {
+	data := []int{-9223372036854775808, 0, 9223372036854775807}
+	actual := IsSorted(data)
+	expected := true
+	require.Equal(t, expected, actual)
+}
+
+// TestCorpusaac8fc6a939d4fadIsSorted is testdata/fuzz/FuzzIsSorted/aac8fc6a939d4fad.
+func TestCorpusaac8fc6a939d4fadIsSorted(t *testing.T) {
+	data := []int{5, 3}
+	actual := IsSorted(data)
+	expected := false
+	require.Equal(t, expected, actual)
+}
+
+// TestCorpusc13e2fc6ac935301IsSorted is testdata/fuzz/FuzzIsSorted/c13e2fc6ac935301.
+func TestCorpusc13e2fc6ac935301IsSorted(t *testing.T) {
+	data := []int{1, 1, 0}
+	actual := IsSorted(data)
+	expected := false
+	require.Equal(t, expected, actual)
+}
+
+// TestCorpusf1c88ac20d680e0aIsSorted is testdata/fuzz/FuzzIsSorted/f1c88ac20d680e0a.
+func TestCorpusf1c88ac20d680e0aIsSorted(t *testing.T) {
+	data := []int{}
+	actual := IsSorted(data)
+	expected := true
+	require.Equal(t, expected, actual)
+}
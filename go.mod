@@ -1,5 +1,15 @@
 module github.com/StevenACoffman/testdemo
 
-go 1.16
+go 1.23
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/rogpeppe/go-internal v1.12.0
+	github.com/stretchr/testify v1.12.1
+	golang.org/x/sys v0.5.0
+	golang.org/x/text v0.14.0
+)
+
+require (
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/tools v0.6.0 // indirect
+)
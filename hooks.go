@@ -0,0 +1,49 @@
+package testdemo
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Hooks are called by the checkers in this package, so that a service can
+// count checks and violations in one place instead of at every call site.
+// They are called once per call of EnsureSorted, CheckSortedCtx or
+// IsSortedCtx, of the stream checkers CheckReader, CheckLines and
+// CheckCSVColumn, and of AppendChecker, from whichever goroutine runs the
+// check, so they must be safe for concurrent use. Functions such as
+// MergeSorted that check their inputs before using them do not call
+// them, and neither does a check given up because its context is done.
+type Hooks struct {
+	// OnCheck is called once per check with the number of elements it was
+	// given or, for a stream, read.
+	OnCheck func(n int)
+	// OnViolation is called when a check finds elements out of order, with
+	// the index of the first one out of place, as in UnsortedError.
+	OnViolation func(index int)
+}
+
+var hooks atomic.Pointer[Hooks]
+
+// SetHooks installs h as the hooks every check calls, or removes them
+// when h is nil, and returns the ones it replaces. It is safe to call
+// while checks run; each check calls the hooks installed when it ends.
+// With no hooks installed, checks pay nothing but an atomic load.
+func SetHooks(h *Hooks) (previous *Hooks) {
+	return hooks.Swap(h)
+}
+
+// reportCheck calls the installed hooks for a check of n elements that
+// returned err.
+func reportCheck(n int, err error) {
+	h := hooks.Load()
+	if h == nil {
+		return
+	}
+	if h.OnCheck != nil {
+		h.OnCheck(n)
+	}
+	var unsorted *UnsortedError
+	if h.OnViolation != nil && errors.As(err, &unsorted) {
+		h.OnViolation(unsorted.Index)
+	}
+}
@@ -0,0 +1,189 @@
+package testdemo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingHooks installs hooks counting checks, the elements they are
+// given and violations, until the test ends.
+type countingHooks struct {
+	checks, elements, violations atomic.Int64
+	// lastViolation is the index of the last violation reported.
+	lastViolation atomic.Int64
+}
+
+func (c *countingHooks) hooks() *Hooks {
+	return &Hooks{
+		OnCheck: func(n int) {
+			c.checks.Add(1)
+			c.elements.Add(int64(n))
+		},
+		OnViolation: func(index int) {
+			c.violations.Add(1)
+			c.lastViolation.Store(int64(index))
+		},
+	}
+}
+
+func installCountingHooks(t *testing.T) *countingHooks {
+	t.Helper()
+	c := &countingHooks{}
+	previous := SetHooks(c.hooks())
+	t.Cleanup(func() { SetHooks(previous) })
+	return c
+}
+
+func TestHooks(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Check     func() error
+		Elements  int64
+		Violation int64 // -1 for none
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			c := installCountingHooks(t)
+			err := tc.Check()
+			require.Equal(t, int64(1), c.checks.Load(), "checks")
+			require.Equal(t, tc.Elements, c.elements.Load(), "elements")
+			if tc.Violation < 0 {
+				require.NoError(t, err)
+				require.Zero(t, c.violations.Load(), "violations")
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, int64(1), c.violations.Load(), "violations")
+			require.Equal(t, tc.Violation, c.lastViolation.Load(), "violation index")
+		})
+	}
+	validate(t, testCase{Name: "EnsureSorted",
+		Check:     func() error { return EnsureSorted([]int{1, 2, 3}) },
+		Elements:  3,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "EnsureSorted unsorted",
+		Check:     func() error { return EnsureSorted([]int{1, 3, 2, 4}) },
+		Elements:  4,
+		Violation: 2,
+	})
+	validate(t, testCase{Name: "CheckReader",
+		Check:     func() error { return CheckReader(strings.NewReader("1 2 3"), ReaderOptions{}) },
+		Elements:  3,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "CheckReader unsorted",
+		Check:     func() error { return CheckReader(strings.NewReader("1 3 2 4"), ReaderOptions{}) },
+		Elements:  3,
+		Violation: 2,
+	})
+	validate(t, testCase{Name: "CheckLines unsorted",
+		Check:     func() error { return CheckLines(strings.NewReader("key\nb\na\n"), LineOptions{Header: true}) },
+		Elements:  2,
+		Violation: 1,
+	})
+	validate(t, testCase{Name: "CheckCSVColumn",
+		Check: func() error {
+			return CheckCSVColumn(strings.NewReader("a,1\nb,2\n"), CSVOptions{Column: 2})
+		},
+		Elements:  2,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "NewAppendChecker unsorted",
+		Check: func() error {
+			_, err := NewAppendChecker([]int{2, 1})
+			return err
+		},
+		Elements:  2,
+		Violation: 1,
+	})
+	validate(t, testCase{Name: "AppendChecker.Append",
+		Check: func() error {
+			c := &AppendChecker{}
+			return c.Append(1, 2, 3)
+		},
+		Elements:  3,
+		Violation: -1,
+	})
+}
+
+func TestHooksOnlyCountChecksCalledFor(t *testing.T) {
+	c := installCountingHooks(t)
+	// These check their inputs only to use them, and report nothing.
+	sorted, unsorted := []int{1, 2, 3}, []int{2, 1}
+	_, err := MergeSorted(sorted, sorted)
+	require.NoError(t, err)
+	_, err = MergeSorted(sorted, unsorted)
+	require.Error(t, err)
+	_, err = PercentileSorted(sorted, 50)
+	require.NoError(t, err)
+	_, err = RankChecked(sorted, 2)
+	require.NoError(t, err)
+	_, err = SelectChecked(unsorted, 0)
+	require.Error(t, err)
+	_, _, err = BucketizeSorted(sorted, 2)
+	require.NoError(t, err)
+
+	// Neither is a check given up because its context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, CheckSortedCtx(ctx, sorted, Order{}), context.Canceled)
+	require.Zero(t, c.checks.Load(), "checks")
+	require.Zero(t, c.violations.Load(), "violations")
+
+	// A finished check is reported once, even one made with a context
+	// that is done by the time it returns.
+	require.NoError(t, CheckSortedCtx(ctx, []int{1}, Order{}))
+	require.Equal(t, int64(1), c.checks.Load(), "checks")
+}
+
+func TestHooksConcurrent(t *testing.T) {
+	c := installCountingHooks(t)
+	// Swapping in other hooks while checks run must be race-free, and
+	// every check must be counted by one of them.
+	other := &countingHooks{}
+	swapped := make(chan struct{})
+	go func() {
+		defer close(swapped)
+		hooks := []*Hooks{c.hooks(), other.hooks()}
+		for i := 0; i < 100; i++ {
+			SetHooks(hooks[i%2])
+		}
+	}()
+	sorted := []int{1, 2, 3, 4}
+	unsorted := []int{4, 3}
+	const goroutines, checks = 8, 100
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < checks; i++ {
+				EnsureSorted(sorted)
+				EnsureSorted(unsorted)
+				CheckReader(strings.NewReader("1 2"), ReaderOptions{})
+			}
+		}()
+	}
+	wg.Wait()
+	<-swapped
+	require.Equal(t, int64(3*goroutines*checks), c.checks.Load()+other.checks.Load())
+	require.Equal(t, int64(goroutines*checks*(4+2+2)), c.elements.Load()+other.elements.Load())
+	require.Equal(t, int64(goroutines*checks), c.violations.Load()+other.violations.Load())
+}
+
+func TestNoHooks(t *testing.T) {
+	previous := SetHooks(nil)
+	t.Cleanup(func() { SetHooks(previous) })
+	require.NoError(t, EnsureSorted([]int{1, 2}))
+	require.Error(t, EnsureSorted([]int{2, 1}))
+	SetHooks(&Hooks{})
+	require.Error(t, EnsureSorted([]int{2, 1}), "hooks without funcs must be ignored")
+}
@@ -0,0 +1,117 @@
+// Package httpapi exposes the sortedness checks over HTTP.
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/StevenACoffman/testdemo"
+)
+
+// DefaultMaxBodyBytes is the largest request body NewHandler accepts
+// unless MaxBodyBytes says otherwise.
+const DefaultMaxBodyBytes = 10 << 20
+
+// Option configures NewHandler.
+type Option func(*handler)
+
+// MaxBodyBytes limits the size of request bodies, which are rejected with
+// 413 Request Entity Too Large when they are bigger.
+func MaxBodyBytes(n int64) Option {
+	return func(h *handler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// CheckRequest is the body of a POST /v1/check request.
+type CheckRequest struct {
+	Values     *[]int `json:"values"`
+	Strict     bool   `json:"strict"`
+	Descending bool   `json:"descending"`
+}
+
+// CheckResponse is the body of a successful POST /v1/check response.
+// ViolationIndex, Prev and Next are null when the values are sorted.
+type CheckResponse struct {
+	Sorted         bool `json:"sorted"`
+	ViolationIndex *int `json:"violationIndex"`
+	Prev           *int `json:"prev"`
+	Next           *int `json:"next"`
+}
+
+// ErrorResponse is the body of every response that is not a 200.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type handler struct {
+	maxBodyBytes int64
+}
+
+// NewHandler returns a handler serving POST /v1/check, which reports
+// whether the values in the request are in the requested order, and
+// where the first pair out of order is. Malformed requests get a 400,
+// other methods a 405, and requests whose context is done before the
+// check finishes a 503.
+func NewHandler(opts ...Option) http.Handler {
+	h := &handler{maxBodyBytes: DefaultMaxBodyBytes}
+	for _, opt := range opts {
+		opt(h)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/check", h.check)
+	return mux
+}
+
+func (h *handler) check(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "only POST is allowed"})
+		return
+	}
+
+	var req CheckRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&req)
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("request body must be a single JSON object")
+	}
+	if err == nil && req.Values == nil {
+		err = errors.New(`"values" is required`)
+	}
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeJSON(w, http.StatusRequestEntityTooLarge, ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	values := *req.Values
+	err = testdemo.CheckSortedCtx(r.Context(), values, testdemo.Order{Descending: req.Descending, Strict: req.Strict})
+	var unsorted *testdemo.UnsortedError
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, CheckResponse{Sorted: true})
+	case errors.As(err, &unsorted):
+		index := unsorted.Index
+		writeJSON(w, http.StatusOK, CheckResponse{
+			ViolationIndex: &index,
+			Prev:           &values[index-1],
+			Next:           &values[index],
+		})
+	default:
+		writeJSON(w, http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,161 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	server := httptest.NewServer(NewHandler(MaxBodyBytes(1024)))
+	defer server.Close()
+
+	type testCase struct {
+		Name           string
+		Method         string
+		Path           string
+		Body           string
+		ExpectedStatus int
+		ExpectedBody   string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			method := tc.Method
+			if method == "" {
+				method = http.MethodPost
+			}
+			path := tc.Path
+			if path == "" {
+				path = "/v1/check"
+			}
+			req, err := http.NewRequest(method, server.URL+path, strings.NewReader(tc.Body))
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedStatus, resp.StatusCode, string(body))
+			if tc.ExpectedBody != "" {
+				require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+				require.JSONEq(t, tc.ExpectedBody, string(body))
+			}
+		})
+	}
+	validate(t, testCase{Name: "Sorted",
+		Body:           `{"values": [1, 2, 2, 3]}`,
+		ExpectedStatus: http.StatusOK,
+		ExpectedBody:   `{"sorted": true, "violationIndex": null, "prev": null, "next": null}`,
+	})
+	validate(t, testCase{Name: "Empty",
+		Body:           `{"values": []}`,
+		ExpectedStatus: http.StatusOK,
+		ExpectedBody:   `{"sorted": true, "violationIndex": null, "prev": null, "next": null}`,
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Body:           `{"values": [1, 5, 4]}`,
+		ExpectedStatus: http.StatusOK,
+		ExpectedBody:   `{"sorted": false, "violationIndex": 2, "prev": 5, "next": 4}`,
+	})
+	validate(t, testCase{Name: "Strict",
+		Body:           `{"values": [1, 2, 2], "strict": true}`,
+		ExpectedStatus: http.StatusOK,
+		ExpectedBody:   `{"sorted": false, "violationIndex": 2, "prev": 2, "next": 2}`,
+	})
+	validate(t, testCase{Name: "Descending",
+		Body:           `{"values": [3, 2, 2], "descending": true}`,
+		ExpectedStatus: http.StatusOK,
+		ExpectedBody:   `{"sorted": true, "violationIndex": null, "prev": null, "next": null}`,
+	})
+	validate(t, testCase{Name: "Malformed JSON",
+		Body:           `{"values": [1, 2`,
+		ExpectedStatus: http.StatusBadRequest,
+		ExpectedBody:   `{"error": "unexpected EOF"}`,
+	})
+	validate(t, testCase{Name: "Not integers",
+		Body:           `{"values": [1.5]}`,
+		ExpectedStatus: http.StatusBadRequest,
+	})
+	validate(t, testCase{Name: "Unknown field",
+		Body:           `{"values": [], "reverse": true}`,
+		ExpectedStatus: http.StatusBadRequest,
+		ExpectedBody:   `{"error": "json: unknown field \"reverse\""}`,
+	})
+	validate(t, testCase{Name: "Missing values",
+		Body:           `{"strict": true}`,
+		ExpectedStatus: http.StatusBadRequest,
+		ExpectedBody:   `{"error": "\"values\" is required"}`,
+	})
+	validate(t, testCase{Name: "Trailing data",
+		Body:           `{"values": []} {}`,
+		ExpectedStatus: http.StatusBadRequest,
+		ExpectedBody:   `{"error": "request body must be a single JSON object"}`,
+	})
+	validate(t, testCase{Name: "Too large",
+		Body:           `{"values": [` + strings.Repeat("1,", 600) + `1]}`,
+		ExpectedStatus: http.StatusRequestEntityTooLarge,
+		ExpectedBody:   `{"error": "http: request body too large"}`,
+	})
+	validate(t, testCase{Name: "Wrong method",
+		Method:         http.MethodGet,
+		ExpectedStatus: http.StatusMethodNotAllowed,
+		ExpectedBody:   `{"error": "only POST is allowed"}`,
+	})
+	validate(t, testCase{Name: "Unknown path",
+		Path:           "/v2/check",
+		Body:           `{"values": []}`,
+		ExpectedStatus: http.StatusNotFound,
+	})
+}
+
+func TestCheckWrongMethodAllowHeader(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/v1/check")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.MethodPost, resp.Header.Get("Allow"))
+}
+
+func TestCheckCancelled(t *testing.T) {
+	values := make([]string, 200000)
+	for i := range values {
+		values[i] = fmt.Sprint(i)
+	}
+	body := `{"values": [` + strings.Join(values, ",") + `]}`
+
+	// The request context is done by the time the handler gets to the
+	// check, as it would be if the client went away mid-way.
+	handler := NewHandler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		cancel()
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/v1/check", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	var got ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.Equal(t, "context canceled", got.Error)
+
+	// The same request with a live context succeeds.
+	server2 := httptest.NewServer(handler)
+	defer server2.Close()
+	resp2, err := http.Post(server2.URL+"/v1/check", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusOK, resp2.StatusCode)
+}
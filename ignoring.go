@@ -0,0 +1,21 @@
+package testdemo
+
+// IsSortedIgnoring reports whether the elements of data that are not
+// missing are in non-decreasing order, skipping every element isMissing
+// reports true for, such as a -1 or math.MinInt sentinel. Each kept
+// element is compared with the kept element before it, so a slice with
+// at most one kept element is sorted. It takes a single pass and does
+// not allocate.
+func IsSortedIgnoring(data []int, isMissing func(int) bool) bool {
+	prev, havePrev := 0, false
+	for _, v := range data {
+		if isMissing(v) {
+			continue
+		}
+		if havePrev && v < prev {
+			return false
+		}
+		prev, havePrev = v, true
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package testdemo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedIgnoring(t *testing.T) {
+	isMissing := func(v int) bool { return v == -1 }
+	type testCase struct {
+		Name     string
+		Array    []int
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := IsSortedIgnoring(tc.Array, isMissing)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "All sentinels",
+		Array:    []int{-1, -1, -1},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Sentinels at the ends",
+		Array:    []int{-1, 0, 2, 5, -1},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Sentinels between sorted elements",
+		Array:    []int{3, -1, -1, 4, -1, 4},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Sentinels between a violation",
+		Array:    []int{3, -1, -1, -1, 2},
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Sentinel would be a violation",
+		Array:    []int{3, -1},
+		Expected: true,
+	})
+}
+
+func TestIsSortedIgnoringMinInt(t *testing.T) {
+	isMissing := func(v int) bool { return v == math.MinInt }
+	require.True(t, IsSortedIgnoring([]int{5, math.MinInt, 7}, isMissing))
+	require.False(t, IsSortedIgnoring([]int{5, math.MinInt, 4}, isMissing))
+}
+
+func TestIsSortedIgnoringDoesNotAllocate(t *testing.T) {
+	data := []int{-1, 1, -1, 2, 3, -1}
+	isMissing := func(v int) bool { return v == -1 }
+	require.Zero(t, testing.AllocsPerRun(100, func() { IsSortedIgnoring(data, isMissing) }))
+}
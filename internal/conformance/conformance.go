@@ -0,0 +1,242 @@
+// Package conformance checks that implementations of the same predicate
+// over []int agree on every input, and reports a table of the smallest
+// inputs they disagree on.
+package conformance
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+)
+
+// shapeLens are the lengths of the generated shapes, picked around the
+// vector widths and the vectorMinLen of the sortedness checks.
+var shapeLens = []int{0, 1, 2, 3, 7, 8, 9, 31, 32, 33, 63, 64, 65, 100}
+
+// randomShapes is how many random inputs RunConformance adds, and
+// vectorLen the length past which they take every vectorized path.
+const (
+	randomShapes = 200
+	vectorLen    = 100
+)
+
+// maxShown is the longest input the divergence table prints in full.
+const maxShown = 16
+
+// input is a slice to run the implementations on, and where it came from.
+type input struct {
+	From string
+	Data []int
+}
+
+// divergence is an input the implementations disagree on.
+type divergence struct {
+	// From is where the input that was shrunk to Data came from.
+	From string
+	Data []int
+	// Results is what each implementation returned on Data, by name.
+	Results map[string]string
+}
+
+// reporter is the part of *testing.T RunConformance reports to.
+type reporter interface {
+	Helper()
+	Logf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// RunConformance runs every implementation in impls on every slice of
+// corpus, and on generated shapes: runs of every length in shapeLens,
+// ascending, descending, constant, with one pair swapped and with the
+// extremes of int at the ends, and random inputs from
+// testhelper.SeededRand. It fails t with a table of the inputs the
+// implementations disagree on, each shrunk to a smallest slice on which
+// they still do, and what every implementation returned on it. An
+// implementation that panics returns "panic" in the table.
+//
+// The implementations are given copies of the inputs, so they may modify
+// them.
+func RunConformance(t *testing.T, impls map[string]func([]int) bool, corpus [][]int) {
+	t.Helper()
+	runConformance(t, testhelper.SeededRand(t), impls, corpus)
+}
+
+func runConformance(t reporter, rng *rand.Rand, impls map[string]func([]int) bool, corpus [][]int) {
+	t.Helper()
+	inputs := make([]input, 0, len(corpus))
+	for i, data := range corpus {
+		inputs = append(inputs, input{From: fmt.Sprintf("corpus[%d]", i), Data: data})
+	}
+	inputs = append(inputs, shapes(rng)...)
+	divs := check(impls, inputs)
+	if len(divs) == 0 {
+		t.Logf("conformance: %d implementations agree on %d inputs", len(impls), len(inputs))
+		return
+	}
+	t.Errorf("conformance: the implementations disagree on %d of %d inputs:\n%s", len(divs), len(inputs), table(impls, divs))
+}
+
+// shapes returns the generated inputs.
+func shapes(rng *rand.Rand) []input {
+	var inputs []input
+	add := func(from string, data []int) {
+		inputs = append(inputs, input{From: from, Data: data})
+	}
+	for _, n := range shapeLens {
+		ascending := make([]int, n)
+		descending := make([]int, n)
+		for i := range ascending {
+			ascending[i] = i
+			descending[i] = n - 1 - i
+		}
+		add(fmt.Sprintf("ascending %d", n), ascending)
+		add(fmt.Sprintf("descending %d", n), descending)
+		add(fmt.Sprintf("constant %d", n), make([]int, n))
+		for i := 0; i+1 < n; i++ {
+			swapped := slices.Clone(ascending)
+			swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+			add(fmt.Sprintf("ascending %d swapped at %d", n, i), swapped)
+		}
+		if n > 0 {
+			add(fmt.Sprintf("ascending %d from MinInt", n), append([]int{math.MinInt}, ascending[1:]...))
+			add(fmt.Sprintf("ascending %d to MaxInt", n), append(slices.Clone(ascending[:n-1]), math.MaxInt))
+			add(fmt.Sprintf("ascending %d then MinInt", n), append(slices.Clone(ascending), math.MinInt))
+		}
+	}
+	for i := range randomShapes {
+		data := make([]int, rng.Intn(2*vectorLen))
+		for j := range data {
+			data[j] = rng.Intn(11) - 5
+		}
+		add(fmt.Sprintf("random %d", i), data)
+		sorted := slices.Clone(data)
+		slices.Sort(sorted)
+		add(fmt.Sprintf("random %d sorted", i), sorted)
+	}
+	return inputs
+}
+
+// check returns the inputs the implementations disagree on, shrunk and
+// with duplicates left out.
+func check(impls map[string]func([]int) bool, inputs []input) []divergence {
+	var divs []divergence
+	seen := map[string]bool{}
+	for _, in := range inputs {
+		if agree(results(impls, in.Data)) {
+			continue
+		}
+		data := shrink(impls, in.Data)
+		key := fmt.Sprint(data)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		divs = append(divs, divergence{From: in.From, Data: data, Results: results(impls, data)})
+	}
+	return divs
+}
+
+// results runs every implementation on a copy of data.
+func results(impls map[string]func([]int) bool, data []int) map[string]string {
+	res := make(map[string]string, len(impls))
+	for name, impl := range impls {
+		res[name] = call(impl, slices.Clone(data))
+	}
+	return res
+}
+
+// call returns what impl returns on data, or "panic" when it panics.
+func call(impl func([]int) bool, data []int) (result string) {
+	defer func() {
+		if recover() != nil {
+			result = "panic"
+		}
+	}()
+	return fmt.Sprint(impl(data))
+}
+
+func agree(res map[string]string) bool {
+	first := ""
+	for _, r := range res {
+		if first == "" {
+			first = r
+		} else if r != first {
+			return false
+		}
+	}
+	return true
+}
+
+// shrink returns a smallest slice derived from data on which the
+// implementations still disagree: it drops runs of elements, from half
+// of data down to single ones, and moves values towards zero, for as
+// long as either keeps them disagreeing.
+func shrink(impls map[string]func([]int) bool, data []int) []int {
+	data = slices.Clone(data)
+	disagree := func(d []int) bool { return !agree(results(impls, d)) }
+	for changed := true; changed; {
+		changed = false
+		for size := len(data) / 2; size >= 1; size /= 2 {
+			for start := 0; start+size <= len(data); {
+				candidate := slices.Delete(slices.Clone(data), start, start+size)
+				if disagree(candidate) {
+					data, changed = candidate, true
+					continue
+				}
+				start += size
+			}
+		}
+		for i := range data {
+			for _, v := range []int{0, data[i] / 2} {
+				if v == data[i] {
+					continue
+				}
+				candidate := slices.Clone(data)
+				candidate[i] = v
+				if disagree(candidate) {
+					data, changed = candidate, true
+					break
+				}
+			}
+		}
+	}
+	return data
+}
+
+// table formats divs with a column per implementation, sorted by name.
+func table(impls map[string]func([]int) bool, divs []divergence) string {
+	names := make([]string, 0, len(impls))
+	for name := range impls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  input\tshrunk from\t%s\n", strings.Join(names, "\t"))
+	for _, d := range divs {
+		row := make([]string, len(names))
+		for i, name := range names {
+			row[i] = d.Results[name]
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", formatInput(d.Data), d.From, strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// formatInput prints data, eliding the middle of long slices.
+func formatInput(data []int) string {
+	if len(data) <= maxShown {
+		return fmt.Sprint(data)
+	}
+	head := strings.Trim(fmt.Sprint(data[:maxShown/2]), "[]")
+	tail := strings.Trim(fmt.Sprint(data[len(data)-maxShown/2:]), "[]")
+	return fmt.Sprintf("[%s ... %s] (%d elements)", head, tail, len(data))
+}
@@ -0,0 +1,126 @@
+package conformance
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReporter is a reporter recording what is reported to it.
+type fakeReporter struct {
+	logs, errors []string
+}
+
+func (f *fakeReporter) Helper() {}
+func (f *fakeReporter) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+func (f *fakeReporter) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// ignoresLast is deliberately wrong: it never looks at the last element.
+func ignoresLast(data []int) bool {
+	return sort.IntsAreSorted(data[:max(len(data)-1, 0)])
+}
+
+var wrongImpls = map[string]func([]int) bool{
+	"reference":    sort.IntsAreSorted,
+	"ignores last": ignoresLast,
+}
+
+func TestShrink(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []int
+		Expected []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, shrink(wrongImpls, tc.Data))
+		})
+	}
+	validate(t, testCase{Name: "Already minimal",
+		Data:     []int{0, -1},
+		Expected: []int{0, -1},
+	})
+	validate(t, testCase{Name: "Long prefix",
+		Data:     []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, -7},
+		Expected: []int{0, -1},
+	})
+	validate(t, testCase{Name: "Large values",
+		Data:     []int{-100, 50, 1000, 999},
+		Expected: []int{1, 0},
+	})
+}
+
+func TestCheck(t *testing.T) {
+	divs := check(wrongImpls, []input{
+		{From: "sorted", Data: []int{1, 2, 3}},
+		{From: "unsorted at the end", Data: []int{1, 2, 3, 0}},
+		{From: "unsorted at the start", Data: []int{3, 2, 1}},
+		{From: "unsorted at the end again", Data: []int{5, 4}},
+	})
+	require.Equal(t, []divergence{{
+		From:    "unsorted at the end",
+		Data:    []int{1, 0},
+		Results: map[string]string{"reference": "false", "ignores last": "true"},
+	}}, divs)
+}
+
+func TestTable(t *testing.T) {
+	impls := map[string]func([]int) bool{
+		"reference":    sort.IntsAreSorted,
+		"ignores last": ignoresLast,
+		"panics":       func(data []int) bool { return data[len(data)-1] > 0 },
+	}
+	empty := []int{}
+	unsorted := []int{1, 2, 3, 0}
+	require.Equal(t, ""+
+		"  input      shrunk from          ignores last  panics  reference\n"+
+		"  []         empty                true          panic   true\n"+
+		"  [1 2 3 0]  unsorted at the end  true          false   false",
+		table(impls, []divergence{
+			{From: "empty", Data: empty, Results: results(impls, empty)},
+			{From: "unsorted at the end", Data: unsorted, Results: results(impls, unsorted)},
+		}))
+}
+
+func TestRunConformance(t *testing.T) {
+	f := &fakeReporter{}
+	runConformance(f, rand.New(rand.NewSource(1)), map[string]func([]int) bool{
+		"reference": sort.IntsAreSorted,
+		"again":     sort.IntsAreSorted,
+	}, [][]int{{1, 0}, nil})
+	require.Empty(t, f.errors)
+	require.Len(t, f.logs, 1)
+	require.True(t, strings.HasPrefix(f.logs[0], "conformance: 2 implementations agree on "), f.logs[0])
+
+	f = &fakeReporter{}
+	runConformance(f, rand.New(rand.NewSource(1)), wrongImpls, [][]int{{1, 0}})
+	require.Len(t, f.errors, 1)
+	require.True(t, strings.HasPrefix(f.errors[0], "conformance: the implementations disagree on "), f.errors[0])
+	lines := strings.Split(f.errors[0], "\n")
+	require.Equal(t, []string{"input", "shrunk", "from", "ignores", "last", "reference"}, strings.Fields(lines[1]))
+	// Every input the wrong implementation gets wrong shrinks to one of
+	// two pairs, as values only move towards zero.
+	require.Len(t, lines, 4)
+	require.Equal(t, []string{"[1", "0]", "corpus[0]", "true", "false"}, strings.Fields(lines[2]))
+	require.Equal(t, []string{"[0", "-1]", "ascending", "1", "then", "MinInt", "true", "false"}, strings.Fields(lines[3]))
+}
+
+func TestFormatInput(t *testing.T) {
+	require.Equal(t, "[]", formatInput(nil))
+	require.Equal(t, "[1 2 3]", formatInput([]int{1, 2, 3}))
+	long := make([]int, 40)
+	for i := range long {
+		long[i] = i
+	}
+	require.Equal(t, "[0 1 2 3 4 5 6 7 ... 32 33 34 35 36 37 38 39] (40 elements)", formatInput(long))
+}
@@ -0,0 +1,135 @@
+// Command corpus2table turns the FuzzIsSorted corpus into readable tests,
+// one test function per corpus entry in the style of function_per_test.go.
+//
+// It is run through go generate from the repository root:
+//
+//	go run ./internal/corpus2table -corpus testdata/fuzz/FuzzIsSorted -out generated_cases_test.go
+//
+// Entries decoding to the same slice are only turned into a test once.
+// The generated file only builds where int is 64 bits, like the corpus
+// values.
+// The expected results come from sort.IntsAreSorted, not from the code
+// under test.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/StevenACoffman/testdemo/internal/fuzzdata"
+)
+
+// int64Bits is the build constraint of the generated file. Corpus values
+// are int64s, which only fit in an int on 64-bit platforms.
+const int64Bits = "!386 && !arm && !mips && !mipsle"
+
+type entry struct {
+	// Name is the name of the corpus file.
+	Name string
+	Data []int
+}
+
+func main() {
+	corpus := flag.String("corpus", "testdata/fuzz/FuzzIsSorted", "corpus `directory` to read")
+	out := flag.String("out", "generated_cases_test.go", "test `file` to write")
+	pkg := flag.String("package", "testdemo", "package of the generated file")
+	flag.Parse()
+
+	entries, err := loadCorpus(*corpus)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "corpus2table:", err)
+		os.Exit(1)
+	}
+	src, err := generate(*pkg, filepath.ToSlash(*corpus), entries)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "corpus2table:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "corpus2table:", err)
+		os.Exit(1)
+	}
+}
+
+// loadCorpus decodes every file in dir, dropping entries whose slice is
+// the same as that of an entry whose file name sorts before theirs.
+func loadCorpus(dir string) ([]entry, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var entries []entry
+	seen := map[string]bool{}
+	for _, file := range files { // ReadDir sorts by name
+		if file.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		b, err := fuzzdata.DecodeCorpusFile(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name(), err)
+		}
+		data := fuzzdata.Ints(b)
+		key := fmt.Sprint(data)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		entries = append(entries, entry{Name: file.Name(), Data: data})
+	}
+	return entries, nil
+}
+
+// testName derives a test function name from a corpus file name.
+func testName(file string) string {
+	var sb strings.Builder
+	sb.WriteString("TestCorpus")
+	for _, r := range file {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteString("IsSorted")
+	return sb.String()
+}
+
+// generate returns the gofmt-ed source of the test file for entries.
+func generate(pkg, corpus string, entries []entry) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by corpus2table from %s; DO NOT EDIT.\n\n", corpus)
+	fmt.Fprintf(&buf, "//go:build %s\n\n", int64Bits)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	if len(entries) > 0 {
+		buf.WriteString("import (\n\t\"github.com/stretchr/testify/require\"\n\t\"testing\"\n)\n")
+	}
+	names := map[string]bool{}
+	for _, e := range entries {
+		name := testName(e.Name)
+		if names[name] {
+			return nil, fmt.Errorf("corpus files %q and another one both map to %s", e.Name, name)
+		}
+		names[name] = true
+		values := make([]string, len(e.Data))
+		for i, v := range e.Data {
+			values[i] = strconv.Itoa(v)
+		}
+		fmt.Fprintf(&buf, "\n// %s is %s/%s.\n", name, corpus, e.Name)
+		fmt.Fprintf(&buf, "func %s(t *testing.T) {\n", name)
+		fmt.Fprintf(&buf, "\tdata := []int{%s}\n", strings.Join(values, ", "))
+		buf.WriteString("\tactual := IsSorted(data)\n")
+		fmt.Fprintf(&buf, "\texpected := %t\n", sort.IntsAreSorted(e.Data))
+		buf.WriteString("\trequire.Equal(t, expected, actual)\n}\n")
+	}
+	return format.Source(buf.Bytes())
+}
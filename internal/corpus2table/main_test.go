@@ -0,0 +1,75 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/fuzzdata"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCorpus writes one corpus file per entry of files into a new
+// directory and returns it.
+func writeCorpus(t *testing.T, files map[string][]int, extra map[string][]byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		b := append(fuzzdata.Bytes(data), extra[name]...)
+		content := "go test fuzz v1\n[]byte(" + strconv.Quote(string(b)) + ")\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestLoadCorpusDeduplicates(t *testing.T) {
+	dir := writeCorpus(t, map[string][]int{
+		"b": {1, 0},
+		"a": {1, 0}, // the same slice as b, only with trailing bytes
+		"c": {},
+		"d": {}, // decodes to the same empty slice as c
+	}, map[string][]byte{"a": {9, 9}, "d": {1}})
+	entries, err := loadCorpus(dir)
+	require.NoError(t, err)
+	require.Equal(t, []entry{
+		{Name: "a", Data: []int{1, 0}},
+		{Name: "c", Data: []int{}},
+	}, entries)
+}
+
+func TestGenerateIsIdempotent(t *testing.T) {
+	dir := writeCorpus(t, map[string][]int{
+		"0f": {0, math.MinInt},
+		"1e": {0, 0},
+	}, nil)
+	entries, err := loadCorpus(dir)
+	require.NoError(t, err)
+	first, err := generate("testdemo", "testdata/fuzz/FuzzIsSorted", entries)
+	require.NoError(t, err)
+
+	entries, err = loadCorpus(dir)
+	require.NoError(t, err)
+	second, err := generate("testdemo", "testdata/fuzz/FuzzIsSorted", entries)
+	require.NoError(t, err)
+	require.Equal(t, string(first), string(second))
+	require.Contains(t, string(first), "// Code generated by corpus2table from testdata/fuzz/FuzzIsSorted; DO NOT EDIT.\n")
+	require.Contains(t, string(first), "func TestCorpus0fIsSorted(t *testing.T) {\n\tdata := []int{0, "+strconv.Itoa(math.MinInt)+"}\n\tactual := IsSorted(data)\n\texpected := false\n")
+	require.Contains(t, string(first), "func TestCorpus1eIsSorted(t *testing.T) {\n\tdata := []int{0, 0}\n\tactual := IsSorted(data)\n\texpected := true\n")
+}
+
+// TestGeneratedFileIsUpToDate fails when the corpus changed without the
+// generated tests being regenerated with go generate.
+func TestGeneratedFileIsUpToDate(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("the corpus decodes to different ints where int is 32 bits")
+	}
+	entries, err := loadCorpus(filepath.Join("..", "..", "testdata", "fuzz", "FuzzIsSorted"))
+	require.NoError(t, err)
+	expected, err := generate("testdemo", "testdata/fuzz/FuzzIsSorted", entries)
+	require.NoError(t, err)
+	actual, err := os.ReadFile(filepath.Join("..", "..", "generated_cases_test.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual), "run go generate in the repository root")
+}
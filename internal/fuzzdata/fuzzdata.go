@@ -0,0 +1,55 @@
+// Package fuzzdata turns the raw bytes a fuzzer produces into the values
+// the fuzz targets work on, so tools reading the corpus decode it the
+// same way the targets do.
+package fuzzdata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// corpusHeader is the first line of every corpus file.
+const corpusHeader = "go test fuzz v1"
+
+// Ints decodes b into ints: every 8 bytes are a little-endian int64, and
+// trailing bytes that do not make up a whole int64 are dropped. Where int
+// is 32 bits, each int64 is converted to int, keeping its low 32 bits.
+func Ints(b []byte) []int {
+	data := make([]int, len(b)/8)
+	for i := range data {
+		data[i] = int(int64(binary.LittleEndian.Uint64(b[8*i:])))
+	}
+	return data
+}
+
+// Bytes is the inverse of Ints, for seeding a corpus with chosen slices.
+// Values are sign-extended to int64, so the corpus reads the same
+// whatever the size of int.
+func Bytes(data []int) []byte {
+	b := make([]byte, 8*len(data))
+	for i, v := range data {
+		binary.LittleEndian.PutUint64(b[8*i:], uint64(int64(v)))
+	}
+	return b
+}
+
+// DecodeCorpusFile returns the []byte value stored in a corpus file of a
+// fuzz target taking a single []byte argument.
+func DecodeCorpusFile(content []byte) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 || strings.TrimSpace(lines[0]) != corpusHeader {
+		return nil, errors.New("not a corpus file with a single value")
+	}
+	value := strings.TrimSpace(lines[1])
+	if !strings.HasPrefix(value, "[]byte(") || !strings.HasSuffix(value, ")") {
+		return nil, fmt.Errorf("value %q is not a []byte", value)
+	}
+	s, err := strconv.Unquote(strings.TrimSuffix(strings.TrimPrefix(value, "[]byte("), ")"))
+	if err != nil {
+		return nil, fmt.Errorf("value %q: %w", value, err)
+	}
+	return []byte(s), nil
+}
@@ -0,0 +1,64 @@
+package fuzzdata
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInts(t *testing.T) {
+	require.Equal(t, []int{}, Ints(nil))
+	require.Equal(t, []int{}, Ints([]byte{1, 2, 3}))
+	require.Equal(t, []int{1}, Ints([]byte{1, 0, 0, 0, 0, 0, 0, 0, 9}))
+	// Where int is 32 bits, int64 values are truncated like conversions.
+	minInt64 := int64(math.MinInt64)
+	require.Equal(t, []int{int(minInt64), -1}, Ints([]byte{0, 0, 0, 0, 0, 0, 0, 0x80, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}))
+}
+
+func TestBytesRoundTrip(t *testing.T) {
+	data := []int{0, math.MinInt, math.MaxInt, -1, 42}
+	require.Equal(t, data, Ints(Bytes(data)))
+}
+
+func TestDecodeCorpusFile(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Content     string
+		Expected    []byte
+		ExpectedErr string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := DecodeCorpusFile([]byte(tc.Content))
+			if tc.ExpectedErr != "" {
+				require.EqualError(t, err, tc.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Escaped bytes",
+		Content:  "go test fuzz v1\n[]byte(\"\\x05\\x00a\\\"\")\n",
+		Expected: []byte{5, 0, 'a', '"'},
+	})
+	validate(t, testCase{Name: "Empty value",
+		Content:  "go test fuzz v1\n[]byte(\"\")\n",
+		Expected: []byte{},
+	})
+	validate(t, testCase{Name: "Missing header",
+		Content:     "[]byte(\"\")\n",
+		ExpectedErr: "not a corpus file with a single value",
+	})
+	validate(t, testCase{Name: "Several values",
+		Content:     "go test fuzz v1\n[]byte(\"\")\nint(1)\n",
+		ExpectedErr: "not a corpus file with a single value",
+	})
+	validate(t, testCase{Name: "Not a []byte",
+		Content:     "go test fuzz v1\nint(1)\n",
+		ExpectedErr: `value "int(1)" is not a []byte`,
+	})
+}
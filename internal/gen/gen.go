@@ -0,0 +1,16 @@
+// Package gen generates test inputs reproducibly: the same seed always
+// gives the same data.
+package gen
+
+import "math/rand"
+
+// ShuffleSeeded returns a copy of data in an order that depends only on
+// seed. data itself is left as it is.
+func ShuffleSeeded(data []int, seed int64) []int {
+	shuffled := make([]int, len(data))
+	copy(shuffled, data)
+	rand.New(rand.NewSource(seed)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
@@ -0,0 +1,82 @@
+// Package testcases is the corpus of IsSorted cases the test files share,
+// whichever style they are written in, so the cases cannot drift apart
+// from one file to the next.
+package testcases
+
+import "math"
+
+// LongLen is the length of the generated case, long enough to take the
+// vectorized path where there is one.
+const LongLen = 10_000
+
+// Case is an input to IsSorted and whether it is sorted in non-decreasing
+// order.
+type Case struct {
+	Name   string
+	Data   []int
+	Sorted bool
+}
+
+// Cases returns the corpus. The slices are built afresh on every call, so
+// a test may modify them.
+func Cases() []Case {
+	return []Case{
+		{Name: "Nil", Data: nil, Sorted: true},
+		{Name: "Empty", Data: []int{}, Sorted: true},
+		{Name: "Single element", Data: []int{0}, Sorted: true},
+		{Name: "Two elements", Data: []int{0, 1}, Sorted: true},
+		{Name: "Two elements unsorted", Data: []int{1, 0}, Sorted: false},
+		{Name: "Zero then MinInt", Data: []int{0, math.MinInt}, Sorted: false},
+		{Name: "MinInt to MaxInt", Data: []int{math.MinInt, 0, math.MaxInt}, Sorted: true},
+		{Name: "MaxInt then MinInt", Data: []int{math.MaxInt, math.MinInt}, Sorted: false},
+		{Name: "Two equal", Data: []int{0, 0}, Sorted: true},
+		{Name: "All equal", Data: []int{7, 7, 7, 7, 7}, Sorted: true},
+		{Name: "Equal then smaller", Data: []int{1, 1, 0}, Sorted: false},
+		{Name: "Negative duplicates", Data: []int{-3, -3, -1, -1, 2}, Sorted: true},
+		{Name: "Long ascending", Data: ascending(100), Sorted: true},
+		{Name: "Long unsorted at the end", Data: swapped(ascending(100), 98), Sorted: false},
+		{Name: "Long unsorted at the start", Data: swapped(ascending(100), 0), Sorted: false},
+		{Name: "Long descending", Data: descending(100), Sorted: false},
+		{Name: "Generated sorted with duplicates", Data: withDuplicates(LongLen), Sorted: true},
+		{Name: "Generated unsorted in the middle", Data: swapped(withDuplicates(LongLen), LongLen/2), Sorted: false},
+	}
+}
+
+// ascending returns 0, 1, ..., n-1.
+func ascending(n int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = i
+	}
+	return data
+}
+
+// descending returns n-1, n-2, ..., 0.
+func descending(n int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = n - 1 - i
+	}
+	return data
+}
+
+// withDuplicates returns n values in non-decreasing order, each repeated
+// three times and starting from a negative one.
+func withDuplicates(n int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = i/3 - n/6
+	}
+	return data
+}
+
+// swapped swaps data[i] with the next value that differs from it, so
+// sorted data is out of order right after i.
+func swapped(data []int, i int) []int {
+	j := i + 1
+	for data[j] == data[i] {
+		j++
+	}
+	data[i], data[j] = data[j], data[i]
+	return data
+}
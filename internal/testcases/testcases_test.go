@@ -0,0 +1,76 @@
+package testcases
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// styleFiles are the test files showing one test style each, which all
+// have to run the corpus.
+var styleFiles = []string{
+	"f_test.go",
+	"std_go_test.go",
+	"symflower_test.go",
+	"testify_suite_test.go",
+}
+
+func TestCasesAreConsistent(t *testing.T) {
+	cases := Cases()
+	names := map[string]bool{}
+	var long, minInt, duplicates bool
+	for _, c := range cases {
+		require.False(t, names[c.Name], "duplicate name %q", c.Name)
+		names[c.Name] = true
+		require.Equal(t, slices.IsSorted(c.Data), c.Sorted, c.Name)
+		long = long || len(c.Data) >= LongLen
+		minInt = minInt || slices.Contains(c.Data, math.MinInt)
+		for i := 1; i < len(c.Data); i++ {
+			duplicates = duplicates || c.Data[i] == c.Data[i-1]
+		}
+	}
+	require.True(t, long, "no case has %d elements", LongLen)
+	require.True(t, minInt, "no case has math.MinInt")
+	require.True(t, duplicates, "no case has duplicates")
+
+	// Every call builds new slices.
+	cases[len(cases)-1].Data[0] = math.MaxInt
+	require.Equal(t, cases[len(cases)-1].Name, Cases()[len(cases)-1].Name)
+	require.NotEqual(t, math.MaxInt, Cases()[len(cases)-1].Data[0])
+}
+
+func TestStyleFilesUseCases(t *testing.T) {
+	const path = "github.com/StevenACoffman/testdemo/internal/testcases"
+	for _, name := range styleFiles {
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join("..", "..", name), nil, 0)
+		require.NoError(t, err)
+		local := ""
+		for _, spec := range file.Imports {
+			if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+				local = "testcases"
+				if spec.Name != nil {
+					local = spec.Name.Name
+				}
+			}
+		}
+		require.NotEmpty(t, local, "%s does not import %s", name, path)
+		called := false
+		ast.Inspect(file, func(node ast.Node) bool {
+			if call, ok := node.(*ast.CallExpr); ok {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Cases" {
+					x, ok := sel.X.(*ast.Ident)
+					called = called || ok && x.Name == local
+				}
+			}
+			return !called
+		})
+		require.True(t, called, "%s does not call %s.Cases", name, local)
+	}
+}
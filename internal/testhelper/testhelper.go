@@ -0,0 +1,38 @@
+// Package testhelper holds helpers the randomized tests share.
+package testhelper
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// SeedEnv is the environment variable that fixes the seed of SeededRand,
+// to repeat a failure:
+//
+//	TESTDEMO_SEED=1234 go test -run TestName .
+const SeedEnv = "TESTDEMO_SEED"
+
+// SeededRand returns a random number generator for t, seeded from
+// TESTDEMO_SEED when it is set and from the clock otherwise. The seed is
+// logged as seed=<n>, and again with how to repeat the run when t fails.
+func SeededRand(t testing.TB) *rand.Rand {
+	t.Helper()
+	seed := time.Now().UnixNano()
+	if s := os.Getenv(SeedEnv); s != "" {
+		var err error
+		seed, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			t.Fatalf("%s=%q is not an int64: %v", SeedEnv, s, err)
+		}
+	}
+	t.Logf("seed=%d", seed)
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("seed=%d: rerun with %s=%d to repeat the failure", seed, SeedEnv, seed)
+		}
+	})
+	return rand.New(rand.NewSource(seed))
+}
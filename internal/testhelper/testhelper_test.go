@@ -0,0 +1,74 @@
+package testhelper
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records what SeededRand does with it.
+type recorder struct {
+	testing.TB
+	logs     []string
+	fatal    string
+	cleanups []func()
+	failed   bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Logf(format string, args ...any) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.fatal = fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+func (r *recorder) Failed() bool { return r.failed }
+
+func (r *recorder) cleanup() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func TestSeededRandHonorsEnv(t *testing.T) {
+	t.Setenv(SeedEnv, "1234")
+	r := &recorder{TB: t}
+	rng := SeededRand(r)
+	want := rand.New(rand.NewSource(1234))
+	for i := 0; i < 10; i++ {
+		require.Equal(t, want.Int63(), rng.Int63())
+	}
+	require.Equal(t, []string{"seed=1234"}, r.logs)
+
+	r.cleanup()
+	require.Equal(t, []string{"seed=1234"}, r.logs, "a passing test does not repeat the seed")
+	r.failed = true
+	r.cleanup()
+	require.Equal(t, []string{"seed=1234", "seed=1234: rerun with TESTDEMO_SEED=1234 to repeat the failure"}, r.logs)
+}
+
+func TestSeededRandLogsClockSeed(t *testing.T) {
+	t.Setenv(SeedEnv, "")
+	r := &recorder{TB: t}
+	rng := SeededRand(r)
+	require.Len(t, r.logs, 1)
+	seed, err := strconv.ParseInt(strings.TrimPrefix(r.logs[0], "seed="), 10, 64)
+	require.NoError(t, err, r.logs[0])
+	require.Equal(t, rand.New(rand.NewSource(seed)).Int63(), rng.Int63(), "the logged seed repeats the run")
+}
+
+func TestSeededRandRejectsBadEnv(t *testing.T) {
+	t.Setenv(SeedEnv, "soon")
+	r := &recorder{TB: t}
+	SeededRand(r)
+	require.Contains(t, r.fatal, `TESTDEMO_SEED="soon" is not an int64`)
+}
@@ -0,0 +1,36 @@
+// Command tinygosmoke exercises the core of the package so that building
+// it with TinyGo shows the core compiles without the reflection-based
+// helpers, which the tinygo build tag leaves out:
+//
+//	tinygo build -o /dev/null ./internal/tinygosmoke
+//
+// The standard toolchain builds the same subset with -tags noreflect.
+// TestTinyGoBuild runs the build when tinygo is on the PATH.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/StevenACoffman/testdemo"
+)
+
+func main() {
+	data := []int{1, 2, 2, 5, 8}
+	if !testdemo.IsSorted(data) || !testdemo.IsSortedOrdered([]string{"a", "b"}) {
+		fail("sorted input reported unsorted")
+	}
+	if i, ok := testdemo.Search(data, 5); !ok || i != 3 {
+		fail(fmt.Sprintf("Search(5) = %d, %t", i, ok))
+	}
+	_, err := testdemo.AsSorted([]int{2, 1})
+	if !errors.Is(err, testdemo.ErrUnsorted) {
+		fail(fmt.Sprintf("AsSorted error %v is not ErrUnsorted", err))
+	}
+}
+
+func fail(msg string) {
+	fmt.Fprintln(os.Stderr, "tinygosmoke:", msg)
+	os.Exit(1)
+}
@@ -0,0 +1,112 @@
+package testdemo
+
+import "slices"
+
+// CountInversions returns the number of inversions in data, pairs i < j
+// with data[i] > data[j]: zero for sorted data and n(n-1)/2 for strictly
+// decreasing data. Equal elements are not inversions. It counts while
+// merge sorting a copy of data, taking O(n log n) time and two O(n)
+// buffers.
+func CountInversions(data []int) int64 {
+	buf := slices.Clone(data)
+	return countInversions(buf, make([]int, len(buf)))
+}
+
+// countInversions merge sorts data using scratch, returning the number of
+// inversions it had.
+func countInversions(data, scratch []int) int64 {
+	if len(data) < 2 {
+		return 0
+	}
+	mid := len(data) / 2
+	n := countInversions(data[:mid], scratch[:mid]) + countInversions(data[mid:], scratch[mid:])
+	copy(scratch, data)
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(data) {
+		if scratch[j] < scratch[i] {
+			// scratch[j] is less than every element left in the left half.
+			n += int64(mid - i)
+			data[k] = scratch[j]
+			j++
+		} else {
+			data[k] = scratch[i]
+			i++
+		}
+		k++
+	}
+	k += copy(data[k:], scratch[i:mid])
+	copy(data[k:], scratch[j:])
+	return n
+}
+
+// InversionsPerElement returns, for each index of data, how many earlier
+// elements are greater than the one there, along with their total, which
+// is CountInversions(data). Equal elements are not inversions. It feeds
+// the rank of each value among the distinct values of data through a
+// Fenwick tree, taking O(n log n) time.
+func InversionsPerElement(data []int) (perElement []int64, total int64) {
+	distinct := slices.Clone(data)
+	slices.Sort(distinct)
+	distinct = slices.Compact(distinct)
+
+	// tree is a Fenwick tree over 1-based ranks, counting the elements
+	// seen so far with each rank.
+	tree := make([]int64, len(distinct)+1)
+	perElement = make([]int64, len(data))
+	for i, v := range data {
+		rank, _ := slices.BinarySearch(distinct, v)
+		rank++
+		var notGreater int64
+		for r := rank; r > 0; r -= r & -r {
+			notGreater += tree[r]
+		}
+		perElement[i] = int64(i) - notGreater
+		total += perElement[i]
+		for r := rank; r < len(tree); r += r & -r {
+			tree[r]++
+		}
+	}
+	return perElement, total
+}
+
+// InversionPairs returns up to limit inversions of data as index pairs
+// (i, j), i < j with data[i] > data[j], for showing what is out of order.
+// Pairs come ordered by i and then j, so the result is deterministic. A
+// limit of zero or less instead returns every adjacent descent, (i, i+1)
+// with data[i] > data[i+1]. Either way the result is empty only when data
+// is sorted.
+//
+// A precomputed suffix minimum skips each i with no inversions in O(1),
+// but an i that has some scans the rest of data for them.
+func InversionPairs(data []int, limit int) [][2]int {
+	var pairs [][2]int
+	if limit <= 0 {
+		for i := 1; i < len(data); i++ {
+			if data[i-1] > data[i] {
+				pairs = append(pairs, [2]int{i - 1, i})
+			}
+		}
+		return pairs
+	}
+	suffixMin := make([]int, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		suffixMin[i] = data[i]
+		if i+1 < len(data) {
+			suffixMin[i] = min(data[i], suffixMin[i+1])
+		}
+	}
+	for i := 0; i+1 < len(data); i++ {
+		if suffixMin[i+1] >= data[i] {
+			continue
+		}
+		for j := i + 1; j < len(data); j++ {
+			if data[i] > data[j] {
+				pairs = append(pairs, [2]int{i, j})
+				if len(pairs) == limit {
+					return pairs
+				}
+			}
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,150 @@
+package testdemo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInversions(t *testing.T) {
+	type testCase struct {
+		Name       string
+		Array      []int
+		PerElement []int64
+		Total      int64
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			perElement, total := InversionsPerElement(tc.Array)
+			require.Equal(t, tc.PerElement, perElement)
+			require.Equal(t, tc.Total, total)
+			require.Equal(t, tc.Total, CountInversions(tc.Array))
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:      []int{},
+		PerElement: []int64{},
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array:      []int{1, 2, 2, 3},
+		PerElement: []int64{0, 0, 0, 0},
+	})
+	validate(t, testCase{Name: "Reversed",
+		Array:      []int{4, 3, 2, 1},
+		PerElement: []int64{0, 1, 2, 3},
+		Total:      6,
+	})
+	validate(t, testCase{Name: "Duplicates are not inversions",
+		Array:      []int{2, 2, 1, 2, 1},
+		PerElement: []int64{0, 0, 2, 0, 3},
+		Total:      5,
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array:      []int{math.MaxInt, math.MinInt, 0},
+		PerElement: []int64{0, 1, 1},
+		Total:      2,
+	})
+}
+
+func TestInversionsAgainstBruteForce(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 300; i++ {
+		data := make([]int, rng.Intn(60))
+		for j := range data {
+			data[j] = rng.Intn(15)
+		}
+		want := make([]int64, len(data))
+		var wantTotal int64
+		for j := range data {
+			for k := 0; k < j; k++ {
+				if data[k] > data[j] {
+					want[j]++
+					wantTotal++
+				}
+			}
+		}
+		perElement, total := InversionsPerElement(data)
+		require.Equal(t, want, perElement, "%v", data)
+		require.Equal(t, wantTotal, total, "%v", data)
+		require.Equal(t, wantTotal, CountInversions(data), "%v", data)
+	}
+}
+
+func TestCountInversionsDoesNotModifyInput(t *testing.T) {
+	data := []int{3, 1, 2}
+	CountInversions(data)
+	require.Equal(t, []int{3, 1, 2}, data)
+}
+
+func TestInversionPairs(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Limit    int
+		Expected [][2]int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := InversionPairs(tc.Array, tc.Limit)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Sorted",
+		Array: []int{1, 2, 2},
+		Limit: 5,
+	})
+	validate(t, testCase{Name: "All pairs within limit",
+		Array:    []int{3, 1, 2},
+		Limit:    5,
+		Expected: [][2]int{{0, 1}, {0, 2}},
+	})
+	validate(t, testCase{Name: "Limit respected",
+		Array:    []int{4, 3, 2, 1},
+		Limit:    4,
+		Expected: [][2]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}},
+	})
+	validate(t, testCase{Name: "Smallest i first",
+		Array:    []int{1, 5, 2, 0},
+		Limit:    3,
+		Expected: [][2]int{{0, 3}, {1, 2}, {1, 3}},
+	})
+	validate(t, testCase{Name: "Adjacent descents",
+		Array:    []int{4, 3, 5, 1, 1},
+		Limit:    0,
+		Expected: [][2]int{{0, 1}, {2, 3}},
+	})
+	validate(t, testCase{Name: "Negative limit",
+		Array:    []int{2, 1},
+		Limit:    -1,
+		Expected: [][2]int{{0, 1}},
+	})
+}
+
+func TestInversionPairsProperties(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 300; i++ {
+		data := make([]int, rng.Intn(20))
+		for j := range data {
+			data[j] = rng.Intn(6)
+		}
+		limit := rng.Intn(10) - 2
+		pairs := InversionPairs(data, limit)
+		require.Equal(t, pairs, InversionPairs(data, limit), "must be deterministic")
+		require.Equal(t, IsSorted(data), len(pairs) == 0, "%v", data)
+
+		_, total := InversionsPerElement(data)
+		if limit > 0 {
+			require.Len(t, pairs, int(min(int64(limit), total)), "%v limit %d", data, limit)
+		}
+		for _, p := range pairs {
+			require.Less(t, p[0], p[1])
+			require.Greater(t, data[p[0]], data[p[1]])
+		}
+	}
+}
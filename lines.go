@@ -0,0 +1,195 @@
+package testdemo
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLineLength is the longest line CheckLines accepts.
+const maxLineLength = 16 << 20
+
+// LineOptions configures CheckLines.
+type LineOptions struct {
+	// Numeric compares keys as floating point numbers instead of strings.
+	Numeric bool
+	// Reverse checks for non-increasing instead of non-decreasing order.
+	Reverse bool
+	// Header skips the first line, which would otherwise be compared too.
+	Header bool
+	// Progress is reported to in bytes read.
+	Progress Progress
+	// Source names the input, such as a file name, in the *UnsortedError
+	// of a check that finds it out of order.
+	Source string
+}
+
+// CSVOptions configures CheckCSVColumn.
+type CSVOptions struct {
+	LineOptions
+	// Column is the 1-based column holding the keys.
+	Column int
+	// Comma is the field delimiter, ',' when zero.
+	Comma rune
+}
+
+// CheckLines checks that the lines read from r are sorted, comparing them
+// byte-wise unless opts say otherwise. It returns nil when they are, an
+// *UnsortedError naming the line number of the first line out of order
+// otherwise, or an error when r cannot be read or a numeric key does not
+// parse.
+func CheckLines(r io.Reader, opts LineOptions) (err error) {
+	keys := newKeyChecker(opts)
+	defer func() { reportCheck(keys.read, err) }()
+	report := opts.Progress.reporter(remainingSize(r))
+	counted := &countingReader{r: r}
+	sc := bufio.NewScanner(counted)
+	sc.Buffer(nil, maxLineLength)
+	line := 0
+	for sc.Scan() {
+		report.update(counted.n)
+		line++
+		if opts.Header && line == 1 {
+			continue
+		}
+		if err := keys.next(sc.Text(), line, ""); err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	report.finish(counted.n)
+	return nil
+}
+
+// IsSortedLines reports whether the lines read from r are in byte-wise
+// non-decreasing order. The error is only non-nil when r cannot be read.
+func IsSortedLines(r io.Reader) (bool, error) {
+	return sortedResult(CheckLines(r, LineOptions{}))
+}
+
+// CheckCSVColumn checks that the records read from r as CSV are sorted by
+// the given column, which every record must have. Fields may be quoted,
+// so a key can contain the delimiter or even newlines. The
+// *UnsortedError returned for a record out of order carries its line
+// number and its raw text.
+func CheckCSVColumn(r io.Reader, opts CSVOptions) (err error) {
+	keys := newKeyChecker(opts.LineOptions)
+	defer func() { reportCheck(keys.read, err) }()
+	if opts.Column < 1 {
+		return fmt.Errorf("invalid column %d: columns start at 1", opts.Column)
+	}
+	report := opts.Progress.reporter(remainingSize(r))
+	counted := &countingReader{r: r}
+	raw := &recordingReader{r: counted}
+	cr := csv.NewReader(raw)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.FieldsPerRecord = -1
+	for first := true; ; first = false {
+		start := cr.InputOffset()
+		record, err := cr.Read()
+		if err == io.EOF {
+			report.finish(counted.n)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := cr.FieldPos(0)
+		if opts.Header && first {
+			continue
+		}
+		if len(record) < opts.Column {
+			return fmt.Errorf("line %d: record has %d columns, want at least %d", line, len(record), opts.Column)
+		}
+		text := strings.TrimRight(string(raw.slice(start, cr.InputOffset())), "\r\n")
+		if err := keys.next(record[opts.Column-1], line, text); err != nil {
+			return err
+		}
+		raw.discard(cr.InputOffset())
+		report.update(counted.n)
+	}
+}
+
+// IsSortedCSVColumn reports whether the CSV records read from r are in
+// byte-wise non-decreasing order of the given 1-based column.
+func IsSortedCSVColumn(r io.Reader, column int) (bool, error) {
+	return sortedResult(CheckCSVColumn(r, CSVOptions{Column: column}))
+}
+
+// sortedResult turns the error of a Check function into the result of
+// the matching IsSorted function.
+func sortedResult(err error) (bool, error) {
+	var unsorted *UnsortedError
+	if errors.As(err, &unsorted) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// keyChecker compares each key with the one before it.
+type keyChecker struct {
+	opts LineOptions
+	// read counts the keys passed to next, and index the ones in order.
+	read      int
+	index     int
+	prev      string
+	prevFloat float64
+}
+
+func newKeyChecker(opts LineOptions) *keyChecker {
+	return &keyChecker{opts: opts}
+}
+
+func (k *keyChecker) next(key string, line int, text string) error {
+	k.read++
+	var ordered bool
+	if k.opts.Numeric {
+		v, err := strconv.ParseFloat(strings.TrimSpace(key), 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid number %q: %w", line, key, err)
+		}
+		ordered = k.index == 0 || inOrder(k.prevFloat, v, k.opts.Reverse, false)
+		k.prevFloat = v
+	} else {
+		ordered = k.index == 0 || inOrder(k.prev, key, k.opts.Reverse, false)
+	}
+	if !ordered {
+		return &UnsortedError{Index: k.index, Prev: k.prev, Next: key, Line: line, Text: text, Source: k.opts.Source}
+	}
+	k.prev = key
+	k.index++
+	return nil
+}
+
+// recordingReader keeps what was read through it, so the raw text of a
+// CSV record can be recovered from its offsets.
+type recordingReader struct {
+	r    io.Reader
+	buf  []byte
+	base int64 // input offset of buf[0]
+}
+
+func (rr *recordingReader) Read(p []byte) (int, error) {
+	n, err := rr.r.Read(p)
+	rr.buf = append(rr.buf, p[:n]...)
+	return n, err
+}
+
+func (rr *recordingReader) slice(start, end int64) []byte {
+	return rr.buf[start-rr.base : end-rr.base]
+}
+
+// discard forgets everything before the input offset off.
+func (rr *recordingReader) discard(off int64) {
+	n := int(off - rr.base)
+	rr.buf = append(rr.buf[:0], rr.buf[n:]...)
+	rr.base = off
+}
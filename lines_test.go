@@ -0,0 +1,139 @@
+package testdemo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLines(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Input       string
+		Options     LineOptions
+		Expected    error
+		ExpectedErr string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := CheckLines(strings.NewReader(tc.Input), tc.Options)
+			if tc.ExpectedErr != "" {
+				require.EqualError(t, actual, tc.ExpectedErr)
+				return
+			}
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Input: "",
+	})
+	validate(t, testCase{Name: "Sorted",
+		Input: "apple\nbanana\nbanana\ncherry",
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Input:    "apple\ncherry\nbanana\n",
+		Expected: &UnsortedError{Index: 2, Prev: "cherry", Next: "banana", Line: 3},
+	})
+	validate(t, testCase{Name: "Byte-wise, not numeric",
+		Input:    "9\n10\n",
+		Expected: &UnsortedError{Index: 1, Prev: "9", Next: "10", Line: 2},
+	})
+	validate(t, testCase{Name: "Numeric",
+		Input:   "9\n10\n 10.5\n",
+		Options: LineOptions{Numeric: true},
+	})
+	validate(t, testCase{Name: "Reverse",
+		Input:   "c\nb\nb\na\n",
+		Options: LineOptions{Reverse: true},
+	})
+	validate(t, testCase{Name: "Header",
+		Input:   "name\nalpha\nbeta\n",
+		Options: LineOptions{Header: true},
+	})
+	validate(t, testCase{Name: "Header is compared without the option",
+		Input:    "name\nalpha\n",
+		Expected: &UnsortedError{Index: 1, Prev: "name", Next: "alpha", Line: 2},
+	})
+	validate(t, testCase{Name: "Invalid number",
+		Input:       "1\ntwo\n",
+		Options:     LineOptions{Numeric: true},
+		ExpectedErr: `line 2: invalid number "two": strconv.ParseFloat: parsing "two": invalid syntax`,
+	})
+}
+
+func TestCheckCSVColumn(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Input       string
+		Options     CSVOptions
+		Expected    error
+		ExpectedErr string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := CheckCSVColumn(strings.NewReader(tc.Input), tc.Options)
+			if tc.ExpectedErr != "" {
+				require.EqualError(t, actual, tc.ExpectedErr)
+				return
+			}
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Sorted by second column",
+		Input:   "b,1\na,2\nc,3\n",
+		Options: CSVOptions{Column: 2},
+	})
+	validate(t, testCase{Name: "Unsorted by first column",
+		Input:    "b,1\na,2\n",
+		Options:  CSVOptions{Column: 1},
+		Expected: &UnsortedError{Index: 1, Prev: "b", Next: "a", Line: 2, Text: "a,2"},
+	})
+	validate(t, testCase{Name: "Quoted fields containing the delimiter",
+		Input:    "id,name\n1,\"Smith, Al\"\n2,\"Smith, Zoe\"\n3,\"Jones, Bo\"\n",
+		Options:  CSVOptions{Column: 2, LineOptions: LineOptions{Header: true}},
+		Expected: &UnsortedError{Index: 2, Prev: "Smith, Zoe", Next: "Jones, Bo", Line: 4, Text: `3,"Jones, Bo"`},
+	})
+	validate(t, testCase{Name: "Header that would violate ordering",
+		Input:   "zzz;count\na;1\nb;2\n",
+		Options: CSVOptions{Column: 1, Comma: ';', LineOptions: LineOptions{Header: true}},
+	})
+	validate(t, testCase{Name: "Header compared without the option",
+		Input:    "zzz;count\na;1\n",
+		Options:  CSVOptions{Column: 1, Comma: ';'},
+		Expected: &UnsortedError{Index: 1, Prev: "zzz", Next: "a", Line: 2, Text: "a;1"},
+	})
+	validate(t, testCase{Name: "Numeric reverse",
+		Input:    "a\t10\nb\t9\nc\t11\n",
+		Options:  CSVOptions{Column: 2, Comma: '\t', LineOptions: LineOptions{Numeric: true, Reverse: true}},
+		Expected: &UnsortedError{Index: 2, Prev: "9", Next: "11", Line: 3, Text: "c\t11"},
+	})
+	validate(t, testCase{Name: "Multi-line quoted field",
+		Input:    "1,\"b\nb\"\r\n2,a\r\n",
+		Options:  CSVOptions{Column: 2},
+		Expected: &UnsortedError{Index: 1, Prev: "b\nb", Next: "a", Line: 3, Text: "2,a"},
+	})
+	validate(t, testCase{Name: "Missing column",
+		Input:       "a,1\nb\n",
+		Options:     CSVOptions{Column: 2},
+		ExpectedErr: "line 2: record has 1 columns, want at least 2",
+	})
+	validate(t, testCase{Name: "Invalid column",
+		Input:       "a\n",
+		ExpectedErr: "invalid column 0: columns start at 1",
+	})
+}
+
+func TestIsSortedLinesAndCSVColumn(t *testing.T) {
+	sorted, err := IsSortedLines(strings.NewReader("a\nb\n"))
+	require.NoError(t, err)
+	require.True(t, sorted)
+
+	sorted, err = IsSortedCSVColumn(strings.NewReader("x,b\ny,a\n"), 2)
+	require.NoError(t, err)
+	require.False(t, sorted)
+}
@@ -0,0 +1,47 @@
+package testdemo
+
+import "errors"
+
+// ErrCycle is returned by EnsureSortedLinked when following next loops
+// back to a node already visited.
+var ErrCycle = errors.New("cycle in linked sequence")
+
+// IsSortedLinked reports whether the values of a linked sequence, such as
+// a linked list, are in non-decreasing order. The sequence starts at head,
+// which may be nil for an empty one, and next returns the node after its
+// argument, or nil after the last. A sequence that loops back on itself
+// is not sorted; see EnsureSortedLinked.
+func IsSortedLinked[T any](head *T, next func(*T) *T, value func(*T) int) bool {
+	return EnsureSortedLinked(head, next, value) == nil
+}
+
+// EnsureSortedLinked is IsSortedLinked returning an error: an
+// *UnsortedError for the first pair out of order, with Index counting
+// nodes from zero, or ErrCycle when the sequence loops back to a node it
+// already visited. Cycles are found with Floyd's algorithm, a second
+// pointer moving at twice the speed, so no nodes are remembered.
+func EnsureSortedLinked[T any](head *T, next func(*T) *T, value func(*T) int) error {
+	if head == nil {
+		return nil
+	}
+	fast := head
+	prev := value(head)
+	index := 0
+	for node := next(head); node != nil; node = next(node) {
+		index++
+		v := value(node)
+		if v < prev {
+			return &UnsortedError{Index: index, Prev: prev, Next: v}
+		}
+		prev = v
+		// fast moves two nodes for each one node moves; in a cycle it
+		// catches up with node from behind.
+		for i := 0; i < 2 && fast != nil; i++ {
+			fast = next(fast)
+		}
+		if fast == node {
+			return ErrCycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type listNode struct {
+	Value int
+	Next  *listNode
+}
+
+// newList links nodes holding values and returns the head and all nodes.
+func newList(values ...int) (*listNode, []*listNode) {
+	nodes := make([]*listNode, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		nodes[i] = &listNode{Value: values[i]}
+		if i+1 < len(values) {
+			nodes[i].Next = nodes[i+1]
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	return nodes[0], nodes
+}
+
+func TestEnsureSortedLinked(t *testing.T) {
+	next := func(n *listNode) *listNode { return n.Next }
+	value := func(n *listNode) int { return n.Value }
+
+	type testCase struct {
+		Name     string
+		Head     *listNode
+		Expected error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := EnsureSortedLinked(tc.Head, next, value)
+			require.Equal(t, tc.Expected, actual)
+			require.Equal(t, tc.Expected == nil, IsSortedLinked(tc.Head, next, value))
+		})
+	}
+	sorted, _ := newList(1, 2, 2, 7)
+	unsorted, _ := newList(1, 5, 3)
+	single, _ := newList(4)
+	cyclic, nodes := newList(1, 1, 1, 1, 1)
+	nodes[4].Next = nodes[1]
+	selfLoop, nodes := newList(3)
+	nodes[0].Next = nodes[0]
+	unsortedCycle, nodes := newList(1, 2, 3)
+	nodes[2].Next = nodes[0]
+
+	validate(t, testCase{Name: "Empty"})
+	validate(t, testCase{Name: "Single node",
+		Head: single,
+	})
+	validate(t, testCase{Name: "Sorted",
+		Head: sorted,
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Head:     unsorted,
+		Expected: &UnsortedError{Index: 2, Prev: 5, Next: 3},
+	})
+	validate(t, testCase{Name: "Cycle",
+		Head:     cyclic,
+		Expected: ErrCycle,
+	})
+	validate(t, testCase{Name: "Self loop",
+		Head:     selfLoop,
+		Expected: ErrCycle,
+	})
+	validate(t, testCase{Name: "Unsorted cycle",
+		Head:     unsortedCycle,
+		Expected: &UnsortedError{Index: 3, Prev: 3, Next: 1},
+	})
+}
@@ -0,0 +1,110 @@
+package testdemo
+
+import (
+	"context"
+	"fmt"
+)
+
+// MergeSorted merges a and b, which must both be sorted in non-decreasing
+// order, into a new sorted slice. It returns an *UnsortedError, with
+// Source "a" or "b" for the input it is in, when one of them is not.
+func MergeSorted(a, b []int) ([]int, error) {
+	ctx := context.Background()
+	if err := checkSorted(ctx, a, Order{Source: "a"}); err != nil {
+		return nil, err
+	}
+	if err := checkSorted(ctx, b, Order{Source: "b"}); err != nil {
+		return nil, err
+	}
+	merged := make([]int, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		// Taking from a on ties keeps the merge stable.
+		if b[0] < a[0] {
+			merged, b = append(merged, b[0]), b[1:]
+		} else {
+			merged, a = append(merged, a[0]), a[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...), nil
+}
+
+// VerifyMerge checks that output is a correct merge of inputs: it has to
+// be sorted in non-decreasing order, and hold every value exactly as many
+// times as all the inputs together do. An order violation is reported as
+// an *UnsortedError; otherwise the error names the smallest value whose
+// count is off, and by how much.
+//
+// When the inputs are sorted too, as merge inputs usually are, the counts
+// are compared by walking all of them in step, using no memory beyond a
+// position per input. Otherwise they are counted in a map.
+func VerifyMerge(inputs [][]int, output []int) error {
+	for i := 1; i < len(output); i++ {
+		if output[i] < output[i-1] {
+			return &UnsortedError{Index: i, Prev: output[i-1], Next: output[i]}
+		}
+	}
+	for _, in := range inputs {
+		if !IsSorted(in) {
+			return verifyMergeCounts(inputs, output)
+		}
+	}
+
+	heads := make([]int, len(inputs))
+	for j := 0; ; {
+		// Compare counts of the smallest value not yet looked at.
+		v, found := 0, false
+		if j < len(output) {
+			v, found = output[j], true
+		}
+		for i, in := range inputs {
+			if heads[i] < len(in) && (!found || in[heads[i]] < v) {
+				v, found = in[heads[i]], true
+			}
+		}
+		if !found {
+			return nil
+		}
+		got := UpperBound(output[j:], v)
+		j += got
+		want := 0
+		for i, in := range inputs {
+			n := UpperBound(in[heads[i]:], v)
+			heads[i] += n
+			want += n
+		}
+		if got != want {
+			return mergeCountError(v, got, want)
+		}
+	}
+}
+
+func verifyMergeCounts(inputs [][]int, output []int) error {
+	counts := map[int]int{}
+	for _, in := range inputs {
+		for _, v := range in {
+			counts[v]++
+		}
+	}
+	for _, v := range output {
+		counts[v]--
+	}
+	var (
+		worst    int
+		mismatch bool
+	)
+	for v, c := range counts {
+		if c != 0 && (!mismatch || v < worst) {
+			worst, mismatch = v, true
+		}
+	}
+	if !mismatch {
+		return nil
+	}
+	got := UpperBound(output, worst) - LowerBound(output, worst)
+	return mergeCountError(worst, got, got+counts[worst])
+}
+
+func mergeCountError(v, got, want int) error {
+	return fmt.Errorf("value %d appears %d times in output but %d times in inputs (%+d)", v, got, want, got-want)
+}
@@ -0,0 +1,83 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyMerge(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Inputs   [][]int
+		Output   []int
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			err := VerifyMerge(tc.Inputs, tc.Output)
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	sorted := [][]int{{1, 4, 4, 9}, {}, {2, 4, 10}}
+	unsorted := [][]int{{9, 4, 1, 4}, {}, {10, 2, 4}}
+	for _, inputs := range []struct {
+		Name   string
+		Inputs [][]int
+	}{{"sorted inputs", sorted}, {"unsorted inputs", unsorted}} {
+		validate(t, testCase{Name: "Correct, " + inputs.Name,
+			Inputs: inputs.Inputs,
+			Output: []int{1, 2, 4, 4, 4, 9, 10},
+		})
+		validate(t, testCase{Name: "Duplicated element, " + inputs.Name,
+			Inputs:   inputs.Inputs,
+			Output:   []int{1, 2, 2, 4, 4, 4, 9, 10},
+			Expected: "value 2 appears 2 times in output but 1 times in inputs (+1)",
+		})
+		validate(t, testCase{Name: "Dropped element, " + inputs.Name,
+			Inputs:   inputs.Inputs,
+			Output:   []int{1, 2, 4, 4, 9, 10},
+			Expected: "value 4 appears 2 times in output but 3 times in inputs (-1)",
+		})
+		validate(t, testCase{Name: "Dropped last element, " + inputs.Name,
+			Inputs:   inputs.Inputs,
+			Output:   []int{1, 2, 4, 4, 4, 9},
+			Expected: "value 10 appears 0 times in output but 1 times in inputs (-1)",
+		})
+		validate(t, testCase{Name: "Foreign element, " + inputs.Name,
+			Inputs:   inputs.Inputs,
+			Output:   []int{0, 1, 2, 4, 4, 4, 9, 10},
+			Expected: "value 0 appears 1 times in output but 0 times in inputs (+1)",
+		})
+		validate(t, testCase{Name: "Unsorted output, " + inputs.Name,
+			Inputs:   inputs.Inputs,
+			Output:   []int{1, 2, 4, 4, 9, 4, 10},
+			Expected: "index 5: 9 followed by 4",
+		})
+	}
+	validate(t, testCase{Name: "No inputs"})
+	validate(t, testCase{Name: "No inputs, some output",
+		Output:   []int{3},
+		Expected: "value 3 appears 1 times in output but 0 times in inputs (+1)",
+	})
+}
+
+func TestMergeSorted(t *testing.T) {
+	got, err := MergeSorted([]int{1, 3, 5}, []int{2, 3, 6, 7})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 3, 5, 6, 7}, got)
+	require.NoError(t, VerifyMerge([][]int{{1, 3, 5}, {2, 3, 6, 7}}, got))
+
+	got, err = MergeSorted(nil, []int{4})
+	require.NoError(t, err)
+	require.Equal(t, []int{4}, got)
+
+	_, err = MergeSorted([]int{1}, []int{3, 2})
+	require.Equal(t, &UnsortedError{Index: 1, Prev: 3, Next: 2, Source: "b", Len: 2}, err)
+}
@@ -0,0 +1,161 @@
+package testdemo
+
+import (
+	"bufio"
+	"container/heap"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// MergeFiles merges the sorted files inPaths into a sorted file at
+// outPath. The files hold whitespace-separated integers, as CheckReader
+// reads, and the output holds one per line. It streams, holding one value
+// per input in memory, and checks each input's order as it goes,
+// returning an *UnsortedError naming the file and line of the first
+// value out of order. The output is written to a temporary file next to
+// outPath and renamed over it only once complete, so on error outPath is
+// left as it was. The output has mode 0o644.
+func MergeFiles(outPath string, inPaths ...string) error {
+	sources := make([]*intSource, 0, len(inPaths))
+	for _, path := range inPaths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		sources = append(sources, newIntSource(f, path))
+	}
+	return writeFileAtomic(outPath, func(w io.Writer) error {
+		return mergeSources(w, sources, nil)
+	})
+}
+
+// writeFileAtomic calls write with a temporary file in the same directory
+// as path and renames it to path, with mode 0o644 whatever the umask, if
+// write and closing the file succeed. Otherwise it removes the temporary
+// file.
+func writeFileAtomic(path string, write func(w io.Writer) error) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if err := f.Chmod(0o644); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	if err := write(bw); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// mergeSources writes the values of sources to w in sorted order, one per
+// line. If onRead is not nil, it is called with how many more bytes were
+// read from sources each time a value is.
+func mergeSources(w io.Writer, sources []*intSource, onRead func(n int64)) error {
+	next := func(s *intSource) (bool, error) {
+		before := s.bytes.n
+		ok, err := s.next()
+		if onRead != nil {
+			onRead(s.bytes.n - before)
+		}
+		return ok, err
+	}
+	h := make(sourceHeap, 0, len(sources))
+	for _, s := range sources {
+		ok, err := next(s)
+		if err != nil {
+			return err
+		}
+		if ok {
+			h = append(h, s)
+		}
+	}
+	heap.Init(&h)
+	var line []byte
+	for len(h) > 0 {
+		line = strconv.AppendInt(line[:0], h[0].value, 10)
+		line = append(line, '\n')
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		ok, err := next(h[0])
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return nil
+}
+
+// intSource reads the whitespace-separated integers of a sorted input one
+// at a time, checking that they are in order.
+type intSource struct {
+	tok   *tokenizer
+	bytes *countingReader
+	name  string
+	index int
+	value int64
+}
+
+func newIntSource(r io.Reader, name string) *intSource {
+	counted := &countingReader{r: r}
+	return &intSource{tok: newTokenizer(counted), bytes: counted, name: name, index: -1}
+}
+
+// next advances to the next value, reporting false at the end of the
+// input. It returns an error for a value that does not parse or is less
+// than the one before it.
+func (s *intSource) next() (bool, error) {
+	if !s.tok.Scan() {
+		if err := s.tok.Err(); err != nil {
+			return false, fmt.Errorf("%s: %w", s.name, err)
+		}
+		return false, nil
+	}
+	text := s.tok.Text()
+	v, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("%s: line %d: invalid number %q: %w", s.name, s.tok.line, text, err)
+	}
+	s.index++
+	if s.index > 0 && v < s.value {
+		return false, &UnsortedError{Index: s.index, Prev: s.value, Next: v, Line: s.tok.line, Source: s.name}
+	}
+	s.value = v
+	return true, nil
+}
+
+// sourceHeap is a min-heap of intSources ordered by their current values.
+type sourceHeap []*intSource
+
+func (h sourceHeap) Len() int           { return len(h) }
+func (h sourceHeap) Less(i, j int) bool { return h[i].value < h[j].value }
+func (h sourceHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *sourceHeap) Push(x any)        { *h = append(*h, x.(*intSource)) }
+
+func (h *sourceHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,120 @@
+package testdemo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestFile writes content to name in dir and returns its path.
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// readInts returns the integers in the file at path.
+func readInts(t *testing.T, path string) []int {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var values []int
+	for _, field := range strings.Fields(string(b)) {
+		v, err := strconv.Atoi(field)
+		require.NoError(t, err)
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestMergeFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTestFile(t, dir, "a.txt", "1\n4\n4\n9\n")
+	b := writeTestFile(t, dir, "b.txt", "-3 2\n5\n")
+	empty := writeTestFile(t, dir, "empty.txt", "")
+	out := filepath.Join(dir, "out.txt")
+	require.NoError(t, MergeFiles(out, a, empty, b))
+
+	got, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "-3\n1\n2\n4\n4\n5\n9\n", string(got))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(out)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	}
+
+	require.NoError(t, MergeFiles(out))
+	got, err = os.ReadFile(out)
+	require.NoError(t, err)
+	require.Empty(t, got, "merging no files makes an empty file")
+}
+
+func TestMergeFilesUnsortedInput(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTestFile(t, dir, "a.txt", "1\n2\n3\n")
+	b := writeTestFile(t, dir, "b.txt", "1\n5\n4\n")
+	out := writeTestFile(t, dir, "out.txt", "previous\n")
+
+	err := MergeFiles(out, a, b)
+	require.Equal(t, &UnsortedError{Index: 2, Prev: int64(5), Next: int64(4), Line: 3, Source: b}, err)
+	require.EqualError(t, err, b+": line 3, index 2: 5 followed by 4")
+
+	got, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "previous\n", string(got), "a failed merge must leave the output alone")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3, "a failed merge must remove its temporary file")
+}
+
+func TestMergeFilesErrors(t *testing.T) {
+	dir := t.TempDir()
+	bad := writeTestFile(t, dir, "bad.txt", "1\nx\n")
+	out := filepath.Join(dir, "out.txt")
+	require.EqualError(t, MergeFiles(out, bad),
+		bad+`: line 2: invalid number "x": strconv.ParseInt: parsing "x": invalid syntax`)
+	require.ErrorIs(t, MergeFiles(out, filepath.Join(dir, "missing.txt")), os.ErrNotExist)
+	_, err := os.Stat(out)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestMergeFilesLarge(t *testing.T) {
+	dir := t.TempDir()
+	rng := testhelper.SeededRand(t)
+	var all []int
+	var paths []string
+	for f := 0; f < 2; f++ {
+		data := make([]int, 1e5)
+		for i := range data {
+			data[i] = rng.Intn(1e6)
+		}
+		slices.Sort(data)
+		all = append(all, data...)
+		var sb strings.Builder
+		for _, v := range data {
+			fmt.Fprintln(&sb, v)
+		}
+		paths = append(paths, writeTestFile(t, dir, fmt.Sprintf("in%d.txt", f), sb.String()))
+	}
+	out := filepath.Join(dir, "out.txt")
+	require.NoError(t, MergeFiles(out, paths...))
+
+	f, err := os.Open(out)
+	require.NoError(t, err)
+	defer f.Close()
+	sorted, err := IsSortedReader(f)
+	require.NoError(t, err)
+	require.True(t, sorted)
+	require.True(t, IsPermutationOf(all, readInts(t, out)))
+}
@@ -0,0 +1,32 @@
+package testdemo
+
+// MinWindowToSort returns the shortest window data[lo:hi] that, sorted on
+// its own, leaves all of data sorted, or an empty window when data is
+// already sorted. It takes two O(n) scans: an element belongs in the
+// window when something before it is greater, or something after it is
+// smaller. Elements equal to their neighbors across the edge, such as the
+// last 3 in 1, 2, 3, 3, 3, 2, 3, 5, stay out.
+func MinWindowToSort(data []int) (lo, hi int) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+	prefixMax := data[0]
+	for i, v := range data {
+		if v < prefixMax {
+			hi = i + 1
+		}
+		prefixMax = max(prefixMax, v)
+	}
+	if hi == 0 {
+		return 0, 0
+	}
+	suffixMin := data[len(data)-1]
+	lo = len(data) - 1
+	for i := len(data) - 1; i >= 0; i-- {
+		if data[i] > suffixMin {
+			lo = i
+		}
+		suffixMin = min(suffixMin, data[i])
+	}
+	return lo, hi
+}
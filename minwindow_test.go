@@ -0,0 +1,78 @@
+package testdemo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinWindowToSort(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Array  []int
+		Lo, Hi int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			lo, hi := MinWindowToSort(tc.Array)
+			require.Equal(t, tc.Lo, lo, "lo")
+			require.Equal(t, tc.Hi, hi, "hi")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array: []int{1, 2, 2, 3},
+	})
+	validate(t, testCase{Name: "Duplicates at the edges",
+		Array: []int{1, 2, 3, 3, 3, 2, 3, 5},
+		Lo:    2,
+		Hi:    6,
+	})
+	validate(t, testCase{Name: "Reversed",
+		Array: []int{3, 2, 1},
+		Lo:    0,
+		Hi:    3,
+	})
+	validate(t, testCase{Name: "Adjacent swap",
+		Array: []int{1, 3, 2, 4},
+		Lo:    1,
+		Hi:    3,
+	})
+	validate(t, testCase{Name: "Small element at the end",
+		Array: []int{2, 3, 4, 1},
+		Lo:    0,
+		Hi:    4,
+	})
+}
+
+// sortsWithWindow reports whether sorting data[lo:hi] sorts data.
+func sortsWithWindow(data []int, lo, hi int) bool {
+	c := slices.Clone(data)
+	slices.Sort(c[lo:hi])
+	return IsSorted(c)
+}
+
+func TestMinWindowToSortIsMinimal(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]int, rng.Intn(10))
+		for j := range data {
+			data[j] = rng.Intn(4)
+		}
+		lo, hi := MinWindowToSort(data)
+		require.True(t, sortsWithWindow(data, lo, hi), "%v [%d:%d]", data, lo, hi)
+		for l := 0; l <= len(data); l++ {
+			for h := l; h <= len(data); h++ {
+				if h-l < hi-lo {
+					require.False(t, sortsWithWindow(data, l, h), "%v [%d:%d] is shorter than [%d:%d]", data, l, h, lo, hi)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package testdemo
+
+import "fmt"
+
+// IsMonotonicModulo reports whether data increases monotonically as
+// serial numbers that wrap around at 2³², such as sequence counters: each
+// element must be ahead of the one before it by more than zero and less
+// than window, counting modulo 2³², so that 4294967295 is followed by 0.
+// Equal neighbors are not ahead of each other.
+//
+// This is serial number comparison as in RFC 1982, with the window
+// narrowing how far ahead counts. The RFC leaves a distance of exactly
+// 2³¹ undefined, since it is as far ahead as behind, so window may be at
+// most 2³¹; it panics if window is larger, or zero, which would let
+// nothing be ahead.
+func IsMonotonicModulo(data []uint32, window uint32) bool {
+	if window == 0 || window > 1<<31 {
+		panic(fmt.Sprintf("testdemo: serial number window %d is outside [1, 2^31]", window))
+	}
+	for i := 1; i < len(data); i++ {
+		if d := data[i] - data[i-1]; d == 0 || d >= window {
+			return false
+		}
+	}
+	return true
+}
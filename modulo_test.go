@@ -0,0 +1,79 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsMonotonicModulo(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []uint32
+		Window   uint32
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := IsMonotonicModulo(tc.Data, tc.Window)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Data:     []uint32{},
+		Window:   10,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Increasing",
+		Data:     []uint32{1, 2, 5, 9},
+		Window:   10,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Wrap around",
+		Data:     []uint32{4294967294, 4294967295, 0, 1},
+		Window:   10,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Wrap around with a gap",
+		Data:     []uint32{4294967290, 3},
+		Window:   10,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Jump beyond the window",
+		Data:     []uint32{1, 11},
+		Window:   10,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Regression",
+		// Going back 1 is being 4294967295 ahead.
+		Data:     []uint32{5, 4},
+		Window:   10,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Repeat",
+		Data:     []uint32{5, 5},
+		Window:   10,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Widest window",
+		Data:     []uint32{0, 1<<31 - 1, 1<<32 - 2},
+		Window:   1 << 31,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Widest window, ambiguous step",
+		Data:     []uint32{0, 1 << 31},
+		Window:   1 << 31,
+		Expected: false,
+	})
+}
+
+func TestIsMonotonicModuloPanicsOnBadWindow(t *testing.T) {
+	require.PanicsWithValue(t, "testdemo: serial number window 0 is outside [1, 2^31]", func() {
+		IsMonotonicModulo([]uint32{1, 2}, 0)
+	})
+	require.PanicsWithValue(t, "testdemo: serial number window 2147483649 is outside [1, 2^31]", func() {
+		IsMonotonicModulo([]uint32{1, 2}, 1<<31+1)
+	})
+}
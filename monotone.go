@@ -0,0 +1,110 @@
+package testdemo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// MonotoneWriter streams a sequence of int64s in non-decreasing order to
+// an io.Writer compactly: the first value as a signed varint, and each
+// one after it as the unsigned varint of its difference from the one
+// before, so dense sequences take about a byte per value. Each value is
+// written with its own Write call, so w should be buffered.
+type MonotoneWriter struct {
+	w    io.Writer
+	buf  []byte
+	n    int
+	prev int64
+}
+
+// NewMonotoneWriter returns a MonotoneWriter writing to w.
+func NewMonotoneWriter(w io.Writer) *MonotoneWriter {
+	return &MonotoneWriter{w: w, buf: make([]byte, 0, binary.MaxVarintLen64)}
+}
+
+// WriteValue writes v, which must not be less than the value written
+// before it. It returns an *UnsortedError, and writes nothing, when it
+// is, with Index counting the values written before v.
+func (m *MonotoneWriter) WriteValue(v int64) error {
+	buf := m.buf[:0]
+	switch {
+	case m.n == 0:
+		buf = binary.AppendVarint(buf, v)
+	case v < m.prev:
+		return &UnsortedError{Index: m.n, Prev: m.prev, Next: v}
+	default:
+		buf = binary.AppendUvarint(buf, uint64(v)-uint64(m.prev))
+	}
+	if _, err := m.w.Write(buf); err != nil {
+		return err
+	}
+	m.prev = v
+	m.n++
+	return nil
+}
+
+// MonotoneReader reads back a sequence written by a MonotoneWriter.
+type MonotoneReader struct {
+	r    *countingByteReader
+	n    int
+	prev int64
+}
+
+// NewMonotoneReader returns a MonotoneReader reading from r. It reads
+// one byte at a time, so it buffers r unless it is an io.ByteReader
+// already.
+func NewMonotoneReader(r io.Reader) *MonotoneReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &MonotoneReader{r: &countingByteReader{r: br}}
+}
+
+// ReadValue returns the next value, or io.EOF once the sequence ends.
+// A varint that is cut short or too long, or a difference taking the
+// value past math.MaxInt64, is an error naming the byte offset where the
+// value starts.
+func (m *MonotoneReader) ReadValue() (int64, error) {
+	start := m.r.n
+	var v int64
+	var err error
+	if m.n == 0 {
+		v, err = binary.ReadVarint(m.r)
+	} else {
+		var d uint64
+		d, err = binary.ReadUvarint(m.r)
+		if err == nil && d > uint64(math.MaxInt64)-uint64(m.prev) {
+			err = fmt.Errorf("difference %d after %d overflows int64", d, m.prev)
+		}
+		v = int64(uint64(m.prev) + d)
+	}
+	// binary reports io.EOF only when no byte of the value was there, and
+	// io.ErrUnexpectedEOF when some were.
+	if err == io.EOF {
+		return 0, io.EOF
+	}
+	if err != nil {
+		return 0, fmt.Errorf("monotone stream: value %d at offset %d: %w", m.n, start, err)
+	}
+	m.prev = v
+	m.n++
+	return v, nil
+}
+
+// countingByteReader counts the bytes read through it in n.
+type countingByteReader struct {
+	r io.ByteReader
+	n int64
+}
+
+func (c *countingByteReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
@@ -0,0 +1,122 @@
+package testdemo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readAllValues reads r to the end, returning the values read before the
+// first error other than io.EOF along with that error.
+func readAllValues(r *MonotoneReader) ([]int64, error) {
+	var values []int64
+	for {
+		v, err := r.ReadValue()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+}
+
+func TestMonotoneRoundTrip(t *testing.T) {
+	for _, values := range [][]int64{
+		nil,
+		{0},
+		{-5},
+		{math.MinInt64, -1, 0, math.MaxInt64},
+		{3, 3, 3},
+		{1 << 40, 1<<40 + 1, 1<<40 + 1000},
+	} {
+		var buf bytes.Buffer
+		w := NewMonotoneWriter(&buf)
+		for _, v := range values {
+			require.NoError(t, w.WriteValue(v))
+		}
+		got, err := readAllValues(NewMonotoneReader(&buf))
+		require.NoError(t, err)
+		require.Equal(t, values, got)
+	}
+}
+
+func TestMonotoneWriterRegression(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewMonotoneWriter(&buf)
+	require.NoError(t, w.WriteValue(5))
+	require.NoError(t, w.WriteValue(7))
+	n := buf.Len()
+	require.Equal(t, &UnsortedError{Index: 2, Prev: int64(7), Next: int64(6)}, w.WriteValue(6))
+	require.Equal(t, n, buf.Len(), "a rejected value must not be written")
+	require.NoError(t, w.WriteValue(7))
+}
+
+func TestMonotoneReaderCorruption(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Stream []byte
+		Values []int64
+		Err    string
+		Is     error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			// A bytes.Reader is an io.ByteReader, so nothing reads ahead.
+			got, err := readAllValues(NewMonotoneReader(bytes.NewReader(tc.Stream)))
+			require.Equal(t, tc.Values, got)
+			require.EqualError(t, err, tc.Err)
+			if tc.Is != nil {
+				require.True(t, errors.Is(err, tc.Is))
+			}
+		})
+	}
+	validate(t, testCase{Name: "Truncated first value",
+		Stream: []byte{0x80},
+		Err:    "monotone stream: value 0 at offset 0: unexpected EOF",
+		Is:     io.ErrUnexpectedEOF,
+	})
+	validate(t, testCase{Name: "Truncated later value",
+		Stream: []byte{0x02, 0x01, 0xff, 0xff},
+		Values: []int64{1, 2},
+		Err:    "monotone stream: value 2 at offset 2: unexpected EOF",
+		Is:     io.ErrUnexpectedEOF,
+	})
+	validate(t, testCase{Name: "Varint too long",
+		Stream: append([]byte{0x00}, bytes.Repeat([]byte{0xff}, 11)...),
+		Values: []int64{0},
+		Err:    "monotone stream: value 1 at offset 1: binary: varint overflows a 64-bit integer",
+	})
+	validate(t, testCase{Name: "Difference past MaxInt64",
+		// 1, then a difference of MaxInt64.
+		Stream: []byte{0x02, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f},
+		Values: []int64{1},
+		Err:    "monotone stream: value 1 at offset 1: difference 9223372036854775807 after 1 overflows int64",
+	})
+}
+
+func TestMonotoneMillionValues(t *testing.T) {
+	const n = 1_000_000
+	var buf bytes.Buffer
+	w := NewMonotoneWriter(&buf)
+	for _, id := range realisticIDs(n) {
+		if err := w.WriteValue(int64(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Logf("%d values in %d bytes, against %d fixed-width", n, buf.Len(), 8*n)
+	require.Less(t, buf.Len(), 8*n/4)
+
+	got, err := readAllValues(NewMonotoneReader(&buf))
+	require.NoError(t, err)
+	require.Len(t, got, n)
+	require.True(t, slices.IsSorted(got))
+}
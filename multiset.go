@@ -0,0 +1,78 @@
+package testdemo
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SortedMultiset is a multiset kept as a sorted slice of distinct values
+// and a parallel slice of how many times each occurs. Heavily duplicated
+// data takes far less memory than a sorted slice repeating each value,
+// and Count is O(log n) in the number of distinct values. The zero value
+// is an empty multiset ready to use.
+type SortedMultiset[T cmp.Ordered] struct {
+	values []T // strictly increasing
+	counts []int
+	total  int
+}
+
+// Add adds one occurrence of v.
+func (m *SortedMultiset[T]) Add(v T) {
+	i, found := slices.BinarySearch(m.values, v)
+	if found {
+		m.counts[i]++
+	} else {
+		m.values = slices.Insert(m.values, i, v)
+		m.counts = slices.Insert(m.counts, i, 1)
+	}
+	m.total++
+}
+
+// Remove removes one occurrence of v and reports whether there was one.
+func (m *SortedMultiset[T]) Remove(v T) bool {
+	i, found := slices.BinarySearch(m.values, v)
+	if !found {
+		return false
+	}
+	if m.counts[i]--; m.counts[i] == 0 {
+		m.values = slices.Delete(m.values, i, i+1)
+		m.counts = slices.Delete(m.counts, i, i+1)
+	}
+	m.total--
+	return true
+}
+
+// Count returns the number of occurrences of v.
+func (m *SortedMultiset[T]) Count(v T) int {
+	i, found := slices.BinarySearch(m.values, v)
+	if !found {
+		return 0
+	}
+	return m.counts[i]
+}
+
+// Len returns the number of distinct values.
+func (m *SortedMultiset[T]) Len() int {
+	return len(m.values)
+}
+
+// TotalLen returns the number of elements, counting every occurrence.
+func (m *SortedMultiset[T]) TotalLen() int {
+	return m.total
+}
+
+// Range returns an iterator over every occurrence in ascending order,
+// repeating each value as many times as it occurs without materializing
+// the repeats. The multiset must not be changed while iterating.
+func (m *SortedMultiset[T]) Range() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i, v := range m.values {
+			for range m.counts[i] {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package testdemo
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+// requireSortedMultisetInvariant fails t unless m holds strictly
+// increasing values with positive counts that add up to its total.
+func requireSortedMultisetInvariant[T cmp.Ordered](t *testing.T, m *SortedMultiset[T]) {
+	t.Helper()
+	require.Equal(t, len(m.values), len(m.counts))
+	total := 0
+	for i, c := range m.counts {
+		if i > 0 {
+			require.Less(t, m.values[i-1], m.values[i], "values %d and %d of %v", i-1, i, m.values)
+		}
+		require.Positive(t, c, "count of %v", m.values[i])
+		total += c
+	}
+	require.Equal(t, total, m.total)
+}
+
+func TestSortedMultiset(t *testing.T) {
+	var m SortedMultiset[string]
+	require.False(t, m.Remove("a"))
+	require.Equal(t, 0, m.TotalLen())
+
+	m.Add("b")
+	m.Add("a")
+	m.Add("b")
+	require.Equal(t, 2, m.Count("b"))
+	require.Equal(t, 0, m.Count("c"))
+	require.Equal(t, 2, m.Len())
+	require.Equal(t, 3, m.TotalLen())
+	require.Equal(t, []string{"a", "b", "b"}, slices.Collect(m.Range()))
+
+	require.False(t, m.Remove("c"))
+	require.Equal(t, 3, m.TotalLen())
+	require.True(t, m.Remove("b"))
+	require.Equal(t, 1, m.Count("b"))
+	require.True(t, m.Remove("a"))
+	require.Equal(t, []string{"b"}, slices.Collect(m.Range()))
+	requireSortedMultisetInvariant(t, &m)
+}
+
+func TestSortedMultisetRangeStopsEarly(t *testing.T) {
+	var m SortedMultiset[int]
+	for _, v := range []int{1, 1, 1, 2} {
+		m.Add(v)
+	}
+	var seen []int
+	for v := range m.Range() {
+		seen = append(seen, v)
+		if len(seen) == 2 {
+			break
+		}
+	}
+	require.Equal(t, []int{1, 1}, seen)
+}
+
+func TestSortedMultisetRandomOperations(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	m := &SortedMultiset[int]{}
+	var model []int // sorted, with duplicates
+	for i := 0; i < 5000; i++ {
+		v := rng.Intn(20)
+		if rng.Intn(3) == 0 {
+			j, found := slices.BinarySearch(model, v)
+			require.Equal(t, found, m.Remove(v))
+			if found {
+				model = slices.Delete(model, j, j+1)
+			}
+		} else {
+			m.Add(v)
+			j, _ := slices.BinarySearch(model, v)
+			model = slices.Insert(model, j, v)
+		}
+		requireSortedMultisetInvariant(t, m)
+		lo, hi := RankRange(model, v)
+		require.Equal(t, hi-lo, m.Count(v))
+		require.Equal(t, len(model), m.TotalLen())
+	}
+	require.Equal(t, model, slices.Collect(m.Range()))
+}
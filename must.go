@@ -0,0 +1,37 @@
+package testdemo
+
+import "cmp"
+
+// The Must functions are for data that is sorted unless the program is
+// wrong, such as lookup tables checked at init time. Rather than return
+// an error they panic with the *UnsortedError it would have been, which
+// recover can inspect, and otherwise return their input without copying.
+
+// MustSorted returns data if it is sorted in non-decreasing order, and
+// panics with an *UnsortedError otherwise.
+func MustSorted(data []int) []int {
+	if err := EnsureSorted(data); err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// MustAsSorted is AsSorted that panics with the *UnsortedError instead of
+// returning it.
+func MustAsSorted[T cmp.Ordered](data []T) Sorted[T] {
+	s, err := AsSorted(data)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// MustMergeSorted is MergeSorted that panics with the *UnsortedError
+// instead of returning it.
+func MustMergeSorted(a, b []int) []int {
+	merged, err := MergeSorted(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return merged
+}
@@ -0,0 +1,64 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recoverPanic returns the value f panics with, or nil if it returns.
+func recoverPanic(f func()) (v any) {
+	defer func() { v = recover() }()
+	f()
+	return nil
+}
+
+func TestMustPanics(t *testing.T) {
+	type testCase struct {
+		Name string
+		Call func()
+		Err  *UnsortedError
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			v := recoverPanic(tc.Call)
+			err, ok := v.(*UnsortedError)
+			require.True(t, ok, "panicked with %T, want *UnsortedError", v)
+			require.Equal(t, tc.Err, err)
+		})
+	}
+	validate(t, testCase{Name: "MustSorted",
+		Call: func() { MustSorted([]int{1, 3, 2}) },
+		Err:  &UnsortedError{Index: 2, Prev: 3, Next: 2, Len: 3},
+	})
+	validate(t, testCase{Name: "MustAsSorted",
+		Call: func() { MustAsSorted([]string{"b", "a"}) },
+		Err:  &UnsortedError{Index: 1, Prev: "b", Next: "a", Len: 2},
+	})
+	validate(t, testCase{Name: "MustMergeSorted first input",
+		Call: func() { MustMergeSorted([]int{2, 1}, []int{1}) },
+		Err:  &UnsortedError{Index: 1, Prev: 2, Next: 1, Source: "a", Len: 2},
+	})
+	validate(t, testCase{Name: "MustMergeSorted second input",
+		Call: func() { MustMergeSorted([]int{1}, []int{1, 5, 4}) },
+		Err:  &UnsortedError{Index: 2, Prev: 5, Next: 4, Source: "b", Len: 3},
+	})
+}
+
+func TestMustReturnsInputUncopied(t *testing.T) {
+	data := make([]int, 3, 8)
+	data[1], data[2] = 1, 2
+	got := MustSorted(data)
+	require.Same(t, &data[0], &got[0])
+	require.Equal(t, len(data), len(got))
+	require.Equal(t, cap(data), cap(got))
+
+	s := MustAsSorted(data)
+	require.Same(t, &data[0], &s.Slice()[0])
+	require.Equal(t, cap(data), cap(s.Slice()))
+
+	require.Equal(t, []int{0, 1, 1, 2, 2, 3}, MustMergeSorted(data, []int{1, 2, 3}))
+	require.Empty(t, MustMergeSorted(nil, nil))
+}
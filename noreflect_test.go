@@ -0,0 +1,30 @@
+//go:build tinygo || noreflect
+
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCoreWithoutReflect is a smoke test of the core API in the build
+// that leaves out the reflection-based helpers:
+//
+//	go test -tags noreflect -run TestCoreWithoutReflect .
+func TestCoreWithoutReflect(t *testing.T) {
+	require.True(t, IsSorted([]int{1, 2, 2, 5}))
+	require.Equal(t, 2, FirstUnsortedIndex([]int{1, 3, 2}))
+	require.True(t, IsSortedOrdered([]float64{-1, 0, 0.5}))
+	require.False(t, IsSortedFunc([]string{"b", "a"}, func(a, b string) bool { return a < b }))
+
+	i, ok := Search([]int{1, 3, 5}, 5)
+	require.True(t, ok)
+	require.Equal(t, 2, i)
+
+	_, err := AsSorted([]int{2, 1})
+	require.ErrorIs(t, err, ErrUnsorted)
+	var unsorted *UnsortedError
+	require.ErrorAs(t, err, &unsorted)
+	require.Equal(t, "index 1: 2 followed by 1", unsorted.Error())
+}
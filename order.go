@@ -0,0 +1,70 @@
+package testdemo
+
+import "context"
+
+// Order is the order a check expects. The zero value is non-decreasing
+// order, which is what IsSorted checks.
+type Order struct {
+	// Descending checks for non-increasing instead of non-decreasing order.
+	Descending bool
+	// Strict makes equal neighbors count as out of order.
+	Strict bool
+	// Source names the input, such as a file name, in the *UnsortedError
+	// of a check that finds it out of order.
+	Source string
+}
+
+// ctxCheckInterval is how many elements are compared between looks at
+// whether the context is done.
+const ctxCheckInterval = 1 << 16
+
+// CheckSortedCtx checks that data is in the given order. It returns nil
+// when it is and an *UnsortedError for the first pair out of order
+// otherwise. For huge slices it gives up early, returning ctx.Err(), once
+// ctx is done, and reports its progress in elements to an optional
+// Progress.
+func CheckSortedCtx(ctx context.Context, data []int, order Order, progress ...Progress) error {
+	err := checkSorted(ctx, data, order, progress...)
+	// A check given up because ctx is done is not reported to the hooks.
+	if err == nil || err != ctx.Err() {
+		reportCheck(len(data), err)
+	}
+	return err
+}
+
+// checkSorted is CheckSortedCtx without the hooks, for the functions that
+// check their inputs on the way to doing something else with them.
+func checkSorted(ctx context.Context, data []int, order Order, progress ...Progress) error {
+	report := firstProgress(progress).reporter(int64(len(data)))
+	for start := 1; start < len(data); start += ctxCheckInterval {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := start + ctxCheckInterval
+		if end > len(data) {
+			end = len(data)
+		}
+		for i := start; i < end; i++ {
+			if !inOrder(int64(data[i-1]), int64(data[i]), order.Descending, order.Strict) {
+				return &UnsortedError{Index: i, Prev: data[i-1], Next: data[i], Source: order.Source, Len: len(data)}
+			}
+		}
+		report.update(int64(end))
+	}
+	report.finish(int64(len(data)))
+	return nil
+}
+
+// EnsureSorted checks that data is sorted in non-decreasing order. It
+// returns nil when it is and an *UnsortedError for the first pair out of
+// order otherwise.
+func EnsureSorted(data []int) error {
+	return CheckSortedCtx(context.Background(), data, Order{})
+}
+
+// IsSortedCtx is IsSorted for slices large enough to want cancellation:
+// it returns ctx.Err() once ctx is done instead of finishing the check.
+// An optional Progress is reported to as CheckSortedCtx does.
+func IsSortedCtx(ctx context.Context, data []int, progress ...Progress) (bool, error) {
+	return sortedResult(CheckSortedCtx(ctx, data, Order{}, progress...))
+}
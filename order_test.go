@@ -0,0 +1,81 @@
+package testdemo
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSortedCtx(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Order    Order
+		Expected error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := CheckSortedCtx(context.Background(), tc.Array, tc.Order)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+	})
+	validate(t, testCase{Name: "Single element",
+		Array: []int{0},
+	})
+	validate(t, testCase{Name: "Two equal",
+		Array: []int{0, 0},
+	})
+	validate(t, testCase{Name: "Two elements unsorted",
+		Array:    []int{0, math.MinInt},
+		Expected: &UnsortedError{Index: 1, Prev: 0, Next: math.MinInt, Len: 2},
+	})
+	validate(t, testCase{Name: "Strict",
+		Array:    []int{1, 2, 2},
+		Order:    Order{Strict: true},
+		Expected: &UnsortedError{Index: 2, Prev: 2, Next: 2, Len: 3},
+	})
+	validate(t, testCase{Name: "Descending",
+		Array: []int{3, 3, 1},
+		Order: Order{Descending: true},
+	})
+	validate(t, testCase{Name: "Source",
+		Array:    []int{2, 1},
+		Order:    Order{Source: "ids"},
+		Expected: &UnsortedError{Index: 1, Prev: 2, Next: 1, Source: "ids", Len: 2},
+	})
+	validate(t, testCase{Name: "Descending strict",
+		Array:    []int{3, 3, 1},
+		Order:    Order{Descending: true, Strict: true},
+		Expected: &UnsortedError{Index: 1, Prev: 3, Next: 3, Len: 3},
+	})
+}
+
+func TestIsSortedCtx(t *testing.T) {
+	data := make([]int, 3*ctxCheckInterval)
+	data[len(data)-1] = -1
+
+	sorted, err := IsSortedCtx(context.Background(), data[:len(data)-1])
+	require.NoError(t, err)
+	require.True(t, sorted)
+
+	sorted, err = IsSortedCtx(context.Background(), data)
+	require.NoError(t, err)
+	require.False(t, sorted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = IsSortedCtx(ctx, data)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// A slice without a pair to compare is sorted, even once ctx is done.
+	sorted, err = IsSortedCtx(ctx, []int{1})
+	require.NoError(t, err)
+	require.True(t, sorted)
+}
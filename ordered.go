@@ -0,0 +1,91 @@
+package testdemo
+
+import "cmp"
+
+// IsSortedOrdered reports whether data is sorted in non-decreasing order
+// as cmp.Less sees it, so any NaNs must come first. For []int, []int32,
+// []int64, []uint64 and []float64 it runs a loop written for that type,
+// which the compiler optimizes better than the generic one.
+func IsSortedOrdered[T cmp.Ordered](data []T) bool {
+	if sorted, ok := isSortedFast(data); ok {
+		return sorted
+	}
+	return isSortedGeneric(data)
+}
+
+// isSortedFast reports whether data is sorted using the loop specialized
+// for its type, with ok false when there is none. It switches on the
+// type of the whole slice once, not per element.
+func isSortedFast[T cmp.Ordered](data []T) (sorted, ok bool) {
+	switch data := any(data).(type) {
+	case []int:
+		return IsSorted(data), true
+	case []int32:
+		return IsSortedInt32(data), true
+	case []int64:
+		return IsSortedInt64(data), true
+	case []uint64:
+		return isSortedUint64s(data), true
+	case []float64:
+		return isSortedFloat64s(data), true
+	}
+	return false, false
+}
+
+func isSortedGeneric[T cmp.Ordered](data []T) bool {
+	for i := 1; i < len(data); i++ {
+		if cmp.Less(data[i], data[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSortedInt32 reports whether data is sorted in non-decreasing order.
+// Like IsSortedInt64, it is for data whose width must not depend on the
+// platform, as int's does.
+func IsSortedInt32(data []int32) bool {
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSortedInt64 reports whether data is sorted in non-decreasing order.
+// It is for 64-bit values, such as IDs and timestamps, that must keep
+// their range where int is 32 bits.
+func IsSortedInt64(data []int64) bool {
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+func isSortedUint64s(data []uint64) bool {
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// isSortedFloat64s orders NaNs before every other value, as cmp.Less
+// does. A single comparison passes ordered pairs of non-NaNs, and only
+// the pairs it fails are looked at for NaNs.
+func isSortedFloat64s(data []float64) bool {
+	for i := 1; i < len(data); i++ {
+		prev, v := data[i-1], data[i]
+		if v >= prev {
+			continue
+		}
+		if v < prev || (v != v && prev == prev) {
+			return false
+		}
+	}
+	return true
+}
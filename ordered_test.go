@@ -0,0 +1,91 @@
+package testdemo
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/fuzzdata"
+	"github.com/stretchr/testify/require"
+)
+
+// corpusSlices returns the slices in the FuzzIsSorted corpus.
+func corpusSlices(t *testing.T) [][]int {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("testdata", "fuzz", "FuzzIsSorted", "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	var out [][]int
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		b, err := fuzzdata.DecodeCorpusFile(content)
+		require.NoError(t, err, file)
+		out = append(out, fuzzdata.Ints(b))
+	}
+	return out
+}
+
+func convertSlice[T, U int | int32 | int64 | uint64 | float64](data []T) []U {
+	out := make([]U, len(data))
+	for i, v := range data {
+		out[i] = U(v)
+	}
+	return out
+}
+
+func TestIsSortedOrderedMatchesGeneric(t *testing.T) {
+	inputs := corpusSlices(t)
+	inputs = append(inputs, nil, []int{1}, []int{2, 1}, []int{-1, 0, 1}, []int{math.MinInt, math.MaxInt})
+	for _, data := range inputs {
+		requireSameResult(t, data)
+		requireSameResult(t, convertSlice[int, int64](data))
+		requireSameResult(t, convertSlice[int, uint64](data))
+		requireSameResult(t, convertSlice[int, float64](data))
+		requireSameResult(t, convertSlice[int, int32](data))
+	}
+
+	nan := math.NaN()
+	for _, data := range [][]float64{
+		{nan, nan, 1, 2},
+		{1, nan},
+		{nan, math.Inf(-1), math.Inf(1)},
+		{math.Inf(1), math.Inf(-1)},
+		{0, math.Copysign(0, -1)},
+	} {
+		requireSameResult(t, data)
+	}
+}
+
+func requireSameResult[T int | int32 | int64 | uint64 | float64](t *testing.T, data []T) {
+	t.Helper()
+	_, specialized := isSortedFast(data)
+	require.Equal(t, isSortedGeneric(data), IsSortedOrdered(data), "%T %v (specialized: %v)", data, data, specialized)
+}
+
+func TestIsSortedFastDispatch(t *testing.T) {
+	_, ok := isSortedFast([]int{1})
+	require.True(t, ok)
+	_, ok = isSortedFast([]int64{1})
+	require.True(t, ok)
+	_, ok = isSortedFast([]uint64{1})
+	require.True(t, ok)
+	_, ok = isSortedFast([]float64{1})
+	require.True(t, ok)
+	_, ok = isSortedFast([]int32{1})
+	require.True(t, ok)
+	_, ok = isSortedFast([]int16{1})
+	require.False(t, ok)
+	_, ok = isSortedFast([]string{"a"})
+	require.False(t, ok)
+}
+
+func TestIsSortedExplicitWidths(t *testing.T) {
+	require.True(t, IsSortedInt32(nil))
+	require.True(t, IsSortedInt32([]int32{math.MinInt32, 0, 0, math.MaxInt32}))
+	require.False(t, IsSortedInt32([]int32{math.MaxInt32, math.MinInt32}))
+	require.True(t, IsSortedInt64(nil))
+	require.True(t, IsSortedInt64([]int64{math.MinInt64, math.MinInt32, 0, math.MaxInt32, math.MaxInt64}))
+	require.False(t, IsSortedInt64([]int64{math.MaxInt32 + 1, math.MaxInt32}))
+}
@@ -0,0 +1,124 @@
+// Package ordertest checks that Less methods and comparators obey the
+// laws of an order, so the people writing them do not each have to
+// write the tests. Given a handful of samples, including equal ones:
+//
+//	func TestVersionOrder(t *testing.T) {
+//		ordertest.RunCompare(t, CompareVersions, []Version{{1, 2}, {1, 10}, {1, 10}, {2, 0}})
+//	}
+//
+// In the messages, a < b means a.Less(b) or cmp(a, b) < 0, and a == b
+// that neither a < b nor b < a. The laws of a strict weak order are
+// checked by testdemo.ValidateLess, on every pair and triple of the
+// first 64 samples, so a few dozen samples are plenty.
+package ordertest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo"
+)
+
+// Lesser is a type ordered by its Less method.
+type Lesser[T any] interface {
+	Less(other T) bool
+}
+
+// reporter is the part of *testing.T the checks report to.
+type reporter interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// RunLesser checks that Less is a strict weak order on samples:
+// irreflexive, asymmetric and transitive, with equality, neither sample
+// less than the other, transitive too. It also checks that sorting
+// samples by Less gives a slice IsSortedFunc accepts. The first of these
+// laws found broken, and a failed sort, each fail t once, naming the
+// samples that show it.
+func RunLesser[T Lesser[T]](t *testing.T, samples []T) {
+	t.Helper()
+	runLesser(t, samples)
+}
+
+// RunCompare checks that cmp is a three-way comparison on samples:
+// cmp(a, a) is 0 and cmp(b, a) has the opposite sign of cmp(a, b), on
+// top of the laws RunLesser checks for cmp(a, b) < 0. It also checks
+// that sorting samples with slices.SortFunc and cmp gives a slice
+// IsSortedFunc accepts.
+func RunCompare[T any](t *testing.T, cmp func(a, b T) int, samples []T) {
+	t.Helper()
+	runCompare(t, cmp, samples)
+}
+
+func runLesser[T Lesser[T]](t reporter, samples []T) {
+	t.Helper()
+	checkLess(t, func(a, b T) bool { return a.Less(b) }, samples)
+}
+
+func runCompare[T any](t reporter, cmp func(a, b T) int, samples []T) {
+	t.Helper()
+	w := witnesses(samples)
+	reflexivity, antisymmetry := false, false
+	for i, a := range samples {
+		if c := cmp(a, a); c != 0 && !reflexivity {
+			t.Errorf("ordertest: reflexivity: cmp(a, a) = %d, not 0, for a = %s", c, w(i))
+			reflexivity = true
+		}
+		for j, b := range samples {
+			ab, ba := cmp(a, b), cmp(b, a)
+			if sign(ab) != -sign(ba) && !antisymmetry {
+				t.Errorf("ordertest: antisymmetry: cmp(a, b) = %d but cmp(b, a) = %d, for a = %s, b = %s", ab, ba, w(i), w(j))
+				antisymmetry = true
+			}
+		}
+	}
+	checkLess(t, func(a, b T) bool { return cmp(a, b) < 0 }, samples)
+}
+
+// checkLess checks the laws of a strict weak order with
+// testdemo.ValidateLess, and that sorting by less agrees with
+// IsSortedFunc.
+func checkLess[T any](t reporter, less func(a, b T) bool, samples []T) {
+	t.Helper()
+	var broken *testdemo.LessError
+	if errors.As(testdemo.ValidateLess(samples, less), &broken) {
+		a, b, c := broken.A, broken.B, broken.C
+		switch broken.Law {
+		case testdemo.LawIrreflexive:
+			t.Errorf("ordertest: %s: a < a, for a = %#v", broken.Law, a)
+		case testdemo.LawAsymmetric:
+			t.Errorf("ordertest: %s: a < b and b < a, for a = %#v, b = %#v", broken.Law, a, b)
+		case testdemo.LawTransitive:
+			t.Errorf("ordertest: %s: a < b and b < c but not a < c, for a = %#v, b = %#v, c = %#v", broken.Law, a, b, c)
+		default:
+			t.Errorf("ordertest: %s: a == b and b == c but not a == c, for a = %#v, b = %#v, c = %#v", broken.Law, a, b, c)
+		}
+	}
+
+	sorted := slices.Clone(samples)
+	slices.SortFunc(sorted, testdemo.LessToCompare(less))
+	if !testdemo.IsSortedFunc(sorted, less) {
+		t.Errorf("ordertest: sorting: IsSortedFunc rejects the samples sorted by slices.SortFunc, %#v", sorted)
+	}
+}
+
+// witnesses returns a function formatting samples[i] for a message.
+func witnesses[T any](samples []T) func(i int) string {
+	return func(i int) string {
+		return fmt.Sprintf("samples[%d] = %#v", i, samples[i])
+	}
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	default:
+		return 0
+	}
+}
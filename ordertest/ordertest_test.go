@@ -0,0 +1,117 @@
+package ordertest
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReporter is a reporter recording what is reported to it.
+type fakeReporter struct {
+	errors []string
+}
+
+func (f *fakeReporter) Helper() {}
+func (f *fakeReporter) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// lenient is deliberately wrong: its Less is <=.
+type lenient int
+
+func (a lenient) Less(b lenient) bool { return a <= b }
+
+// byValue is correct.
+type byValue int
+
+func (a byValue) Less(b byValue) bool { return a < b }
+
+func TestRunCompare(t *testing.T) {
+	type testCase struct {
+		Name           string
+		Compare        func(a, b int) int
+		Samples        []int
+		ExpectedErrors []string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			f := &fakeReporter{}
+			runCompare(f, tc.Compare, tc.Samples)
+			require.Equal(t, tc.ExpectedErrors, f.errors)
+		})
+	}
+	validate(t, testCase{Name: "cmp.Compare",
+		Compare: cmp.Compare[int],
+		Samples: []int{3, 1, 2, 2, -1},
+	})
+	validate(t, testCase{Name: "No samples",
+		Compare: func(a, b int) int { return -1 },
+	})
+	validate(t, testCase{Name: "Always less",
+		Compare: func(a, b int) int { return -1 },
+		Samples: []int{1, 2},
+		ExpectedErrors: []string{
+			"ordertest: reflexivity: cmp(a, a) = -1, not 0, for a = samples[0] = 1",
+			"ordertest: antisymmetry: cmp(a, b) = -1 but cmp(b, a) = -1, for a = samples[0] = 1, b = samples[0] = 1",
+			"ordertest: irreflexivity: a < a, for a = 1",
+			"ordertest: sorting: IsSortedFunc rejects the samples sorted by slices.SortFunc, []int{2, 1}",
+		},
+	})
+	validate(t, testCase{Name: "Rock paper scissors",
+		Compare: func(a, b int) int {
+			switch {
+			case a == b:
+				return 0
+			case (a+1)%3 == b:
+				return -1
+			default:
+				return 1
+			}
+		},
+		Samples: []int{0, 1, 2},
+		ExpectedErrors: []string{
+			"ordertest: transitivity: a < b and b < c but not a < c, for a = 0, b = 1, c = 2",
+		},
+	})
+	validate(t, testCase{Name: "Equal within one",
+		Compare: func(a, b int) int {
+			if a-b <= 1 && b-a <= 1 {
+				return 0
+			}
+			return cmp.Compare(a, b)
+		},
+		Samples: []int{1, 2, 3},
+		ExpectedErrors: []string{
+			"ordertest: transitivity of incomparability: a == b and b == c but not a == c, for a = 1, b = 2, c = 3",
+		},
+	})
+	validate(t, testCase{Name: "One-sided",
+		Compare: func(a, b int) int {
+			if a == 1 && b == 2 {
+				return 0
+			}
+			return cmp.Compare(a, b)
+		},
+		Samples: []int{1, 2},
+		ExpectedErrors: []string{
+			"ordertest: antisymmetry: cmp(a, b) = 0 but cmp(b, a) = 1, for a = samples[0] = 1, b = samples[1] = 2",
+		},
+	})
+}
+
+func TestRunLesser(t *testing.T) {
+	f := &fakeReporter{}
+	runLesser(f, []byValue{2, 1, 1, 3})
+	require.Empty(t, f.errors)
+
+	f = &fakeReporter{}
+	runLesser(f, []lenient{2, 1, 1})
+	require.Equal(t, []string{
+		"ordertest: irreflexivity: a < a, for a = 2",
+		"ordertest: sorting: IsSortedFunc rejects the samples sorted by slices.SortFunc, []ordertest.lenient{1, 1, 2}",
+	}, f.errors)
+}
@@ -0,0 +1,30 @@
+package testdemo_test
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo"
+	"github.com/StevenACoffman/testdemo/ordertest"
+)
+
+// These tests are in testdemo_test because ordertest imports testdemo.
+
+func TestCompareNaturalIsAnOrder(t *testing.T) {
+	ordertest.RunCompare(t, testdemo.CompareNatural, []string{
+		"", "a", "A", "a1", "a01", "a001", "a2", "a10", "a1b", "a01b", "a1a",
+		"b", "9", "10", "009", "x99y", "x099y", "x100y", "x100", "1.10", "1.9",
+		"é", "a1", "00", "0",
+	})
+}
+
+func TestCompareUTF16IsAnOrder(t *testing.T) {
+	ordertest.RunCompare(t, testdemo.CompareUTF16, []string{
+		"", "a", "ab", "b", "\uffff", "\U00010000", "\U0010ffff", "", "é", "ab",
+	})
+}
+
+func TestVersionsAreAnOrder(t *testing.T) {
+	versions := []testdemo.Version{{1, 2}, {1, 10}, {1, 10}, {2, 0}, {0, 99}, {10, 1}, {2, 0}}
+	ordertest.RunCompare(t, testdemo.CompareVersions, versions)
+	ordertest.RunLesser(t, versions)
+}
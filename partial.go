@@ -0,0 +1,26 @@
+package testdemo
+
+// IsPartiallySorted reports whether data[:k] holds the k smallest elements
+// of data in sorted order, as a partial sort for top-k queries leaves it;
+// data[k:] may be in any order. It runs in O(n) by comparing the largest
+// element of the prefix with the smallest of the suffix. A k of zero or
+// less is always satisfied, and a k of len(data) or more is IsSorted.
+func IsPartiallySorted(data []int, k int) bool {
+	if k <= 0 {
+		return true
+	}
+	if k >= len(data) {
+		return IsSorted(data)
+	}
+	if !IsSorted(data[:k]) {
+		return false
+	}
+	// The prefix is sorted, so its last element is its largest.
+	largest := data[k-1]
+	for _, v := range data[k:] {
+		if v < largest {
+			return false
+		}
+	}
+	return true
+}
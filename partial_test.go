@@ -0,0 +1,109 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPartiallySorted(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		K        int
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := IsPartiallySorted(tc.Array, tc.K)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		K:        3,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Zero k",
+		Array:    []int{3, 2, 1},
+		K:        0,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Negative k",
+		Array:    []int{3, 2, 1},
+		K:        -1,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Prefix sorted, suffix unordered",
+		Array:    []int{1, 2, 5, 3, 4},
+		K:        2,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Prefix unsorted",
+		Array:    []int{2, 1, 5, 3, 4},
+		K:        2,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Suffix holds a smaller element",
+		Array:    []int{1, 3, 5, 2, 4},
+		K:        2,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Duplicates straddling the boundary",
+		Array:    []int{1, 2, 2, 2, 9, 2},
+		K:        2,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "k equals length",
+		Array:    []int{1, 2, 3},
+		K:        3,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "k beyond length, unsorted",
+		Array:    []int{1, 3, 2},
+		K:        10,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array:    []int{math.MinInt, math.MaxInt, 0},
+		K:        1,
+		Expected: true,
+	})
+}
+
+func TestIsPartiallySortedAgainstSort(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 1000; i++ {
+		data := make([]int, rng.Intn(20))
+		for j := range data {
+			data[j] = rng.Intn(10)
+		}
+		k := rng.Intn(len(data)+2) - 1
+		if rng.Intn(2) == 0 && k > 0 && k < len(data) {
+			// Make most of these partially sorted, so both answers get
+			// exercised.
+			sort.Ints(data)
+			rng.Shuffle(len(data)-k, func(a, b int) { data[k+a], data[k+b] = data[k+b], data[k+a] })
+		}
+
+		sorted := make([]int, len(data))
+		copy(sorted, data)
+		sort.Ints(sorted)
+		n := k
+		if n < 0 {
+			n = 0
+		}
+		if n > len(data) {
+			n = len(data)
+		}
+		expected := slices.Equal(data[:n], sorted[:n])
+
+		require.Equal(t, expected, IsPartiallySorted(data, k), "%v k=%d", data, k)
+	}
+}
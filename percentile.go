@@ -0,0 +1,44 @@
+package testdemo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MedianSorted returns the median of data, which must be sorted: the
+// middle element for odd lengths and the mean of the two middle elements
+// for even ones. It is PercentileSorted(data, 50).
+func MedianSorted(data []int) (float64, error) {
+	return PercentileSorted(data, 50)
+}
+
+// PercentileSorted returns the p-th percentile of data, which must be
+// sorted in non-decreasing order; an *UnsortedError is returned if it is
+// not. p must be within [0, 100].
+//
+// The percentile is interpolated linearly between the closest ranks, the
+// method spreadsheets call PERCENTILE.INC and NumPy calls "linear": with n
+// elements, the rank is r = p/100 * (n-1), and the result is
+// data[⌊r⌋] + (r-⌊r⌋) * (data[⌊r⌋+1] - data[⌊r⌋]). So p 0 is the
+// minimum, p 100 the maximum, and a single element is every percentile.
+func PercentileSorted(data []int, p float64) (float64, error) {
+	if math.IsNaN(p) || p < 0 || p > 100 {
+		return 0, fmt.Errorf("percentile %v is outside [0, 100]", p)
+	}
+	if len(data) == 0 {
+		return 0, errors.New("percentile of an empty slice")
+	}
+	if err := checkSorted(context.Background(), data, Order{}); err != nil {
+		return 0, err
+	}
+	rank := p / 100 * float64(len(data)-1)
+	lower := int(rank)
+	if lower == len(data)-1 {
+		return float64(data[lower]), nil
+	}
+	frac := rank - float64(lower)
+	lo, hi := float64(data[lower]), float64(data[lower+1])
+	return lo + frac*(hi-lo), nil
+}
@@ -0,0 +1,144 @@
+package testdemo
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentileSorted(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		P        float64
+		Expected float64
+		Err      string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := PercentileSorted(tc.Array, tc.P)
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, tc.Expected, actual, 1e-9)
+		})
+	}
+	validate(t, testCase{Name: "Single element",
+		Array:    []int{7},
+		P:        90,
+		Expected: 7,
+	})
+	validate(t, testCase{Name: "Minimum",
+		Array:    []int{1, 2, 3, 4},
+		P:        0,
+		Expected: 1,
+	})
+	validate(t, testCase{Name: "Maximum",
+		Array:    []int{1, 2, 3, 4},
+		P:        100,
+		Expected: 4,
+	})
+	validate(t, testCase{Name: "Exact rank",
+		Array:    []int{10, 20, 30, 40, 50},
+		P:        25,
+		Expected: 20,
+	})
+	validate(t, testCase{Name: "Interpolated",
+		// rank 0.9 * 4 = 3.6, so 40 + 0.6 * (50-40).
+		Array:    []int{10, 20, 30, 40, 50},
+		P:        90,
+		Expected: 46,
+	})
+	validate(t, testCase{Name: "Interpolated even length",
+		// rank 0.1 * 3 = 0.3, so 1 + 0.3 * (5-1).
+		Array:    []int{1, 5, 6, 9},
+		P:        10,
+		Expected: 2.2,
+	})
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+		P:     50,
+		Err:   "percentile of an empty slice",
+	})
+	validate(t, testCase{Name: "Below range",
+		Array: []int{1},
+		P:     -1,
+		Err:   "percentile -1 is outside [0, 100]",
+	})
+	validate(t, testCase{Name: "Above range",
+		Array: []int{1},
+		P:     100.5,
+		Err:   "percentile 100.5 is outside [0, 100]",
+	})
+	validate(t, testCase{Name: "NaN",
+		Array: []int{1},
+		P:     math.NaN(),
+		Err:   "percentile NaN is outside [0, 100]",
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Array: []int{1, 3, 2},
+		P:     50,
+		Err:   "index 2: 3 followed by 2",
+	})
+}
+
+func TestMedianSorted(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Expected float64
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := MedianSorted(tc.Array)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Single element",
+		Array:    []int{-3},
+		Expected: -3,
+	})
+	validate(t, testCase{Name: "Odd length",
+		Array:    []int{1, 2, 9},
+		Expected: 2,
+	})
+	validate(t, testCase{Name: "Even length",
+		Array:    []int{1, 2, 3, 9},
+		Expected: 2.5,
+	})
+
+	var unsorted *UnsortedError
+	_, err := MedianSorted([]int{2, 1})
+	require.ErrorAs(t, err, &unsorted)
+}
+
+func TestPercentileSortedAgainstReference(t *testing.T) {
+	// The reference sorts a copy and indexes it directly, which only
+	// works for percentiles that land exactly on a rank.
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 200; i++ {
+		data := make([]int, rng.Intn(30)+1)
+		for j := range data {
+			data[j] = rng.Intn(1000) - 500
+		}
+		sort.Ints(data)
+		rank := rng.Intn(len(data))
+		p := 100 * float64(rank)
+		if len(data) > 1 {
+			p /= float64(len(data) - 1)
+		}
+		actual, err := PercentileSorted(data, p)
+		require.NoError(t, err)
+		require.InDelta(t, float64(data[rank]), actual, 1e-9, "%v p=%v", data, p)
+	}
+}
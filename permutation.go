@@ -0,0 +1,40 @@
+package testdemo
+
+// IsPermutationOf reports whether a and b hold the same elements the same
+// number of times, in any order. When both are sorted it compares them in
+// a single walk without allocating; otherwise it counts a's elements
+// in a map.
+func IsPermutationOf(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if IsSorted(a) && IsSorted(b) {
+		// Sorted permutations of each other are equal.
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+	return IsPermutationOfComparable(a, b)
+}
+
+// IsPermutationOfComparable is IsPermutationOf for any comparable element
+// type. It always counts in a map, since the elements may have no order.
+func IsPermutationOfComparable[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[T]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		if counts[v] == 0 {
+			return false
+		}
+		counts[v]--
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPermutationOf(t *testing.T) {
+	type testCase struct {
+		Name     string
+		A, B     []int
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, IsPermutationOf(tc.A, tc.B), "IsPermutationOf")
+			require.Equal(t, tc.Expected, IsPermutationOfComparable(tc.A, tc.B), "IsPermutationOfComparable")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Different lengths",
+		A: []int{1, 2},
+		B: []int{1, 2, 2},
+	})
+	validate(t, testCase{Name: "Both sorted, equal",
+		A:        []int{1, 2, 2, 3},
+		B:        []int{1, 2, 2, 3},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Both sorted, different",
+		A: []int{1, 2, 2, 3},
+		B: []int{1, 2, 3, 3},
+	})
+	validate(t, testCase{Name: "Shuffled",
+		A:        []int{3, 1, 2, 2},
+		B:        []int{2, 3, 2, 1},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Duplicate heavy, different counts",
+		A: []int{7, 7, 7, 7, 1},
+		B: []int{7, 1, 7, 1, 7},
+	})
+}
+
+func TestIsPermutationOfSortedDoesNotAllocate(t *testing.T) {
+	a := []int{1, 2, 2, 3, 5, 8}
+	b := []int{1, 2, 2, 3, 5, 8}
+	allocs := testing.AllocsPerRun(100, func() { IsPermutationOf(a, b) })
+	require.Zero(t, allocs)
+}
+
+func TestIsPermutationOfComparableStrings(t *testing.T) {
+	require.True(t, IsPermutationOfComparable([]string{"b", "a", "b"}, []string{"b", "b", "a"}))
+	require.False(t, IsPermutationOfComparable([]string{"b", "a", "a"}, []string{"b", "b", "a"}))
+}
@@ -0,0 +1,81 @@
+package testdemo
+
+import "fmt"
+
+// ApplyPermutation returns data rearranged by perm, so that element i of
+// the result is data[perm[i]]. Applying the result of Argsort this way
+// sorts data. It returns an error if perm is not a permutation of the
+// indices of data: the wrong length, or an index out of range or
+// repeated.
+func ApplyPermutation[T any](data []T, perm []int) ([]T, error) {
+	if err := checkPermutation(perm, len(data)); err != nil {
+		return nil, err
+	}
+	out := make([]T, len(data))
+	for i, p := range perm {
+		out[i] = data[p]
+	}
+	return out, nil
+}
+
+// ApplyPermutationInPlace is ApplyPermutation rearranging data itself. It
+// follows each cycle of perm, moving every element once, so beyond data
+// it only needs a bit per element to track which have moved. data is
+// left untouched if perm is invalid.
+func ApplyPermutationInPlace[T any](data []T, perm []int) error {
+	if len(perm) != len(data) {
+		return fmt.Errorf("permutation has %d elements, want %d", len(perm), len(data))
+	}
+	seen := make(bitset, (len(perm)+63)/64)
+	for i, p := range perm {
+		if p < 0 || p >= len(perm) {
+			return fmt.Errorf("permutation index %d: %d is out of range [0, %d)", i, p, len(perm))
+		}
+		if seen.has(p) {
+			return fmt.Errorf("permutation index %d: %d is repeated", i, p)
+		}
+		seen.set(p)
+	}
+
+	// Every bit is set now, so a cleared bit marks an element as moved.
+	moved := seen
+	for start := range data {
+		if !moved.has(start) {
+			continue
+		}
+		first := data[start]
+		j := start
+		for {
+			moved.clear(j)
+			k := perm[j]
+			if k == start {
+				data[j] = first
+				break
+			}
+			data[j] = data[k]
+			j = k
+		}
+	}
+	return nil
+}
+
+// InversePermutation returns the permutation undoing perm: applying perm
+// and then its inverse leaves data as it was. It returns an error if perm
+// is not a permutation of 0 to len(perm)-1.
+func InversePermutation(perm []int) ([]int, error) {
+	if err := checkPermutation(perm, len(perm)); err != nil {
+		return nil, err
+	}
+	inv := make([]int, len(perm))
+	for i, p := range perm {
+		inv[p] = i
+	}
+	return inv, nil
+}
+
+// bitset is a set of small non-negative ints.
+type bitset []uint64
+
+func (b bitset) has(i int) bool { return b[i/64]&(1<<(i%64)) != 0 }
+func (b bitset) set(i int)      { b[i/64] |= 1 << (i % 64) }
+func (b bitset) clear(i int)    { b[i/64] &^= 1 << (i % 64) }
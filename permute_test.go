@@ -0,0 +1,96 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPermutation(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Perm     []int
+		Expected []string
+		Err      string
+	}
+	data := []string{"a", "b", "c", "d"}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := ApplyPermutation(data, tc.Perm)
+			inPlace := make([]string, len(data))
+			copy(inPlace, data)
+			inPlaceErr := ApplyPermutationInPlace(inPlace, tc.Perm)
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				require.EqualError(t, inPlaceErr, tc.Err)
+				require.Equal(t, data, inPlace, "an invalid permutation must not change data")
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, inPlaceErr)
+			require.Equal(t, tc.Expected, actual)
+			require.Equal(t, tc.Expected, inPlace)
+		})
+	}
+	validate(t, testCase{Name: "Identity",
+		Perm:     []int{0, 1, 2, 3},
+		Expected: []string{"a", "b", "c", "d"},
+	})
+	validate(t, testCase{Name: "One cycle",
+		Perm:     []int{1, 2, 3, 0},
+		Expected: []string{"b", "c", "d", "a"},
+	})
+	validate(t, testCase{Name: "Two cycles",
+		Perm:     []int{1, 0, 3, 2},
+		Expected: []string{"b", "a", "d", "c"},
+	})
+	validate(t, testCase{Name: "Wrong length",
+		Perm: []int{0, 1, 2},
+		Err:  "permutation has 3 elements, want 4",
+	})
+	validate(t, testCase{Name: "Out of range",
+		Perm: []int{0, 1, 2, -1},
+		Err:  "permutation index 3: -1 is out of range [0, 4)",
+	})
+	validate(t, testCase{Name: "Repeated",
+		Perm: []int{0, 2, 2, 1},
+		Err:  "permutation index 2: 2 is repeated",
+	})
+}
+
+func TestInversePermutation(t *testing.T) {
+	inv, err := InversePermutation([]int{2, 0, 1})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 0}, inv)
+
+	_, err = InversePermutation([]int{0, 0})
+	require.EqualError(t, err, "permutation index 1: 0 is repeated")
+}
+
+func TestPermutationProperties(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		n := rng.Intn(200)
+		data := make([]int, n)
+		for j := range data {
+			data[j] = rng.Intn(1000)
+		}
+		perm := rng.Perm(n)
+
+		applied, err := ApplyPermutation(data, perm)
+		require.NoError(t, err)
+		inPlace := make([]int, n)
+		copy(inPlace, data)
+		require.NoError(t, ApplyPermutationInPlace(inPlace, perm))
+		require.Equal(t, applied, inPlace)
+
+		inv, err := InversePermutation(perm)
+		require.NoError(t, err)
+		restored, err := ApplyPermutation(applied, inv)
+		require.NoError(t, err)
+		require.Equal(t, data, restored)
+	}
+}
@@ -0,0 +1,35 @@
+package testdemo
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PreservesOrder reports whether f is order-preserving over the values
+// in data, which may be in any order and is not modified: whether x < y
+// implies f(x) <= f(y) for every x and y in data. Ties are allowed, so a
+// truncating division preserves order while an overflowing
+// multiplication does not.
+//
+// It sorts the distinct values and checks that mapping them gives a
+// non-decreasing sequence. When it does not, the error names the first
+// adjacent pair of inputs, in ascending order, where the order flips.
+// Empty data preserves order.
+func PreservesOrder(data []int, f func(int) int) (bool, error) {
+	inputs := slices.Clone(data)
+	slices.Sort(inputs)
+	inputs = slices.Compact(inputs)
+	if len(inputs) == 0 {
+		return true, nil
+	}
+	prev := f(inputs[0])
+	for i := 1; i < len(inputs); i++ {
+		next := f(inputs[i])
+		if prev > next {
+			x, y := inputs[i-1], inputs[i]
+			return false, fmt.Errorf("order flips between inputs %d and %d: f(%d) = %d > f(%d) = %d", x, y, x, prev, y, next)
+		}
+		prev = next
+	}
+	return true, nil
+}
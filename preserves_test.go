@@ -0,0 +1,55 @@
+package testdemo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreservesOrder(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []int
+		F        func(int) int
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			ok, err := PreservesOrder(tc.Data, tc.F)
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				require.True(t, ok)
+				return
+			}
+			require.False(t, ok)
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Data: []int{},
+		F:    func(x int) int { return -x },
+	})
+	validate(t, testCase{Name: "Offset",
+		Data: []int{5, -3, 9, 0},
+		F:    func(x int) int { return x + 100 },
+	})
+	validate(t, testCase{Name: "Truncating division ties",
+		Data: []int{7, 1, 4, 9, 3, 3},
+		F:    func(x int) int { return x / 4 },
+	})
+	validate(t, testCase{Name: "Negation",
+		Data:     []int{3, 1, 2},
+		F:        func(x int) int { return -x },
+		Expected: "order flips between inputs 1 and 2: f(1) = -1 > f(2) = -2",
+	})
+	validate(t, testCase{Name: "Overflowing multiplication",
+		Data: []int{1, math.MaxInt / 2, math.MaxInt/2 + 1},
+		F:    func(x int) int { return x * 2 },
+		Expected: fmt.Sprintf("order flips between inputs %d and %d: f(%d) = %d > f(%d) = %d",
+			math.MaxInt/2, math.MaxInt/2+1, math.MaxInt/2, math.MaxInt-1, math.MaxInt/2+1, math.MinInt),
+	})
+}
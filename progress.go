@@ -0,0 +1,94 @@
+package testdemo
+
+import (
+	"io"
+	"io/fs"
+)
+
+// Progress reports how far a long check has got, as for a progress bar.
+// Func is called with how much of the input is done and how much there is
+// in total, counted in elements for slices and bytes for readers and
+// files, with -1 for a total that is not known up front, as for a pipe.
+// The done values never decrease, calls never overlap, and a check that
+// succeeds makes a last call with done equal to total, known by then. A
+// nil Func costs nothing.
+type Progress struct {
+	Func func(done, total int64)
+	// Every is how much more must be done between calls, and 1<<16 when
+	// not positive.
+	Every int64
+}
+
+// progressReporter calls a Progress's Func when enough more is done.
+type progressReporter struct {
+	fn           func(done, total int64)
+	every, total int64
+	last         int64
+}
+
+func (p Progress) reporter(total int64) *progressReporter {
+	every := p.Every
+	if every <= 0 {
+		every = 1 << 16
+	}
+	return &progressReporter{fn: p.Func, every: every, total: total}
+}
+
+// update reports done if it is at least every past the last report.
+func (r *progressReporter) update(done int64) {
+	if r.fn != nil && done-r.last >= r.every {
+		r.last = done
+		r.fn(done, r.total)
+	}
+}
+
+// finish makes the last report, with everything done.
+func (r *progressReporter) finish(done int64) {
+	if r.fn != nil {
+		r.fn(done, done)
+	}
+}
+
+// firstProgress returns the Progress given to a function taking an
+// optional one, or the zero Progress when none was.
+func firstProgress(progress []Progress) Progress {
+	if len(progress) == 0 {
+		return Progress{}
+	}
+	return progress[0]
+}
+
+// countingReader counts the bytes read through it in n.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// remainingSize returns how many bytes are left to read from r, or -1
+// when that cannot be told without reading them.
+func remainingSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case interface {
+		io.Seeker
+		Stat() (fs.FileInfo, error)
+	}:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return info.Size() - offset
+	}
+	return -1
+}
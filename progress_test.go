@@ -0,0 +1,159 @@
+package testdemo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type progressCall struct{ Done, Total int64 }
+
+// progressRecorder records the calls made to its Progress.
+type progressRecorder struct {
+	calls []progressCall
+}
+
+func (r *progressRecorder) progress(every int64) Progress {
+	return Progress{Func: func(done, total int64) {
+		r.calls = append(r.calls, progressCall{done, total})
+	}, Every: every}
+}
+
+// requireProgress checks that the calls recorded grew by at least every
+// each, never went back, and ended with both done and total at total.
+func (r *progressRecorder) requireProgress(t *testing.T, every, total int64) {
+	t.Helper()
+	require.NotEmpty(t, r.calls)
+	last := r.calls[len(r.calls)-1]
+	require.Equal(t, progressCall{total, total}, last, "the last call")
+	var prev int64
+	for i, c := range r.calls[:len(r.calls)-1] {
+		require.GreaterOrEqual(t, c.Done-prev, every, "call %d of %v", i, r.calls)
+		require.LessOrEqual(t, c.Done, total, "call %d of %v", i, r.calls)
+		prev = c.Done
+	}
+	require.GreaterOrEqual(t, last.Done, prev)
+}
+
+func TestCheckSortedCtxProgress(t *testing.T) {
+	data := make([]int, 5*ctxCheckInterval+7)
+	for i := range data {
+		data[i] = i
+	}
+	var r progressRecorder
+	require.NoError(t, CheckSortedCtx(context.Background(), data, Order{}, r.progress(2*ctxCheckInterval)))
+	r.requireProgress(t, 2*ctxCheckInterval, int64(len(data)))
+	require.Len(t, r.calls, 3)
+	for _, c := range r.calls {
+		require.Equal(t, int64(len(data)), c.Total)
+	}
+
+	r = progressRecorder{}
+	sorted, err := IsSortedCtx(context.Background(), []int{}, r.progress(0))
+	require.NoError(t, err)
+	require.True(t, sorted)
+	require.Equal(t, []progressCall{{0, 0}}, r.calls)
+
+	// An unsorted slice gets no last call.
+	r = progressRecorder{}
+	data[len(data)-1] = -1
+	sorted, err = IsSortedCtx(context.Background(), data, r.progress(ctxCheckInterval))
+	require.NoError(t, err)
+	require.False(t, sorted)
+	for _, c := range r.calls {
+		require.Less(t, c.Done, int64(len(data)))
+	}
+
+	_, err = IsSortedCtx(context.Background(), data, Progress{})
+	require.NoError(t, err, "a nil Func must be ignored")
+}
+
+// numberLines returns the lines 0 to n-1, with a header if asked.
+func numberLines(n int, header string) string {
+	var sb strings.Builder
+	if header != "" {
+		sb.WriteString(header + "\n")
+	}
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "%08d\n", i)
+	}
+	return sb.String()
+}
+
+func TestFileCheckerProgress(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Header string
+		Check  func(r io.Reader, p Progress) error
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			input := numberLines(1e5, tc.Header)
+			const every = 64 << 10
+
+			var r progressRecorder
+			require.NoError(t, tc.Check(strings.NewReader(input), r.progress(every)))
+			r.requireProgress(t, every, int64(len(input)))
+			require.Greater(t, len(r.calls), 5)
+			for _, c := range r.calls {
+				require.Equal(t, int64(len(input)), c.Total, "the size of a strings.Reader is known")
+			}
+
+			// io.MultiReader hides the size, as a pipe would.
+			r = progressRecorder{}
+			require.NoError(t, tc.Check(io.MultiReader(strings.NewReader(input)), r.progress(every)))
+			r.requireProgress(t, every, int64(len(input)))
+			for _, c := range r.calls[:len(r.calls)-1] {
+				require.Equal(t, int64(-1), c.Total)
+			}
+		})
+	}
+	validate(t, testCase{Name: "CheckReader",
+		Check: func(r io.Reader, p Progress) error {
+			return CheckReader(r, ReaderOptions{Progress: p})
+		},
+	})
+	validate(t, testCase{Name: "CheckLines",
+		Header: "key",
+		Check: func(r io.Reader, p Progress) error {
+			return CheckLines(r, LineOptions{Header: true, Progress: p})
+		},
+	})
+	validate(t, testCase{Name: "CheckCSVColumn",
+		Header: "key",
+		Check: func(r io.Reader, p Progress) error {
+			return CheckCSVColumn(r, CSVOptions{LineOptions: LineOptions{Header: true, Progress: p}, Column: 1})
+		},
+	})
+}
+
+func TestExternalSortProgress(t *testing.T) {
+	dir := t.TempDir()
+	var sb strings.Builder
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&sb, "%d\n", (i*7919)%20000)
+	}
+	in := writeTestFile(t, dir, "in.txt", sb.String())
+	size := int64(sb.Len())
+
+	// 20 runs merge in one pass, 200 in several.
+	for _, memBudget := range []int{1000, 100} {
+		var r progressRecorder
+		_, err := ExternalSort(filepath.Join(dir, "out.txt"), in, memBudget, r.progress(4096))
+		require.NoError(t, err)
+		r.requireProgress(t, 4096, 2*size)
+		var merging bool
+		for _, c := range r.calls {
+			require.Equal(t, 2*size, c.Total)
+			merging = merging || c.Done > size
+		}
+		require.True(t, merging, "the merge must report progress too")
+	}
+}
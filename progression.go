@@ -0,0 +1,47 @@
+package testdemo
+
+// IsArithmeticProgression reports whether consecutive elements of data
+// all differ by the same stride, and returns it. Fewer than two elements
+// are trivially a progression, with a stride of 0; two elements always
+// are, with the stride between them, unless it does not fit in an int64.
+// A stride of 0 means all elements are equal, and a negative one that
+// they strictly decrease.
+func IsArithmeticProgression(data []int) (stride int64, ok bool) {
+	if NearestProgressionViolation(data) >= 0 {
+		return 0, false
+	}
+	if len(data) < 2 {
+		return 0, true
+	}
+	stride, _ = diff64(data[0], data[1])
+	return stride, true
+}
+
+// NearestProgressionViolation returns the first index i at which
+// data[i]-data[i-1] differs from the stride set by data[1]-data[0], or -1
+// when data is an arithmetic progression. It is 1 when that first stride
+// does not fit in an int64.
+func NearestProgressionViolation(data []int) int {
+	if len(data) < 2 {
+		return -1
+	}
+	stride, ok := diff64(data[0], data[1])
+	if !ok {
+		return 1
+	}
+	for i := 2; i < len(data); i++ {
+		if d, ok := diff64(data[i-1], data[i]); !ok || d != stride {
+			return i
+		}
+	}
+	return -1
+}
+
+// diff64 returns b-a, and whether it did not overflow an int64.
+func diff64(a, b int) (int64, bool) {
+	x, y := int64(a), int64(b)
+	d := y - x
+	// Subtraction overflows only when the operands' signs differ and the
+	// result's sign differs from y's.
+	return d, (x < 0) == (y < 0) || (d < 0) == (y < 0)
+}
@@ -0,0 +1,81 @@
+package testdemo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsArithmeticProgression(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Array     []int
+		Stride    int64
+		OK        bool
+		Violation int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			stride, ok := IsArithmeticProgression(tc.Array)
+			require.Equal(t, tc.OK, ok, "ok")
+			require.Equal(t, tc.Stride, stride, "stride")
+			require.Equal(t, tc.Violation, NearestProgressionViolation(tc.Array), "violation")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:     []int{},
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Single",
+		Array:     []int{5},
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Two define the stride",
+		Array:     []int{5, 12},
+		Stride:    7,
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Positive stride",
+		Array:     []int{1, 4, 7, 10},
+		Stride:    3,
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Negative stride",
+		Array:     []int{10, 8, 6, 4, 2, 0, -2},
+		Stride:    -2,
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Stride 0",
+		Array:     []int{4, 4, 4},
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Off by one mid-sequence",
+		Array:     []int{0, 10, 20, 31, 40, 50},
+		Violation: 3,
+	})
+	validate(t, testCase{Name: "Near MinInt64",
+		Array:     []int{math.MinInt + 2, math.MinInt + 1, math.MinInt},
+		Stride:    -1,
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Largest strides",
+		Array:     []int{math.MinInt, -1, math.MaxInt - 1},
+		Stride:    math.MaxInt,
+		OK:        true,
+		Violation: -1,
+	})
+	validate(t, testCase{Name: "Later difference wraps to the stride",
+		Array:     []int{math.MinInt + 1, math.MinInt, math.MaxInt},
+		Violation: 2,
+	})
+}
@@ -0,0 +1,45 @@
+package testdemo
+
+// RadixSortInt64 sorts data in place in non-decreasing order with a
+// least-significant-digit radix sort, a byte per pass, taking O(n) time
+// and one scratch buffer as long as data. Flipping the sign bit of each
+// value makes negative numbers order before positive ones as unsigned
+// keys. Passes over bytes that every value shares are skipped.
+func RadixSortInt64(data []int64) {
+	if len(data) < 2 {
+		return
+	}
+	key := func(v int64) uint64 { return uint64(v) ^ 1<<63 }
+
+	var counts [8][256]int
+	for _, v := range data {
+		k := key(v)
+		for b := range counts {
+			counts[b][byte(k>>(8*b))]++
+		}
+	}
+
+	src, dst := data, make([]int64, len(data))
+	for b := range counts {
+		c := &counts[b]
+		if c[byte(key(data[0])>>(8*b))] == len(data) {
+			continue // every value has the same byte b
+		}
+		// Turn counts into the offset each byte value starts at.
+		offset := 0
+		for i, n := range c {
+			c[i] = offset
+			offset += n
+		}
+		shift := 8 * b
+		for _, v := range src {
+			d := byte(key(v) >> shift)
+			dst[c[d]] = v
+			c[d]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &data[0] {
+		copy(data, src)
+	}
+}
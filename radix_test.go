@@ -0,0 +1,61 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRadixSortInt64(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Array []int64
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			expected := slices.Clone(tc.Array)
+			slices.Sort(expected)
+			RadixSortInt64(tc.Array)
+			require.Equal(t, expected, tc.Array)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int64{},
+	})
+	validate(t, testCase{Name: "Single element",
+		Array: []int64{-1},
+	})
+	validate(t, testCase{Name: "Negative and positive",
+		Array: []int64{3, -1, 0, -300, 256, 255, -256},
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array: []int64{math.MaxInt64, 0, math.MinInt64, -1, math.MinInt64 + 1, math.MaxInt64 - 1},
+	})
+	validate(t, testCase{Name: "All equal",
+		Array: []int64{7, 7, 7},
+	})
+	validate(t, testCase{Name: "Odd number of passes",
+		// Only the lowest byte differs, so the result ends up in the
+		// scratch buffer and has to be copied back.
+		Array: []int64{3, 1, 2},
+	})
+}
+
+func TestRadixSortInt64Random(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 100; i++ {
+		data := make([]int64, rng.Intn(1000))
+		for j := range data {
+			data[j] = int64(rng.Uint64())
+		}
+		expected := slices.Clone(data)
+		slices.Sort(expected)
+		RadixSortInt64(data)
+		require.Equal(t, expected, data)
+	}
+}
@@ -0,0 +1,48 @@
+package testdemo
+
+// IsSortedRange reports whether data[lo:hi] is sorted. It panics if the
+// range is out of bounds, as slicing would.
+func IsSortedRange(data []int, lo, hi int) bool {
+	return IsSorted(data[lo:hi])
+}
+
+// RangeSortedness answers whether ranges of a slice are sorted in O(1)
+// each, after O(n) precomputation, for when there are many queries about
+// the same data. It holds the length of the sorted run starting at each
+// index, so it does not see later changes to the data: after mutating
+// it, call Update to rebuild.
+type RangeSortedness struct {
+	// run[i] is the length of the longest sorted data[i:i+run[i]].
+	run []int
+}
+
+// NewRangeSortedness precomputes the RangeSortedness of data.
+func NewRangeSortedness(data []int) *RangeSortedness {
+	r := &RangeSortedness{}
+	r.Update(data)
+	return r
+}
+
+// Update rebuilds r for data, which may have been mutated since r was
+// built or be a different slice entirely.
+func (r *RangeSortedness) Update(data []int) {
+	if cap(r.run) >= len(data) {
+		r.run = r.run[:len(data)]
+	} else {
+		r.run = make([]int, len(data))
+	}
+	for i := len(data) - 1; i >= 0; i-- {
+		r.run[i] = 1
+		if i+1 < len(data) && data[i] <= data[i+1] {
+			r.run[i] += r.run[i+1]
+		}
+	}
+}
+
+// Query reports whether data[lo:hi] was sorted when r was last built.
+// Empty ranges are sorted. It panics if the range is out of bounds, as
+// slicing would.
+func (r *RangeSortedness) Query(lo, hi int) bool {
+	_ = r.run[lo:hi]
+	return lo == hi || hi-lo <= r.run[lo]
+}
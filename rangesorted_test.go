@@ -0,0 +1,99 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeSortedness(t *testing.T) {
+	data := []int{1, 2, 2, 0, 5, 6, 3}
+	r := NewRangeSortedness(data)
+	type testCase struct {
+		Name     string
+		Lo, Hi   int
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, r.Query(tc.Lo, tc.Hi))
+			require.Equal(t, tc.Expected, IsSortedRange(data, tc.Lo, tc.Hi))
+		})
+	}
+	validate(t, testCase{Name: "Empty at start",
+		Lo:       0,
+		Hi:       0,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Empty at end",
+		Lo:       7,
+		Hi:       7,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Whole first run",
+		Lo:       0,
+		Hi:       3,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "One past the first run",
+		Lo:       0,
+		Hi:       4,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Whole second run",
+		Lo:       3,
+		Hi:       6,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Straddling runs",
+		Lo:       2,
+		Hi:       4,
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Single element",
+		Lo:       6,
+		Hi:       7,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Full range",
+		Lo:       0,
+		Hi:       7,
+		Expected: IsSorted(data),
+	})
+}
+
+func TestRangeSortednessUpdate(t *testing.T) {
+	data := []int{1, 2, 3}
+	r := NewRangeSortedness(data)
+	data[1] = 5
+	require.True(t, r.Query(0, 3), "a stale RangeSortedness answers for the old data")
+	r.Update(data)
+	require.False(t, r.Query(0, 3))
+	r.Update(nil)
+	require.True(t, r.Query(0, 0))
+}
+
+func TestRangeSortednessPanicsOutOfBounds(t *testing.T) {
+	r := NewRangeSortedness([]int{1, 2})
+	require.Panics(t, func() { r.Query(1, 3) })
+	require.Panics(t, func() { r.Query(2, 1) })
+}
+
+func TestRangeSortednessAgainstIsSortedRange(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 200; i++ {
+		data := make([]int, rng.Intn(30))
+		for j := range data {
+			data[j] = rng.Intn(5)
+		}
+		r := NewRangeSortedness(data)
+		for q := 0; q < 50; q++ {
+			lo := rng.Intn(len(data) + 1)
+			hi := lo + rng.Intn(len(data)-lo+1)
+			require.Equal(t, IsSortedRange(data, lo, hi), r.Query(lo, hi), "%v [%d:%d]", data, lo, hi)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package testdemo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Rank returns the number of elements of data less than v. data must be
+// sorted in non-decreasing order; use RankChecked when that is not known.
+func Rank(data []int, v int) int {
+	return LowerBound(data, v)
+}
+
+// RankRange returns the range data[lo:hi] of elements equal to v, which
+// is empty, with lo == hi == Rank(data, v), when v is not in data. data
+// must be sorted in non-decreasing order.
+func RankRange(data []int, v int) (lo, hi int) {
+	return LowerBound(data, v), UpperBound(data, v)
+}
+
+// Select returns the k-th smallest element of data, counting from zero,
+// and an error if k is not an index of data. data must be sorted in
+// non-decreasing order, which makes this data[k]; use SelectChecked when
+// that is not known.
+func Select(data []int, k int) (int, error) {
+	if k < 0 || k >= len(data) {
+		return 0, fmt.Errorf("select: k %d is out of range for %d elements", k, len(data))
+	}
+	return data[k], nil
+}
+
+// RankChecked is Rank for data that may not be sorted: it returns an
+// *UnsortedError when it is not.
+func RankChecked(data []int, v int) (int, error) {
+	if err := checkSorted(context.Background(), data, Order{}); err != nil {
+		return 0, err
+	}
+	return Rank(data, v), nil
+}
+
+// SelectChecked is Select for data that may not be sorted: it returns an
+// *UnsortedError when it is not.
+func SelectChecked(data []int, k int) (int, error) {
+	if err := checkSorted(context.Background(), data, Order{}); err != nil {
+		return 0, err
+	}
+	return Select(data, k)
+}
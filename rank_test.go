@@ -0,0 +1,64 @@
+package testdemo
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankAgainstLinearScan(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]int, rng.Intn(40))
+		for j := range data {
+			data[j] = rng.Intn(8)
+		}
+		sort.Ints(data)
+		v := rng.Intn(10) - 1
+
+		var less, equal int
+		for _, d := range data {
+			if d < v {
+				less++
+			} else if d == v {
+				equal++
+			}
+		}
+		require.Equal(t, less, Rank(data, v), "%v v=%d", data, v)
+		lo, hi := RankRange(data, v)
+		require.Equal(t, less, lo, "%v v=%d", data, v)
+		require.Equal(t, less+equal, hi, "%v v=%d", data, v)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	data := []int{1, 2, 2, 5}
+	for k, want := range data {
+		actual, err := Select(data, k)
+		require.NoError(t, err)
+		require.Equal(t, want, actual)
+	}
+	_, err := Select(data, -1)
+	require.EqualError(t, err, "select: k -1 is out of range for 4 elements")
+	_, err = Select(data, len(data))
+	require.EqualError(t, err, "select: k 4 is out of range for 4 elements")
+	_, err = Select(nil, 0)
+	require.EqualError(t, err, "select: k 0 is out of range for 0 elements")
+}
+
+func TestCheckedRankAndSelect(t *testing.T) {
+	unsorted := []int{1, 3, 2}
+	_, err := RankChecked(unsorted, 2)
+	require.Equal(t, &UnsortedError{Index: 2, Prev: 3, Next: 2, Len: 3}, err)
+	_, err = SelectChecked(unsorted, 0)
+	require.Equal(t, &UnsortedError{Index: 2, Prev: 3, Next: 2, Len: 3}, err)
+
+	rank, err := RankChecked([]int{1, 2, 3}, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, rank)
+	v, err := SelectChecked([]int{1, 2, 3}, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, v)
+}
@@ -0,0 +1,68 @@
+package testdemo
+
+// RankMethod is how Ranks ranks equal values.
+type RankMethod int
+
+const (
+	// RankAverage gives tied values the mean of the ranks they span,
+	// so 10, 20, 20, 30 ranks 1, 2.5, 2.5, 4.
+	RankAverage RankMethod = iota
+	// RankMin gives tied values the lowest rank they span, competition
+	// ranking: 1, 2, 2, 4.
+	RankMin
+	// RankMax gives tied values the highest rank they span: 1, 3, 3, 4.
+	RankMax
+	// RankDense gives tied values the same rank and the next value the
+	// next rank, leaving no gaps: 1, 2, 2, 3.
+	RankDense
+)
+
+// Ranks returns the 1-based rank of each element of data in sorted
+// order, with ties ranked by method. It is computed from Argsort.
+func Ranks(data []int, method RankMethod) []float64 {
+	perm := Argsort(data)
+	ranks := make([]float64, len(data))
+	dense := 0
+	for lo := 0; lo < len(perm); {
+		hi := lo + 1
+		for hi < len(perm) && data[perm[hi]] == data[perm[lo]] {
+			hi++
+		}
+		dense++
+		// The tie spans ranks lo+1 to hi.
+		var rank float64
+		switch method {
+		case RankMin:
+			rank = float64(lo + 1)
+		case RankMax:
+			rank = float64(hi)
+		case RankDense:
+			rank = float64(dense)
+		default:
+			rank = float64(lo+1+hi) / 2
+		}
+		for _, p := range perm[lo:hi] {
+			ranks[p] = rank
+		}
+		lo = hi
+	}
+	return ranks
+}
+
+// IsSortedByRanks reports whether ranks order data the way its values
+// do, as any RankMethod should: equal values have equal ranks, and
+// greater values greater ranks.
+func IsSortedByRanks(data []int, ranks []float64) bool {
+	if len(ranks) != len(data) {
+		return false
+	}
+	perm := Argsort(data)
+	for i := 1; i < len(perm); i++ {
+		prev, next := perm[i-1], perm[i]
+		if data[prev] == data[next] && ranks[prev] != ranks[next] ||
+			data[prev] < data[next] && !(ranks[prev] < ranks[next]) {
+			return false
+		}
+	}
+	return true
+}
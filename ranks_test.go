@@ -0,0 +1,79 @@
+package testdemo
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRanks(t *testing.T) {
+	data := []int{30, 10, 20, 20, 30, 30, 5}
+	type testCase struct {
+		Name     string
+		Method   RankMethod
+		Expected []float64
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := Ranks(data, tc.Method)
+			require.Equal(t, tc.Expected, actual)
+			require.True(t, IsSortedByRanks(data, actual))
+		})
+	}
+	validate(t, testCase{Name: "Average",
+		Method:   RankAverage,
+		Expected: []float64{6, 2, 3.5, 3.5, 6, 6, 1},
+	})
+	validate(t, testCase{Name: "Min",
+		Method:   RankMin,
+		Expected: []float64{5, 2, 3, 3, 5, 5, 1},
+	})
+	validate(t, testCase{Name: "Max",
+		Method:   RankMax,
+		Expected: []float64{7, 2, 4, 4, 7, 7, 1},
+	})
+	validate(t, testCase{Name: "Dense",
+		Method:   RankDense,
+		Expected: []float64{4, 2, 3, 3, 4, 4, 1},
+	})
+}
+
+func TestRanksEmpty(t *testing.T) {
+	require.Equal(t, []float64{}, Ranks(nil, RankDense))
+}
+
+func TestIsSortedByRanks(t *testing.T) {
+	data := []int{3, 1, 2}
+	require.True(t, IsSortedByRanks(data, []float64{3, 1, 2}))
+	require.True(t, IsSortedByRanks(data, []float64{30, 1, 2}))
+	require.False(t, IsSortedByRanks(data, []float64{1, 3, 2}))
+	require.False(t, IsSortedByRanks([]int{1, 1}, []float64{1, 2}))
+	require.False(t, IsSortedByRanks(data, []float64{1, 2}))
+}
+
+func TestRanksOfStrictlyIncreasingInput(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 100; i++ {
+		n := rng.Intn(30)
+		seen := map[int]bool{}
+		var data []int
+		for len(data) < n {
+			if v := rng.Intn(1000); !seen[v] {
+				seen[v] = true
+				data = append(data, v)
+			}
+		}
+		sort.Ints(data)
+		expected := make([]float64, n)
+		for j := range expected {
+			expected[j] = float64(j + 1)
+		}
+		for _, method := range []RankMethod{RankAverage, RankMin, RankMax, RankDense} {
+			require.Equal(t, expected, Ranks(data, method), "method %d", method)
+		}
+	}
+}
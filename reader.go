@@ -0,0 +1,128 @@
+package testdemo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ReaderOptions configures CheckReader.
+type ReaderOptions struct {
+	Order
+	// Float parses the values as float64 instead of int64.
+	Float bool
+	// Progress is reported to in bytes read.
+	Progress Progress
+}
+
+// CheckReader reads whitespace-separated numbers from r and checks that
+// they are sorted, one value at a time, so the input never has to fit in
+// memory. It returns nil when they are, an *UnsortedError for the first
+// pair out of order, or an error naming the line of a value that does
+// not parse.
+func CheckReader(r io.Reader, opts ReaderOptions) (err error) {
+	read := 0
+	defer func() { reportCheck(read, err) }()
+	report := opts.Progress.reporter(remainingSize(r))
+	counted := &countingReader{r: r}
+	tok := newTokenizer(counted)
+	var (
+		prevInt   int64
+		prevFloat float64
+	)
+	for index := 0; tok.Scan(); index++ {
+		read++
+		report.update(counted.n)
+		text := tok.Text()
+		if opts.Float {
+			v, err := strconv.ParseFloat(text, 64)
+			if err == nil && math.IsNaN(v) {
+				err = errors.New("NaN cannot be ordered")
+			}
+			if err != nil {
+				return fmt.Errorf("line %d: invalid number %q: %w", tok.line, text, err)
+			}
+			if index > 0 && !inOrder(prevFloat, v, opts.Descending, opts.Strict) {
+				return &UnsortedError{Index: index, Prev: prevFloat, Next: v, Line: tok.line, Source: opts.Source}
+			}
+			prevFloat = v
+			continue
+		}
+		v, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid number %q: %w", tok.line, text, err)
+		}
+		if index > 0 && !inOrder(prevInt, v, opts.Descending, opts.Strict) {
+			return &UnsortedError{Index: index, Prev: prevInt, Next: v, Line: tok.line, Source: opts.Source}
+		}
+		prevInt = v
+	}
+	if err := tok.Err(); err != nil {
+		return err
+	}
+	report.finish(counted.n)
+	return nil
+}
+
+// IsSortedReader reports whether the whitespace-separated integers read
+// from r are in non-decreasing order. The error is only non-nil when r
+// cannot be read or holds something other than integers.
+func IsSortedReader(r io.Reader) (bool, error) {
+	return sortedResult(CheckReader(r, ReaderOptions{}))
+}
+
+// inOrder reports whether next may follow prev.
+func inOrder[T int64 | float64 | string](prev, next T, descending, strict bool) bool {
+	if descending {
+		prev, next = next, prev
+	}
+	if strict {
+		return prev < next
+	}
+	return prev <= next
+}
+
+// tokenizer splits its input into whitespace-separated tokens, keeping
+// track of the line the current token is on.
+type tokenizer struct {
+	*bufio.Scanner
+	line int
+}
+
+func newTokenizer(r io.Reader) *tokenizer {
+	tok := &tokenizer{Scanner: bufio.NewScanner(r), line: 1}
+	tok.Split(tok.split)
+	return tok
+}
+
+func (tok *tokenizer) split(data []byte, atEOF bool) (int, []byte, error) {
+	skip := 0
+	for skip < len(data) && isSpace(data[skip]) {
+		if data[skip] == '\n' {
+			tok.line++
+		}
+		skip++
+	}
+	for i := skip; i < len(data); i++ {
+		if isSpace(data[i]) {
+			return i, data[skip:i], nil
+		}
+	}
+	if atEOF && skip < len(data) {
+		return len(data), data[skip:], nil
+	}
+	// Only whitespace so far: drop it, and wait for more data unless
+	// there is none.
+	return skip, nil, nil
+}
+
+func isSpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package testdemo
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReader(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Input    string
+		Options  ReaderOptions
+		Expected error
+		// ExpectedErr is matched against errors other than UnsortedError.
+		ExpectedErr string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			// Reading a byte at a time makes tokens and newlines straddle
+			// buffer boundaries, reading everything at once hits EOF with
+			// data left to split.
+			for _, r := range []io.Reader{strings.NewReader(tc.Input), iotest.OneByteReader(strings.NewReader(tc.Input))} {
+				actual := CheckReader(r, tc.Options)
+				if tc.ExpectedErr != "" {
+					require.EqualError(t, actual, tc.ExpectedErr)
+					continue
+				}
+				require.Equal(t, tc.Expected, actual)
+			}
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Input: "",
+	})
+	validate(t, testCase{Name: "Only whitespace",
+		Input: " \n\t\n",
+	})
+	validate(t, testCase{Name: "Sorted across lines",
+		Input: "-9223372036854775808 0\n0 3\r\n17\n",
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Input:    "1 2\n\n3 2\n",
+		Expected: &UnsortedError{Index: 3, Prev: int64(3), Next: int64(2), Line: 3},
+	})
+	validate(t, testCase{Name: "Strict rejects duplicates",
+		Input:    "1\n1\n",
+		Options:  ReaderOptions{Order: Order{Strict: true}},
+		Expected: &UnsortedError{Index: 1, Prev: int64(1), Next: int64(1), Line: 2},
+	})
+	validate(t, testCase{Name: "Descending",
+		Input:   "3 3 2 -1",
+		Options: ReaderOptions{Order: Order{Descending: true}},
+	})
+	validate(t, testCase{Name: "Descending unsorted",
+		Input:    "3 2 5",
+		Options:  ReaderOptions{Order: Order{Descending: true}},
+		Expected: &UnsortedError{Index: 2, Prev: int64(2), Next: int64(5), Line: 1},
+	})
+	validate(t, testCase{Name: "Floats",
+		Input:   "-Inf -1.5 1e3 1e3 +Inf",
+		Options: ReaderOptions{Float: true},
+	})
+	validate(t, testCase{Name: "Floats unsorted",
+		Input:    "0.5\n0.25",
+		Options:  ReaderOptions{Float: true},
+		Expected: &UnsortedError{Index: 1, Prev: 0.5, Next: 0.25, Line: 2},
+	})
+	validate(t, testCase{Name: "Float in integer mode",
+		Input:       "1\n1.5",
+		ExpectedErr: `line 2: invalid number "1.5": strconv.ParseInt: parsing "1.5": invalid syntax`,
+	})
+	validate(t, testCase{Name: "NaN",
+		Input:       "1 NaN",
+		Options:     ReaderOptions{Float: true},
+		ExpectedErr: `line 1: invalid number "NaN": NaN cannot be ordered`,
+	})
+}
+
+func TestIsSortedReader(t *testing.T) {
+	sorted, err := IsSortedReader(strings.NewReader("1 2 3"))
+	require.NoError(t, err)
+	require.True(t, sorted)
+
+	sorted, err = IsSortedReader(strings.NewReader("2 1"))
+	require.NoError(t, err)
+	require.False(t, sorted)
+
+	_, err = IsSortedReader(strings.NewReader("x"))
+	require.Error(t, err)
+}
+
+func TestUnsortedErrorMessage(t *testing.T) {
+	err := &UnsortedError{Index: 3, Prev: int64(3), Next: int64(2)}
+	require.EqualError(t, err, "index 3: 3 followed by 2")
+	err.Line = 7
+	require.EqualError(t, err, "line 7, index 3: 3 followed by 2")
+	err.Source = "data.txt"
+	require.EqualError(t, err, "data.txt: line 7, index 3: 3 followed by 2")
+}
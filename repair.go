@@ -0,0 +1,92 @@
+package testdemo
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SortableByOneSwap reports whether swapping data[i] and data[j] would
+// sort data in non-decreasing order, and returns i < j. Sorted data needs
+// no swap and returns i == j == 0. It takes O(n): the swap has to take
+// the first element of the first descent as far left as elements equal
+// to it go, as in 1, 3, 3, 2, and the last element of the last descent
+// as far right as elements equal to it go, as in 1, 3, 2, 2, so only
+// that one swap needs trying.
+func SortableByOneSwap(data []int) (i, j int, ok bool) {
+	first, last := -1, -1
+	for k := 1; k < len(data); k++ {
+		if data[k] < data[k-1] {
+			if first < 0 {
+				first = k - 1
+			}
+			last = k
+		}
+	}
+	if first < 0 {
+		return 0, 0, true
+	}
+	i, j = first, last
+	for i > 0 && data[i-1] == data[i] {
+		i--
+	}
+	for j+1 < len(data) && data[j+1] == data[j] {
+		j++
+	}
+	if !sortedAfterSwap(data, i, j) {
+		return 0, 0, false
+	}
+	return i, j, true
+}
+
+// sortedAfterSwap reports whether data would be sorted with data[i] and
+// data[j], i < j, swapped, without swapping them: data may be shared with
+// goroutines reading it. Each swapped element has to fit between the
+// neighbours of its new position, and the runs around them be sorted.
+func sortedAfterSwap(data []int, i, j int) bool {
+	if i > 0 && data[i-1] > data[j] {
+		return false
+	}
+	if j+1 < len(data) && data[i] > data[j+1] {
+		return false
+	}
+	if j == i+1 {
+		if data[j] > data[i] {
+			return false
+		}
+	} else if data[j] > data[i+1] || data[j-1] > data[i] {
+		return false
+	}
+	return IsSorted(data[:i]) && IsSorted(data[i+1:j]) && IsSorted(data[j+1:])
+}
+
+// RepairHint suggests how to fix the violation err found in data, for
+// messages about short lists people edit by hand: "swap indices i and j"
+// when one swap sorts data, "move element at i to position k" when
+// moving the element on either side of the violation does, k being where
+// it ends up, and "re-sort required" otherwise. It returns "" for a nil
+// err.
+func RepairHint(data []int, err *UnsortedError) string {
+	if err == nil {
+		return ""
+	}
+	if i, j, ok := SortableByOneSwap(data); ok && i != j {
+		return fmt.Sprintf("swap indices %d and %d", i, j)
+	}
+	if err.Index > 0 && err.Index < len(data) {
+		// Either data[Index] is too small and belongs further left, or
+		// data[Index-1] is too large and belongs further right.
+		for _, from := range []int{err.Index, err.Index - 1} {
+			rest := slices.Delete(slices.Clone(data), from, from+1)
+			if !IsSorted(rest) {
+				continue
+			}
+			// Land as close to where it was as equal elements allow.
+			to := UpperBound(rest, data[from])
+			if from < err.Index {
+				to = LowerBound(rest, data[from])
+			}
+			return fmt.Sprintf("move element at %d to position %d", from, to)
+		}
+	}
+	return "re-sort required"
+}
@@ -0,0 +1,163 @@
+package testdemo
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortableByOneSwap(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Array []int
+		I, J  int
+		OK    bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			before := slices.Clone(tc.Array)
+			i, j, ok := SortableByOneSwap(tc.Array)
+			require.Equal(t, tc.OK, ok, "ok")
+			require.Equal(t, tc.I, i, "i")
+			require.Equal(t, tc.J, j, "j")
+			require.Equal(t, before, tc.Array, "data must be left as it was")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+		OK:    true,
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array: []int{1, 2, 2},
+		OK:    true,
+	})
+	validate(t, testCase{Name: "Adjacent",
+		Array: []int{1, 3, 2, 4},
+		I:     1,
+		J:     2,
+		OK:    true,
+	})
+	validate(t, testCase{Name: "Distant",
+		Array: []int{1, 5, 3, 4, 2, 6},
+		I:     1,
+		J:     4,
+		OK:    true,
+	})
+	validate(t, testCase{Name: "Duplicates of the larger",
+		Array: []int{1, 3, 3, 2},
+		I:     1,
+		J:     3,
+		OK:    true,
+	})
+	validate(t, testCase{Name: "Duplicates of the smaller",
+		Array: []int{1, 3, 2, 2},
+		I:     1,
+		J:     3,
+		OK:    true,
+	})
+	validate(t, testCase{Name: "Rotation",
+		Array: []int{2, 3, 1},
+	})
+}
+
+func TestSortableByOneSwapMatchesBruteForce(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for n := 0; n < 1000; n++ {
+		data := make([]int, rng.Intn(8))
+		for k := range data {
+			data[k] = rng.Intn(4)
+		}
+		want := IsSorted(data)
+		for i := 0; i < len(data) && !want; i++ {
+			for j := i + 1; j < len(data) && !want; j++ {
+				data[i], data[j] = data[j], data[i]
+				want = IsSorted(data)
+				data[i], data[j] = data[j], data[i]
+			}
+		}
+		i, j, ok := SortableByOneSwap(data)
+		require.Equal(t, want, ok, "%v", data)
+		if ok && len(data) > 0 {
+			swapped := slices.Clone(data)
+			swapped[i], swapped[j] = swapped[j], swapped[i]
+			require.True(t, IsSorted(swapped), "%v swapping %d and %d", data, i, j)
+		}
+	}
+}
+
+func TestSortableByOneSwapDoesNotWrite(t *testing.T) {
+	// data is read here while SortableByOneSwap and RepairHint look at
+	// it, which is only safe, and race-free under -race, if they never
+	// write to it, not even to swap elements back.
+	data := []int{1, 5, 3, 4, 2, 6}
+	want := slices.Clone(data)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				SortableByOneSwap(data)
+				RepairHint(data, &UnsortedError{Index: 2, Prev: 5, Next: 3})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if !slices.Equal(want, data) {
+					t.Errorf("data changed to %v", data)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, want, data)
+}
+
+func TestRepairHint(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Array []int
+		Hint  string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			var unsorted *UnsortedError
+			if err := EnsureSorted(tc.Array); err != nil {
+				unsorted = err.(*UnsortedError)
+			}
+			require.Equal(t, tc.Hint, RepairHint(tc.Array, unsorted))
+		})
+	}
+	validate(t, testCase{Name: "Sorted",
+		Array: []int{1, 2, 3},
+	})
+	validate(t, testCase{Name: "Transposition",
+		Array: []int{10, 30, 20, 40},
+		Hint:  "swap indices 1 and 2",
+	})
+	validate(t, testCase{Name: "Misplaced small element",
+		Array: []int{10, 20, 30, 40, 5},
+		Hint:  "move element at 4 to position 0",
+	})
+	validate(t, testCase{Name: "Misplaced large element",
+		Array: []int{10, 50, 20, 30, 40},
+		Hint:  "move element at 1 to position 4",
+	})
+	validate(t, testCase{Name: "Misplaced among duplicates",
+		Array: []int{1, 2, 2, 3, 4, 2},
+		Hint:  "move element at 5 to position 3",
+	})
+	validate(t, testCase{Name: "Shuffled",
+		Array: []int{5, 1, 4, 2, 3, 0},
+		Hint:  "re-sort required",
+	})
+}
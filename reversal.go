@@ -0,0 +1,37 @@
+package testdemo
+
+// SortableByOneReversal reports whether reversing the single window
+// data[lo:hi] would sort data in non-decreasing order, and returns that
+// window. Sorted data needs no reversal and returns an empty window. It
+// takes O(n): the window has to start at the first descent, widened left
+// over elements equal to it, and run as far right as data keeps not
+// increasing; then only its edges and what follows it need checking.
+func SortableByOneReversal(data []int) (lo, hi int, ok bool) {
+	lo = 0
+	for lo+1 < len(data) && data[lo] <= data[lo+1] {
+		lo++
+	}
+	if lo+1 >= len(data) {
+		return 0, 0, true
+	}
+	hi = lo + 1
+	for hi < len(data) && data[hi] <= data[hi-1] {
+		hi++
+	}
+	// Equal elements right before the descent belong at the end of the
+	// reversed window, as in 1, 3, 3, 2.
+	for lo > 0 && data[lo-1] == data[lo] {
+		lo--
+	}
+	// Reversed, the window starts with data[hi-1] and ends with data[lo].
+	if lo > 0 && data[lo-1] > data[hi-1] {
+		return 0, 0, false
+	}
+	if hi < len(data) && data[lo] > data[hi] {
+		return 0, 0, false
+	}
+	if !IsSorted(data[hi:]) {
+		return 0, 0, false
+	}
+	return lo, hi, true
+}
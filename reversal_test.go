@@ -0,0 +1,119 @@
+package testdemo
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortableByOneReversal(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Array  []int
+		Lo, Hi int
+		Ok     bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			lo, hi, ok := SortableByOneReversal(tc.Array)
+			require.Equal(t, tc.Ok, ok, "ok")
+			require.Equal(t, tc.Lo, lo, "lo")
+			require.Equal(t, tc.Hi, hi, "hi")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+		Ok:    true,
+	})
+	validate(t, testCase{Name: "Sorted",
+		Array: []int{1, 2, 2, 3},
+		Ok:    true,
+	})
+	validate(t, testCase{Name: "Reversed",
+		Array: []int{5, 4, 3, 3, 1},
+		Lo:    0,
+		Hi:    5,
+		Ok:    true,
+	})
+	validate(t, testCase{Name: "Middle window",
+		Array: []int{1, 5, 4, 3, 6},
+		Lo:    1,
+		Hi:    4,
+		Ok:    true,
+	})
+	validate(t, testCase{Name: "Duplicates at the left edge",
+		Array: []int{1, 3, 3, 2, 3},
+		Lo:    1,
+		Hi:    4,
+		Ok:    true,
+	})
+	validate(t, testCase{Name: "Duplicates at the right edge",
+		Array: []int{1, 4, 2, 2, 4},
+		Lo:    1,
+		Hi:    4,
+		Ok:    true,
+	})
+	validate(t, testCase{Name: "Left edge too large",
+		Array: []int{3, 5, 4, 2},
+	})
+	validate(t, testCase{Name: "Window at the end",
+		Array: []int{1, 5, 4, 3},
+		Lo:    1,
+		Hi:    4,
+		Ok:    true,
+	})
+	validate(t, testCase{Name: "Right edge too large",
+		Array: []int{1, 5, 4, 2, 3},
+	})
+	validate(t, testCase{Name: "Following element too small",
+		Array: []int{1, 5, 4, 3, 2, 6, 0},
+	})
+	validate(t, testCase{Name: "Two windows",
+		Array: []int{2, 1, 3, 5, 4},
+	})
+}
+
+// sortableByReversalBrute tries every window.
+func sortableByReversalBrute(data []int) bool {
+	for lo := 0; lo <= len(data); lo++ {
+		for hi := lo; hi <= len(data); hi++ {
+			c := slices.Clone(data)
+			slices.Reverse(c[lo:hi])
+			if IsSorted(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestSortableByOneReversalProperties(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 2000; i++ {
+		data := make([]int, rng.Intn(9))
+		for j := range data {
+			data[j] = rng.Intn(4)
+		}
+		if rng.Intn(2) == 0 {
+			// Most random slices need more than one reversal, so make
+			// half of them need at most one.
+			sort.Ints(data)
+			lo := rng.Intn(len(data) + 1)
+			hi := lo + rng.Intn(len(data)-lo+1)
+			slices.Reverse(data[lo:hi])
+		}
+
+		lo, hi, ok := SortableByOneReversal(data)
+		require.Equal(t, sortableByReversalBrute(data), ok, "%v", data)
+		if ok {
+			c := slices.Clone(data)
+			slices.Reverse(c[lo:hi])
+			require.True(t, IsSorted(c), "%v reversed at [%d:%d]", data, lo, hi)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package testdemo
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// LowerBound returns the index of the first element of data not less than
+// target, or len(data) if there is none. data must be sorted in
+// non-decreasing order; the result is meaningless otherwise.
+func LowerBound(data []int, target int) int {
+	lo, hi := 0, len(data)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if data[mid] < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound returns the index of the first element of data greater than
+// target, or len(data) if there is none. data must be sorted in
+// non-decreasing order; the result is meaningless otherwise.
+func UpperBound(data []int, target int) int {
+	lo, hi := 0, len(data)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if data[mid] <= target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// GallopSearch returns LowerBound(data, target), starting the search at
+// hint instead of the middle. It gallops away from hint in steps that
+// double until the target is bracketed, then binary-searches the bracket,
+// so it takes O(log d) comparisons when the answer is d positions from
+// hint. That beats LowerBound when answers cluster near hint, as they do
+// in merge joins. A bad hint costs at most about twice LowerBound, and a
+// hint outside [0, len(data)] is clamped.
+func GallopSearch(data []int, target int, hint int) int {
+	n := len(data)
+	if hint < 0 {
+		hint = 0
+	}
+	if hint > n {
+		hint = n
+	}
+	// Narrow the answer down to data[lo:hi+1], where hi is either n or
+	// an index known to hold an element not less than target.
+	var lo, hi int
+	if hint < n && data[hint] < target {
+		prev := hint
+		for step := 1; ; step *= 2 {
+			next := hint + step
+			if next >= n {
+				lo, hi = prev+1, n
+				break
+			}
+			if data[next] >= target {
+				lo, hi = prev+1, next
+				break
+			}
+			prev = next
+		}
+	} else {
+		prev := hint
+		for step := 1; ; step *= 2 {
+			next := hint - step
+			if next < 0 {
+				lo, hi = 0, prev
+				break
+			}
+			if data[next] < target {
+				lo, hi = next+1, prev
+				break
+			}
+			prev = next
+		}
+	}
+	return lo + LowerBound(data[lo:hi], target)
+}
+
+// Search reports whether target is in data, along with LowerBound(data,
+// target): the index of its first occurrence when it is, and where it
+// would be inserted when it is not. data must be sorted in non-decreasing
+// order.
+func Search(data []int, target int) (int, bool) {
+	i := LowerBound(data, target)
+	return i, i < len(data) && data[i] == target
+}
+
+// InterpolationSearch is Search for data whose values are spread about
+// evenly: rather than halving the range, it probes where target would be
+// if the values between the ends of the range were uniform. That takes
+// O(log log n) probes on uniform data instead of O(log n). The probe is
+// computed with 128-bit arithmetic, so no values overflow it. Skewed data
+// can make probes crawl, so after about 2·log₂ n of them it falls back to
+// binary search for the rest of the range.
+func InterpolationSearch(data []int, target int) (int, bool) {
+	// The answer is in [lo, hi]; data[hi] is not less than target unless
+	// hi is len(data).
+	lo, hi := 0, len(data)
+	for probes := 2 * bits.Len(uint(len(data))); lo < hi; probes-- {
+		first, last := data[lo], data[hi-1]
+		if target <= first {
+			break
+		}
+		if target > last {
+			lo = hi
+			break
+		}
+		if probes == 0 {
+			lo += LowerBound(data[lo:hi], target)
+			break
+		}
+		// first < target <= last, so both differences are positive and
+		// (target-first)/(last-first) is at most one: the probe stays
+		// within [lo, hi-1], and the quotient fits in 64 bits.
+		num := uint64(target) - uint64(first)
+		den := uint64(last) - uint64(first)
+		prodHi, prodLo := bits.Mul64(num, uint64(hi-1-lo))
+		offset, _ := bits.Div64(prodHi, prodLo, den)
+		mid := lo + int(offset)
+		if data[mid] < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(data) && data[lo] == target
+}
+
+// AscendRange returns an iterator over the elements of data within
+// [lo, hi], in order. data must be sorted in non-decreasing order. The
+// first element is found by binary search, and iteration stops at the
+// first element above hi, so a range takes O(log n) plus its length.
+func AscendRange(data []int, lo, hi int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, v := range data[LowerBound(data, lo):] {
+			if v > hi || !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBounds(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Array  []int
+		Target int
+		Lower  int
+		Upper  int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Lower, LowerBound(tc.Array, tc.Target), "LowerBound")
+			require.Equal(t, tc.Upper, UpperBound(tc.Array, tc.Target), "UpperBound")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+	})
+	validate(t, testCase{Name: "Before all",
+		Array:  []int{1, 2, 3},
+		Target: 0,
+		Lower:  0,
+		Upper:  0,
+	})
+	validate(t, testCase{Name: "After all",
+		Array:  []int{1, 2, 3},
+		Target: 4,
+		Lower:  3,
+		Upper:  3,
+	})
+	validate(t, testCase{Name: "Duplicates",
+		Array:  []int{1, 2, 2, 2, 3},
+		Target: 2,
+		Lower:  1,
+		Upper:  4,
+	})
+	validate(t, testCase{Name: "Missing",
+		Array:  []int{1, 3},
+		Target: 2,
+		Lower:  1,
+		Upper:  1,
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array:  []int{math.MinInt, math.MaxInt},
+		Target: math.MaxInt,
+		Lower:  1,
+		Upper:  2,
+	})
+}
+
+func TestGallopSearchAgainstLowerBound(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 2000; i++ {
+		data := make([]int, rng.Intn(70))
+		for j := range data {
+			data[j] = rng.Intn(30)
+		}
+		sort.Ints(data)
+		target := rng.Intn(34) - 2
+		hint := rng.Intn(len(data)+20) - 10
+
+		require.Equal(t, LowerBound(data, target), GallopSearch(data, target, hint),
+			"%v target=%d hint=%d", data, target, hint)
+	}
+}
+
+func TestInterpolationSearch(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Array  []int
+		Target int
+		Index  int
+		Found  bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			index, found := InterpolationSearch(tc.Array, tc.Target)
+			require.Equal(t, tc.Found, found)
+			require.Equal(t, tc.Index, index)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+	})
+	validate(t, testCase{Name: "All equal",
+		Array:  []int{4, 4, 4, 4},
+		Target: 4,
+		Index:  0,
+		Found:  true,
+	})
+	validate(t, testCase{Name: "Uniform",
+		Array:  []int{0, 10, 20, 30, 40, 50},
+		Target: 30,
+		Index:  3,
+		Found:  true,
+	})
+	validate(t, testCase{Name: "Missing",
+		Array:  []int{0, 10, 20, 30, 40, 50},
+		Target: 31,
+		Index:  4,
+	})
+	validate(t, testCase{Name: "Duplicates",
+		Array:  []int{1, 2, 2, 2, 9},
+		Target: 2,
+		Index:  1,
+		Found:  true,
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array:  []int{math.MinInt, -1, math.MaxInt},
+		Target: math.MaxInt,
+		Index:  2,
+		Found:  true,
+	})
+	validate(t, testCase{Name: "Skewed",
+		Array:  []int{1, 2, 3, 4, 5, 6, 7, 8, math.MaxInt},
+		Target: 8,
+		Index:  7,
+		Found:  true,
+	})
+}
+
+func TestAscendRange(t *testing.T) {
+	data := []int{1, 3, 3, 5, 8, 13}
+	type testCase struct {
+		Name     string
+		Lo, Hi   int
+		Expected []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, slices.Collect(AscendRange(data, tc.Lo, tc.Hi)))
+		})
+	}
+	validate(t, testCase{Name: "Inner range",
+		Lo:       3,
+		Hi:       8,
+		Expected: []int{3, 3, 5, 8},
+	})
+	validate(t, testCase{Name: "Bounds between elements",
+		Lo:       2,
+		Hi:       6,
+		Expected: []int{3, 3, 5},
+	})
+	validate(t, testCase{Name: "Everything",
+		Lo:       -100,
+		Hi:       100,
+		Expected: data,
+	})
+	validate(t, testCase{Name: "Empty between elements",
+		Lo: 9,
+		Hi: 12,
+	})
+	validate(t, testCase{Name: "Inverted",
+		Lo: 8,
+		Hi: 3,
+	})
+	validate(t, testCase{Name: "Below the data",
+		Lo: -10,
+		Hi: 0,
+	})
+	validate(t, testCase{Name: "Above the data",
+		Lo: 14,
+		Hi: 20,
+	})
+
+	require.Empty(t, slices.Collect(AscendRange(nil, 0, 10)))
+	var seen []int
+	for v := range AscendRange(data, 0, 100) {
+		seen = append(seen, v)
+		if len(seen) == 2 {
+			break
+		}
+	}
+	require.Equal(t, []int{1, 3}, seen, "break must stop the iteration")
+}
@@ -0,0 +1,143 @@
+package testdemo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// SelectKth returns the k-th smallest element of data, counting from 0,
+// without sorting it: data may be in any order and is not modified. It
+// is introselect, taking O(n) even on inputs chosen to defeat its pivots.
+// It returns an error when k is not an index of data.
+func SelectKth(data []int, k int) (int, error) {
+	if k < 0 || k >= len(data) {
+		return 0, fmt.Errorf("select: k %d is out of range for %d elements", k, len(data))
+	}
+	work := slices.Clone(data)
+	introselect(work, k)
+	return work[k], nil
+}
+
+// Median returns the median of data, which may be in any order and is
+// not modified. For an even number of elements it is the midpoint of the
+// two middle ones, as MedianSorted gives for the same elements sorted.
+// It takes O(n) time and returns an error for empty data.
+func Median(data []int) (float64, error) {
+	if len(data) == 0 {
+		return 0, errors.New("median of an empty slice")
+	}
+	work := slices.Clone(data)
+	k := len(work) / 2
+	introselect(work, k)
+	hi := float64(work[k])
+	if len(work)%2 == 1 {
+		return hi, nil
+	}
+	// introselect left the k elements below work[k] in work[:k], so the
+	// lower middle element is the largest of them.
+	lo := float64(slices.Max(work[:k]))
+	// Interpolating in float64, as PercentileSorted does, cannot overflow
+	// the way (a+b)/2 on ints can.
+	return lo + 0.5*(hi-lo), nil
+}
+
+// MedianOfMedians returns an element of data with at least 3/10 of the
+// elements no greater than it and at least 3/10 no less, a pivot that
+// keeps partition-based algorithms linear however data is ordered. It
+// takes O(n) time, does not modify data, and returns an error for empty
+// data.
+func MedianOfMedians(data []int) (int, error) {
+	if len(data) == 0 {
+		return 0, errors.New("median of medians of an empty slice")
+	}
+	pivot, _ := medianOfMedians(slices.Clone(data))
+	return pivot, nil
+}
+
+// introselect reorders data so that data[k] is the element sorting would
+// put there, with none greater before it and none less after it. It
+// returns how many elements its partitions examined, the measure of its
+// work that tests bound.
+//
+// It is quickselect with median-of-three pivots and a three-way
+// partition, so runs of equal elements end it early. Pivots that keep
+// splitting off only a few elements could make that quadratic, so once
+// partitioning has examined 4n elements, well past the 2-3n lucky pivots
+// need, it picks the rest with medianOfMedians, which always discards at
+// least 30% of the range.
+func introselect(data []int, k int) (work int) {
+	lo, hi := 0, len(data)
+	budget := 4 * len(data)
+	for hi-lo > 1 {
+		var pivot int
+		if work < budget {
+			pivot = medianOfThree(data[lo], data[lo+(hi-lo)/2], data[hi-1])
+		} else {
+			var w int
+			pivot, w = medianOfMedians(data[lo:hi])
+			work += w
+		}
+		// Partition data[lo:hi] into less than, equal to, and greater than
+		// pivot: data[lo:lt], data[lt:gt], and data[gt:hi].
+		lt, i, gt := lo, lo, hi
+		for i < gt {
+			switch {
+			case data[i] < pivot:
+				data[lt], data[i] = data[i], data[lt]
+				lt++
+				i++
+			case data[i] > pivot:
+				gt--
+				data[gt], data[i] = data[i], data[gt]
+			default:
+				i++
+			}
+		}
+		work += hi - lo
+		switch {
+		case k < lt:
+			hi = lt
+		case k >= gt:
+			lo = gt
+		default:
+			return work
+		}
+	}
+	return work
+}
+
+// medianOfMedians returns a pivot for data with at least 3/10 of its
+// elements on either side of it, and the work, as introselect counts it,
+// that took. It sorts each group of five, gathers the groups' medians at
+// the front of data, and selects their median. data is reordered.
+func medianOfMedians(data []int) (pivot, work int) {
+	if len(data) <= 5 {
+		InsertionSort(data)
+		return data[(len(data)-1)/2], len(data)
+	}
+	groups := 0
+	for start := 0; start < len(data); start += 5 {
+		group := data[start:min(start+5, len(data))]
+		InsertionSort(group)
+		work += len(group)
+		m := (len(group) - 1) / 2
+		data[groups], group[m] = group[m], data[groups]
+		groups++
+	}
+	work += introselect(data[:groups], groups/2)
+	return data[groups/2], work
+}
+
+func medianOfThree(a, b, c int) int {
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b = c
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,245 @@
+package testdemo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectKth(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []int
+		K        int
+		Expected int
+		Err      string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			before := slices.Clone(tc.Data)
+			got, err := SelectKth(tc.Data, tc.K)
+			require.Equal(t, before, tc.Data, "data was modified")
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, got)
+		})
+	}
+	validate(t, testCase{Name: "Single",
+		Data:     []int{7},
+		K:        0,
+		Expected: 7,
+	})
+	validate(t, testCase{Name: "Smallest",
+		Data:     []int{5, 1, 9, 3, 7},
+		K:        0,
+		Expected: 1,
+	})
+	validate(t, testCase{Name: "Largest",
+		Data:     []int{5, 1, 9, 3, 7},
+		K:        4,
+		Expected: 9,
+	})
+	validate(t, testCase{Name: "Middle",
+		Data:     []int{5, 1, 9, 3, 7},
+		K:        2,
+		Expected: 5,
+	})
+	validate(t, testCase{Name: "Duplicates",
+		Data:     []int{4, 9, 4, 1, 4, 4, 2},
+		K:        4,
+		Expected: 4,
+	})
+	validate(t, testCase{Name: "All equal",
+		Data:     []int{3, 3, 3, 3},
+		K:        2,
+		Expected: 3,
+	})
+	validate(t, testCase{Name: "Negative k",
+		Data: []int{1, 2},
+		K:    -1,
+		Err:  "select: k -1 is out of range for 2 elements",
+	})
+	validate(t, testCase{Name: "k past the end",
+		Data: []int{1, 2},
+		K:    2,
+		Err:  "select: k 2 is out of range for 2 elements",
+	})
+	validate(t, testCase{Name: "Empty",
+		Data: []int{},
+		K:    0,
+		Err:  "select: k 0 is out of range for 0 elements",
+	})
+}
+
+func TestSelectKthMatchesSort(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for range 500 {
+		data := make([]int, 1+rng.Intn(200))
+		for i := range data {
+			data[i] = rng.Intn(50)
+		}
+		sorted := slices.Clone(data)
+		slices.Sort(sorted)
+		for _, k := range []int{0, len(data) / 2, len(data) - 1, rng.Intn(len(data))} {
+			got, err := SelectKth(data, k)
+			require.NoError(t, err)
+			require.Equal(t, sorted[k], got, "k=%d data=%v", k, data)
+		}
+	}
+}
+
+// TestIntroselectLinearWork bounds the work introselect does on inputs
+// that push quickselect towards its quadratic worst case. The ceiling is
+// a generous multiple of n; quadratic behaviour would exceed it by orders
+// of magnitude.
+func TestIntroselectLinearWork(t *testing.T) {
+	const n = 100_000
+	ascending := make([]int, n)
+	for i := range ascending {
+		ascending[i] = i
+	}
+	inputs := map[string]func() []int{
+		"ascending": func() []int { return slices.Clone(ascending) },
+		"descending": func() []int {
+			data := slices.Clone(ascending)
+			slices.Reverse(data)
+			return data
+		},
+		"organ pipe": func() []int {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = min(i, n-1-i)
+			}
+			return data
+		},
+		"sawtooth": func() []int {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = i % 64
+			}
+			return data
+		},
+		"all equal": func() []int { return make([]int, n) },
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			for _, k := range []int{0, n / 3, n / 2, n - 1} {
+				data := input()
+				sorted := slices.Clone(data)
+				slices.Sort(sorted)
+				work := introselect(data, k)
+				require.Equal(t, sorted[k], data[k])
+				require.LessOrEqual(t, work, 32*n, "k=%d", k)
+			}
+		})
+	}
+}
+
+func TestIntroselectFallback(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for range 200 {
+		data := make([]int, 1+rng.Intn(300))
+		for i := range data {
+			data[i] = rng.Intn(1000)
+		}
+
+		// Every pivot from medianOfMedians must have at least 3/10 of
+		// the elements on each side of it, less the rounding of groups.
+		pivot, _ := medianOfMedians(slices.Clone(data))
+		var atMost, atLeast int
+		for _, v := range data {
+			if v <= pivot {
+				atMost++
+			}
+			if v >= pivot {
+				atLeast++
+			}
+		}
+		require.GreaterOrEqual(t, atMost, 3*len(data)/10-2, "pivot %d of %v", pivot, data)
+		require.GreaterOrEqual(t, atLeast, 3*len(data)/10-2, "pivot %d of %v", pivot, data)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []int
+		Expected float64
+		Err      string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			before := slices.Clone(tc.Data)
+			got, err := Median(tc.Data)
+			require.Equal(t, before, tc.Data, "data was modified")
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, got)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Data: []int{},
+		Err:  "median of an empty slice",
+	})
+	validate(t, testCase{Name: "Single",
+		Data:     []int{-4},
+		Expected: -4,
+	})
+	validate(t, testCase{Name: "Odd length",
+		Data:     []int{9, 1, 5, 3, 7},
+		Expected: 5,
+	})
+	validate(t, testCase{Name: "Even length",
+		Data:     []int{8, 2, 6, 4},
+		Expected: 5,
+	})
+	validate(t, testCase{Name: "Even length with a fractional midpoint",
+		Data:     []int{3, 2},
+		Expected: 2.5,
+	})
+	validate(t, testCase{Name: "Duplicates",
+		Data:     []int{4, 1, 4, 9, 4, 4},
+		Expected: 4,
+	})
+}
+
+func TestMedianMatchesMedianSorted(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for range 500 {
+		data := make([]int, 1+rng.Intn(100))
+		for i := range data {
+			data[i] = rng.Int() - rng.Int()
+		}
+		got, err := Median(data)
+		require.NoError(t, err)
+		sorted := slices.Clone(data)
+		slices.Sort(sorted)
+		want, err := MedianSorted(sorted)
+		require.NoError(t, err)
+		require.Equal(t, want, got, "data=%v", data)
+	}
+}
+
+func TestMedianOfMedians(t *testing.T) {
+	_, err := MedianOfMedians(nil)
+	require.EqualError(t, err, "median of medians of an empty slice")
+
+	data := []int{9, 3, 7, 1, 5, 2, 8}
+	before := slices.Clone(data)
+	pivot, err := MedianOfMedians(data)
+	require.NoError(t, err)
+	require.Equal(t, before, data, "data was modified")
+	require.Contains(t, data, pivot)
+}
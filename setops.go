@@ -0,0 +1,78 @@
+package testdemo
+
+import "container/heap"
+
+// SymmetricDifferenceSorted returns the values in exactly one of a and b,
+// which must be sorted in non-decreasing order, as a sorted slice without
+// duplicates. It takes a single O(n+m) pass.
+func SymmetricDifferenceSorted(a, b []int) []int {
+	var out []int
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || i < len(a) && a[i] < b[j]:
+			out = appendUnique(out, a[i])
+			i = UpperBound(a[i:], a[i]) + i
+		case i == len(a) || b[j] < a[i]:
+			out = appendUnique(out, b[j])
+			j = UpperBound(b[j:], b[j]) + j
+		default:
+			v := a[i]
+			i = UpperBound(a[i:], v) + i
+			j = UpperBound(b[j:], v) + j
+		}
+	}
+	return out
+}
+
+// UnionSortedK returns the values in any of lists, each of which must be
+// sorted in non-decreasing order, as a sorted slice without duplicates.
+// It merges through a min-heap of the lists' heads, so it takes
+// O(total·log k) for k lists, and it allocates the output once, sized
+// for the case where no values are shared.
+func UnionSortedK(lists ...[]int) []int {
+	total := 0
+	h := make(mergeHeap, 0, len(lists))
+	for _, l := range lists {
+		total += len(l)
+		if len(l) > 0 {
+			h = append(h, l)
+		}
+	}
+	heap.Init(&h)
+	out := make([]int, 0, total)
+	for len(h) > 0 {
+		out = appendUnique(out, h[0][0])
+		if h[0] = h[0][1:]; len(h[0]) == 0 {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return out
+}
+
+// appendUnique appends v to sorted out unless it is already its last
+// element.
+func appendUnique(out []int, v int) []int {
+	if len(out) > 0 && out[len(out)-1] == v {
+		return out
+	}
+	return append(out, v)
+}
+
+// mergeHeap is a min-heap of non-empty sorted lists ordered by their
+// first elements.
+type mergeHeap [][]int
+
+func (h mergeHeap) Len() int           { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return h[i][0] < h[j][0] }
+func (h mergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)        { *h = append(*h, x.([]int)) }
+
+func (h *mergeHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,73 @@
+package testdemo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymmetricDifferenceSorted(t *testing.T) {
+	type testCase struct {
+		Name     string
+		A, B     []int
+		Expected []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := SymmetricDifferenceSorted(tc.A, tc.B)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Both empty"})
+	validate(t, testCase{Name: "One empty",
+		A:        []int{1, 1, 2},
+		Expected: []int{1, 2},
+	})
+	validate(t, testCase{Name: "Fully overlapping",
+		A: []int{1, 2, 3},
+		B: []int{1, 2, 2, 3},
+	})
+	validate(t, testCase{Name: "Partly overlapping",
+		A:        []int{1, 3, 5, 7},
+		B:        []int{2, 3, 3, 6, 7, 8},
+		Expected: []int{1, 2, 5, 6, 8},
+	})
+}
+
+func TestUnionSortedK(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Lists    [][]int
+		Expected []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual := UnionSortedK(tc.Lists...)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "No lists",
+		Expected: []int{},
+	})
+	validate(t, testCase{Name: "Empty lists",
+		Lists:    [][]int{{}, nil},
+		Expected: []int{},
+	})
+	validate(t, testCase{Name: "Fully overlapping",
+		Lists:    [][]int{{1, 2, 3}, {1, 2, 3}, {1, 1, 2, 3}},
+		Expected: []int{1, 2, 3},
+	})
+	validate(t, testCase{Name: "Interleaved",
+		Lists:    [][]int{{1, 4, 7}, {2, 5, 8}, {}, {3, 6, 9}},
+		Expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	})
+	validate(t, testCase{Name: "Extremes",
+		Lists:    [][]int{{math.MinInt}, {math.MaxInt}, {math.MinInt, 0}},
+		Expected: []int{math.MinInt, 0, math.MaxInt},
+	})
+}
@@ -0,0 +1,57 @@
+package testdemo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// CheckShardedFiles checks that the files at paths, taken in order, hold
+// one sorted sequence: every file has to be sorted, and the last value of
+// each file must not be greater than the first value of the next
+// non-empty one. Every line is passed to parse. The files are read one
+// line at a time, so they never have to fit in memory.
+//
+// A violation is reported as an *UnsortedError whose Source is the file
+// holding Next, and whose Line and Index are those of Next within it.
+// Violations between two files are wrapped in an error naming both.
+func CheckShardedFiles(paths []string, parse func(line string) (int64, error)) error {
+	var (
+		prev     int64
+		prevPath string
+		havePrev bool
+	)
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		sc := bufio.NewScanner(f)
+		sc.Buffer(nil, maxLineLength)
+		var line, index int
+		for sc.Scan() {
+			line++
+			v, err := parse(sc.Text())
+			if err != nil {
+				f.Close()
+				return fmt.Errorf("%s: line %d: %w", path, line, err)
+			}
+			if havePrev && prev > v {
+				f.Close()
+				err := &UnsortedError{Index: index, Prev: prev, Next: v, Line: line, Source: path}
+				if index == 0 {
+					return fmt.Errorf("shards %s and %s are out of order: %w", prevPath, path, err)
+				}
+				return err
+			}
+			prev, prevPath, havePrev = v, path, true
+			index++
+		}
+		err = sc.Err()
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package testdemo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckShardedFiles(t *testing.T) {
+	parse := func(line string) (int64, error) {
+		return strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+	}
+	type testCase struct {
+		Name string
+		// Shards are the contents of the files, in order. A nil shard is a
+		// file that does not exist.
+		Shards      []*string
+		Expected    *UnsortedError
+		ExpectedErr string
+	}
+	shard := func(s string) *string { return &s }
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			dir := t.TempDir()
+			var paths []string
+			for i, content := range tc.Shards {
+				path := filepath.Join(dir, "shard"+strconv.Itoa(i))
+				if content != nil {
+					require.NoError(t, os.WriteFile(path, []byte(*content), 0o644))
+				}
+				paths = append(paths, path)
+			}
+			replacer := strings.NewReplacer(dir+string(filepath.Separator), "")
+
+			err := CheckShardedFiles(paths, parse)
+			if tc.ExpectedErr == "" && tc.Expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tc.ExpectedErr != "" {
+				require.Equal(t, tc.ExpectedErr, replacer.Replace(err.Error()))
+			}
+			if tc.Expected != nil {
+				var unsorted *UnsortedError
+				require.True(t, errors.As(err, &unsorted))
+				unsorted.Source = replacer.Replace(unsorted.Source)
+				require.Equal(t, tc.Expected, unsorted)
+			}
+		})
+	}
+	validate(t, testCase{Name: "No shards"})
+	validate(t, testCase{Name: "Sorted shards",
+		Shards: []*string{shard("1\n2\n"), shard("2\n5\n"), shard("9")},
+	})
+	validate(t, testCase{Name: "Internal violation",
+		Shards:      []*string{shard("1\n2\n"), shard("3\n7\n6\n")},
+		Expected:    &UnsortedError{Index: 2, Prev: int64(7), Next: int64(6), Line: 3, Source: "shard1"},
+		ExpectedErr: "shard1: line 3, index 2: 7 followed by 6",
+	})
+	validate(t, testCase{Name: "Boundary violation",
+		Shards:      []*string{shard("1\n4\n"), shard("3\n7\n")},
+		Expected:    &UnsortedError{Index: 0, Prev: int64(4), Next: int64(3), Line: 1, Source: "shard1"},
+		ExpectedErr: "shards shard0 and shard1 are out of order: shard1: line 1, index 0: 4 followed by 3",
+	})
+	validate(t, testCase{Name: "Empty shard in the middle",
+		Shards: []*string{shard("1\n4\n"), shard(""), shard("4\n7\n")},
+	})
+	validate(t, testCase{Name: "Boundary violation across an empty shard",
+		Shards:      []*string{shard("1\n4\n"), shard(""), shard("3\n")},
+		Expected:    &UnsortedError{Index: 0, Prev: int64(4), Next: int64(3), Line: 1, Source: "shard2"},
+		ExpectedErr: "shards shard0 and shard2 are out of order: shard2: line 1, index 0: 4 followed by 3",
+	})
+	validate(t, testCase{Name: "Parse error",
+		Shards:      []*string{shard("1\nx\n")},
+		ExpectedErr: `shard0: line 2: strconv.ParseInt: parsing "x": invalid syntax`,
+	})
+	validate(t, testCase{Name: "Unreadable shard",
+		Shards:      []*string{shard("1\n"), nil},
+		ExpectedErr: "open shard1: no such file or directory",
+	})
+}
+
+func TestCheckShardedFilesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	err := CheckShardedFiles([]string{dir}, func(string) (int64, error) { return 0, nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), dir)
+}
@@ -0,0 +1,39 @@
+package testdemo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// LogViolation logs err, as returned by a check, to logger at error
+// level. An *UnsortedError, even wrapped, is logged as "unsorted input"
+// with its fields as attributes: index, prev and next always, and length,
+// source and line when they are known. Any other error is logged as
+// "check failed" with it as the error attribute. A nil err logs nothing.
+func LogViolation(logger *slog.Logger, err error) {
+	if err == nil {
+		return
+	}
+	ctx := context.Background()
+	var unsorted *UnsortedError
+	if !errors.As(err, &unsorted) {
+		logger.LogAttrs(ctx, slog.LevelError, "check failed", slog.Any("error", err))
+		return
+	}
+	attrs := []slog.Attr{
+		slog.Int("index", unsorted.Index),
+		slog.Any("prev", unsorted.Prev),
+		slog.Any("next", unsorted.Next),
+	}
+	if unsorted.Len > 0 {
+		attrs = append(attrs, slog.Int("length", unsorted.Len))
+	}
+	if unsorted.Source != "" {
+		attrs = append(attrs, slog.String("source", unsorted.Source))
+	}
+	if unsorted.Line > 0 {
+		attrs = append(attrs, slog.Int("line", unsorted.Line))
+	}
+	logger.LogAttrs(ctx, slog.LevelError, "unsorted input", attrs...)
+}
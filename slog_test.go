@@ -0,0 +1,89 @@
+package testdemo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler keeps the records logged through it.
+type recordingHandler struct {
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+// attrs returns the attributes of r by key, resolved to plain values.
+func attrs(r slog.Record) map[string]any {
+	m := map[string]any{}
+	r.Attrs(func(a slog.Attr) bool {
+		m[a.Key] = a.Value.Resolve().Any()
+		return true
+	})
+	return m
+}
+
+func TestLogViolation(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Err     error
+		Message string
+		Attrs   map[string]any
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			h := &recordingHandler{}
+			LogViolation(slog.New(h), tc.Err)
+			require.Len(t, h.records, 1)
+			require.Equal(t, slog.LevelError, h.records[0].Level)
+			require.Equal(t, tc.Message, h.records[0].Message)
+			require.Equal(t, tc.Attrs, attrs(h.records[0]))
+		})
+	}
+	validate(t, testCase{Name: "Slice",
+		Err:     CheckSortedCtx(context.Background(), []int{1, 3, 2}, Order{Source: "ids"}),
+		Message: "unsorted input",
+		Attrs:   map[string]any{"index": int64(2), "prev": int64(3), "next": int64(2), "length": int64(3), "source": "ids"},
+	})
+	validate(t, testCase{Name: "File",
+		Err:     CheckLines(strings.NewReader("a\nc\nb\n"), LineOptions{Source: "names.txt"}),
+		Message: "unsorted input",
+		Attrs:   map[string]any{"index": int64(2), "prev": "c", "next": "b", "source": "names.txt", "line": int64(3)},
+	})
+	validate(t, testCase{Name: "Stream",
+		Err:     CheckReader(strings.NewReader("1 2\n0"), ReaderOptions{}),
+		Message: "unsorted input",
+		Attrs:   map[string]any{"index": int64(2), "prev": int64(2), "next": int64(0), "line": int64(2)},
+	})
+	validate(t, testCase{Name: "Wrapped",
+		Err:     fmt.Errorf("loading: %w", EnsureSorted([]int{2, 1})),
+		Message: "unsorted input",
+		Attrs:   map[string]any{"index": int64(1), "prev": int64(2), "next": int64(1), "length": int64(2)},
+	})
+	someErr := errors.New("disk on fire")
+	validate(t, testCase{Name: "Other error",
+		Err:     someErr,
+		Message: "check failed",
+		Attrs:   map[string]any{"error": someErr},
+	})
+}
+
+func TestLogViolationNil(t *testing.T) {
+	h := &recordingHandler{}
+	LogViolation(slog.New(h), nil)
+	require.Empty(t, h.records)
+}
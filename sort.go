@@ -1,14 +1,99 @@
 package testdemo
 
+// The loops in this file are written so that the compiler can prove every
+// index in bounds and drop the checks, which TestSortLoopsHaveNoBoundsChecks
+// verifies, and so that they compare each adjacent pair once: n-1
+// comparisons for sorted input.
+
 // IsSorted reports whether data is sorted.
 func IsSorted(data []int) bool {
-	n := len(data)
-	if n == 0 || n == 1 {
+	return FirstUnsortedIndex(data) < 0
+}
+
+// FirstUnsortedIndex returns the index of the first element of data less
+// than the one before it, or -1 when data is sorted in non-decreasing
+// order. On CPUs with a vectorized check it checks long slices with
+// that.
+func FirstUnsortedIndex(data []int) int {
+	if len(data) >= vectorMinLen && vectorized.Load() {
+		return firstUnsortedVector(data)
+	}
+	return firstUnsortedScalar(data)
+}
+
+func firstUnsortedScalar(data []int) int {
+	if len(data) < 2 {
+		return -1
+	}
+	prev := data[0]
+	for i, v := range data[1:] {
+		if v < prev {
+			return i + 1
+		}
+		prev = v
+	}
+	return -1
+}
+
+// IsStrictlySorted reports whether data is sorted in increasing order,
+// with no two elements equal.
+func IsStrictlySorted(data []int) bool {
+	return FirstNotStrictlySortedIndex(data) < 0
+}
+
+// FirstNotStrictlySortedIndex returns the index of the first element of
+// data not greater than the one before it, or -1 when data is sorted in
+// increasing order.
+func FirstNotStrictlySortedIndex(data []int) int {
+	if len(data) < 2 {
+		return -1
+	}
+	prev := data[0]
+	for i, v := range data[1:] {
+		if v <= prev {
+			return i + 1
+		}
+		prev = v
+	}
+	return -1
+}
+
+// IsSortedFunc reports whether data is sorted in non-decreasing order of
+// less, calling it once per adjacent pair until one is out of order.
+func IsSortedFunc[T any](data []T, less func(a, b T) bool) bool {
+	return FirstUnsortedIndexFunc(data, less) < 0
+}
+
+// FirstUnsortedIndexFunc is FirstUnsortedIndex for data ordered by less:
+// it returns the index of the first element less than the one before it,
+// or -1 when there is none.
+func FirstUnsortedIndexFunc[T any](data []T, less func(a, b T) bool) int {
+	if len(data) < 2 {
+		return -1
+	}
+	prev := data[0]
+	for i, v := range data[1:] {
+		if less(v, prev) {
+			return i + 1
+		}
+		prev = v
+	}
+	return -1
+}
+
+// IsStrictlySortedFunc reports whether data is sorted in increasing order
+// of less, with each element less than the next. It calls less once per
+// adjacent pair until one is out of order.
+func IsStrictlySortedFunc[T any](data []T, less func(a, b T) bool) bool {
+	if len(data) < 2 {
 		return true
 	}
-	i := 0
-	for i < n-1 && data[i] <= data[i+1] {
-		i = i + 1
+	prev := data[0]
+	for _, v := range data[1:] {
+		if !less(prev, v) {
+			return false
+		}
+		prev = v
 	}
-	return i == n-1
+	return true
 }
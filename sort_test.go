@@ -0,0 +1,120 @@
+package testdemo
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSortLoopsHaveNoBoundsChecks compiles the package with the bounds
+// check debug flag, which reports every check left in, and requires that
+// none are left in sort.go.
+func TestSortLoopsHaveNoBoundsChecks(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	out, err := exec.Command(goTool, "build", "-o", "/dev/null", "-gcflags=-d=ssa/check_bce/debug=1", ".").CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "./sort.go:") {
+			t.Errorf("bounds check left in: %s", line)
+		}
+	}
+}
+
+func TestFirstUnsortedIndex(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []int
+		Expected int
+		Strict   int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			less := func(a, b int) bool { return a < b }
+			require.Equal(t, tc.Expected, FirstUnsortedIndex(tc.Data))
+			require.Equal(t, tc.Expected, FirstUnsortedIndexFunc(tc.Data, less))
+			require.Equal(t, tc.Expected < 0, IsSorted(tc.Data))
+			require.Equal(t, tc.Expected < 0, IsSortedFunc(tc.Data, less))
+			require.Equal(t, tc.Strict, FirstNotStrictlySortedIndex(tc.Data))
+			require.Equal(t, tc.Strict < 0, IsStrictlySorted(tc.Data))
+			require.Equal(t, tc.Strict < 0, IsStrictlySortedFunc(tc.Data, less))
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Data:     []int{},
+		Expected: -1,
+		Strict:   -1,
+	})
+	validate(t, testCase{Name: "Single",
+		Data:     []int{4},
+		Expected: -1,
+		Strict:   -1,
+	})
+	validate(t, testCase{Name: "Increasing",
+		Data:     []int{1, 2, 3},
+		Expected: -1,
+		Strict:   -1,
+	})
+	validate(t, testCase{Name: "Equal neighbors",
+		Data:     []int{1, 2, 2, 3},
+		Expected: -1,
+		Strict:   2,
+	})
+	validate(t, testCase{Name: "Drop at the end",
+		Data:     []int{1, 2, 3, 0},
+		Expected: 3,
+		Strict:   3,
+	})
+	validate(t, testCase{Name: "First of several",
+		Data:     []int{5, 1, 0},
+		Expected: 1,
+		Strict:   1,
+	})
+}
+
+func TestSortednessComparisonCounts(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 1000} {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = i
+		}
+		var calls int
+		less := func(a, b int) bool {
+			calls++
+			return a < b
+		}
+		want := max(n-1, 0)
+
+		calls = 0
+		require.True(t, IsSortedFunc(data, less))
+		require.Equal(t, want, calls, "IsSortedFunc n=%d", n)
+
+		calls = 0
+		require.Equal(t, -1, FirstUnsortedIndexFunc(data, less))
+		require.Equal(t, want, calls, "FirstUnsortedIndexFunc n=%d", n)
+
+		calls = 0
+		require.True(t, IsStrictlySortedFunc(data, less))
+		require.Equal(t, want, calls, "IsStrictlySortedFunc n=%d", n)
+	}
+}
+
+func TestIsSortedFuncMatchesIsSorted(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	less := func(a, b int) bool { return a < b }
+	for range 1000 {
+		data := make([]int, rng.Intn(8))
+		for i := range data {
+			data[i] = rng.Intn(4)
+		}
+		require.Equal(t, IsSorted(data), IsSortedFunc(data, less), "%v", data)
+		require.Equal(t, IsStrictlySorted(data), IsStrictlySortedFunc(data, less), "%v", data)
+	}
+}
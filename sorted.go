@@ -0,0 +1,61 @@
+package testdemo
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Sorted is a slice known to be sorted in non-decreasing order, checked
+// once by AsSorted so that code handed one need not check it again. It
+// shares the slice it was made from, which must not be modified while the
+// Sorted is in use.
+type Sorted[T cmp.Ordered] struct {
+	data []T
+}
+
+// AsSorted returns data as a Sorted, or an *UnsortedError for the first
+// pair out of order when it is not sorted.
+func AsSorted[T cmp.Ordered](data []T) (Sorted[T], error) {
+	if sorted, ok := isSortedFast(data); ok && sorted {
+		return Sorted[T]{data: data}, nil
+	}
+	for i := 1; i < len(data); i++ {
+		if cmp.Less(data[i], data[i-1]) {
+			return Sorted[T]{}, &UnsortedError{Index: i, Prev: data[i-1], Next: data[i], Len: len(data)}
+		}
+	}
+	return Sorted[T]{data: data}, nil
+}
+
+// Slice returns the sorted slice, which must not be modified.
+func (s Sorted[T]) Slice() []T {
+	return s.data
+}
+
+// Len returns the number of elements.
+func (s Sorted[T]) Len() int {
+	return len(s.data)
+}
+
+// All returns an iterator over the elements in ascending order.
+func (s Sorted[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in descending order,
+// walking the slice from its end rather than reversing a copy.
+func (s Sorted[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.data) - 1; i >= 0; i-- {
+			if !yield(s.data[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package testdemo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsSorted(t *testing.T) {
+	s, err := AsSorted([]string{"a", "b", "b"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "b"}, s.Slice())
+	require.Equal(t, 3, s.Len())
+
+	_, err = AsSorted([]float64{1, 0.5})
+	require.Equal(t, &UnsortedError{Index: 1, Prev: 1.0, Next: 0.5, Len: 2}, err)
+
+	var zero Sorted[int]
+	require.Zero(t, zero.Len())
+	require.Empty(t, zero.Slice())
+}
+
+func TestSortedIterators(t *testing.T) {
+	s := MustAsSorted([]int{1, 2, 2, 5})
+	require.Equal(t, []int{1, 2, 2, 5}, slices.Collect(s.All()))
+	require.Equal(t, []int{5, 2, 2, 1}, slices.Collect(s.Backward()))
+
+	var empty Sorted[int]
+	require.Empty(t, slices.Collect(empty.All()))
+	require.Empty(t, slices.Collect(empty.Backward()))
+
+	var seen []int
+	for v := range s.Backward() {
+		seen = append(seen, v)
+		if v == 2 {
+			break
+		}
+	}
+	require.Equal(t, []int{5, 2}, seen, "break must stop Backward")
+	seen = nil
+	for v := range s.All() {
+		seen = append(seen, v)
+		break
+	}
+	require.Equal(t, []int{1}, seen, "break must stop All")
+}
+
+func TestSortedBackwardDoesNotCopy(t *testing.T) {
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	s := MustAsSorted(data)
+	sum := 0
+	allocs := testing.AllocsPerRun(10, func() {
+		sum = 0
+		for v := range s.Backward() {
+			sum += v
+		}
+	})
+	require.Zero(t, allocs)
+	require.Equal(t, 999*1000/2, sum)
+}
@@ -0,0 +1,44 @@
+package testdemo
+
+// SortedBuffer keeps the n smallest values offered to it, in sorted
+// order, as a streaming top-n query does.
+type SortedBuffer struct {
+	n      int
+	values []int
+}
+
+// NewSortedBuffer returns an empty buffer keeping at most n values.
+func NewSortedBuffer(n int) *SortedBuffer {
+	return &SortedBuffer{n: n, values: make([]int, 0, max(n, 0))}
+}
+
+// Offer adds v if it is among the n smallest values offered so far,
+// dropping the largest kept value to make room if the buffer is full, and
+// reports whether v was kept. A v equal to the largest kept value of a
+// full buffer is not kept. It costs a binary search plus shifting the
+// larger kept values along.
+func (b *SortedBuffer) Offer(v int) bool {
+	if len(b.values) == b.n {
+		if b.n <= 0 || v >= b.values[b.n-1] {
+			return false
+		}
+		b.values = b.values[:b.n-1]
+	}
+	i := UpperBound(b.values, v)
+	b.values = append(b.values, 0)
+	copy(b.values[i+1:], b.values[i:])
+	b.values[i] = v
+	return true
+}
+
+// Values returns the kept values in non-decreasing order. It does not
+// allocate: the slice is the buffer's own, valid until the next call to
+// Offer or Reset, and must not be modified.
+func (b *SortedBuffer) Values() []int {
+	return b.values
+}
+
+// Reset empties the buffer, keeping its memory.
+func (b *SortedBuffer) Reset() {
+	b.values = b.values[:0]
+}
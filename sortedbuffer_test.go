@@ -0,0 +1,96 @@
+package testdemo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedBuffer(t *testing.T) {
+	type testCase struct {
+		Name     string
+		N        int
+		Stream   []int
+		Kept     []bool
+		Expected []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			b := NewSortedBuffer(tc.N)
+			var kept []bool
+			for _, v := range tc.Stream {
+				kept = append(kept, b.Offer(v))
+			}
+			require.Equal(t, tc.Kept, kept)
+			require.Equal(t, tc.Expected, b.Values())
+		})
+	}
+	validate(t, testCase{Name: "Zero capacity",
+		N:        0,
+		Stream:   []int{1, 2},
+		Kept:     []bool{false, false},
+		Expected: []int{},
+	})
+	validate(t, testCase{Name: "Capacity one",
+		N:        1,
+		Stream:   []int{5, 7, 3, 3},
+		Kept:     []bool{true, false, true, false},
+		Expected: []int{3},
+	})
+	validate(t, testCase{Name: "Ascending",
+		N:        3,
+		Stream:   []int{1, 2, 3, 4, 5},
+		Kept:     []bool{true, true, true, false, false},
+		Expected: []int{1, 2, 3},
+	})
+	validate(t, testCase{Name: "Descending",
+		N:        3,
+		Stream:   []int{5, 4, 3, 2, 1},
+		Kept:     []bool{true, true, true, true, true},
+		Expected: []int{1, 2, 3},
+	})
+	validate(t, testCase{Name: "Duplicates",
+		N:        3,
+		Stream:   []int{2, 2, 1, 2, 1},
+		Kept:     []bool{true, true, true, false, true},
+		Expected: []int{1, 1, 2},
+	})
+}
+
+func TestSortedBufferReset(t *testing.T) {
+	b := NewSortedBuffer(2)
+	b.Offer(1)
+	b.Offer(2)
+	b.Reset()
+	require.Empty(t, b.Values())
+	require.True(t, b.Offer(9))
+	require.Equal(t, []int{9}, b.Values())
+}
+
+func TestSortedBufferValuesDoesNotAllocate(t *testing.T) {
+	b := NewSortedBuffer(4)
+	b.Offer(3)
+	b.Offer(1)
+	require.Zero(t, testing.AllocsPerRun(100, func() { _ = b.Values() }))
+}
+
+func TestSortedBufferKeepsSmallest(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 300; i++ {
+		n := rng.Intn(10)
+		stream := make([]int, rng.Intn(50))
+		b := NewSortedBuffer(n)
+		for j := range stream {
+			stream[j] = rng.Intn(20)
+			b.Offer(stream[j])
+		}
+		expected := slices.Clone(stream)
+		slices.Sort(expected)
+		expected = expected[:min(n, len(expected))]
+		require.Equal(t, expected, slices.Clone(b.Values()), "n=%d %v", n, stream)
+	}
+}
@@ -0,0 +1,198 @@
+package testdemo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// A sorted file holds a list of ints sorted in non-decreasing order. It
+// starts with a header, all little-endian:
+//
+//	magic   [4]byte  "SRTD"
+//	version uint8    1
+//	flags   uint8    bit 0 set for a delta-varint payload
+//	count   uint64   number of values
+//	min     int64    first value, 0 when there are none
+//	max     int64    last value, 0 when there are none
+//
+// followed by the payload: count int64s, or with the delta-varint flag,
+// the values as a MonotoneWriter writes them. Nothing follows it.
+const (
+	sortedFileMagic   = "SRTD"
+	sortedFileVersion = 1
+	sortedFileHeader  = 4 + 1 + 1 + 8 + 8 + 8
+
+	sortedFileDelta = 1 << 0
+)
+
+// Errors ReadSortedFile wraps, one for each way a sorted file can be
+// corrupt. An unsorted payload is an *UnsortedError instead.
+var (
+	ErrBadMagic           = errors.New("not a sorted file")
+	ErrUnsupportedVersion = errors.New("unsupported sorted file version")
+	ErrTruncated          = errors.New("sorted file is truncated")
+	// ErrCountMismatch means the payload holds more values than the
+	// header counts.
+	ErrCountMismatch = errors.New("sorted file payload does not match its count")
+	// ErrHeaderMismatch means the payload's first or last value is not the
+	// min or max in the header.
+	ErrHeaderMismatch = errors.New("sorted file payload does not match its header")
+	// ErrOverflow means a value in the header or payload does not fit in
+	// an int, as one written where int is 64 bits may not where it is 32.
+	ErrOverflow = errors.New("sorted file value overflows int")
+)
+
+// WriteSortedFile writes data, which must be sorted in non-decreasing
+// order, to w as a sorted file, with whichever payload is smaller. It
+// returns an *UnsortedError, having written nothing, when data is not
+// sorted.
+func WriteSortedFile(w io.Writer, data []int) error {
+	// A varint takes a byte per 7 bits of its value.
+	size := 0
+	for i, v := range data {
+		if i > 0 && v < data[i-1] {
+			return &UnsortedError{Index: i, Prev: data[i-1], Next: v}
+		}
+		x := int64(v)
+		d := uint64(x<<1) ^ uint64(x>>63) // the first value is zigzagged
+		if i > 0 {
+			d = uint64(v) - uint64(data[i-1])
+		}
+		size += max(1, (bits.Len64(d)+6)/7)
+	}
+
+	header := make([]byte, 0, sortedFileHeader)
+	header = append(header, sortedFileMagic...)
+	header = append(header, sortedFileVersion)
+	delta := size < 8*len(data)
+	if delta {
+		header = append(header, sortedFileDelta)
+	} else {
+		header = append(header, 0)
+	}
+	header = binary.LittleEndian.AppendUint64(header, uint64(len(data)))
+	var lo, hi int
+	if len(data) > 0 {
+		lo, hi = data[0], data[len(data)-1]
+	}
+	header = binary.LittleEndian.AppendUint64(header, uint64(lo))
+	header = binary.LittleEndian.AppendUint64(header, uint64(hi))
+
+	bw := bufio.NewWriter(w)
+	bw.Write(header)
+	if delta {
+		m := NewMonotoneWriter(bw)
+		for _, v := range data {
+			// data is sorted, so only bw can fail, and it reports that on
+			// Flush.
+			m.WriteValue(int64(v))
+		}
+	} else {
+		var buf [8]byte
+		for _, v := range data {
+			binary.LittleEndian.PutUint64(buf[:], uint64(v))
+			bw.Write(buf[:])
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadSortedFile reads a sorted file from r and returns its values,
+// checking the header, that the payload holds exactly the number of
+// values counted and that they are sorted. A corrupt file is an error
+// wrapping ErrBadMagic, ErrUnsupportedVersion, ErrTruncated,
+// ErrCountMismatch or ErrHeaderMismatch, or an *UnsortedError for a
+// payload out of order. A file whose values do not fit in an int is an
+// error wrapping ErrOverflow.
+func ReadSortedFile(r io.Reader) ([]int, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, sortedFileHeader)
+	if n, err := io.ReadFull(br, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("%w: header has %d of %d bytes", ErrTruncated, n, sortedFileHeader)
+		}
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], []byte(sortedFileMagic)) {
+		return nil, fmt.Errorf("%w: magic is %q", ErrBadMagic, header[:4])
+	}
+	if v := header[4]; v != sortedFileVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, v)
+	}
+	delta := header[5]&sortedFileDelta != 0
+	count := binary.LittleEndian.Uint64(header[6:])
+	lo64 := int64(binary.LittleEndian.Uint64(header[14:]))
+	hi64 := int64(binary.LittleEndian.Uint64(header[22:]))
+	lo, ok := fitInt(lo64)
+	if !ok {
+		return nil, fmt.Errorf("%w: header min is %d", ErrOverflow, lo64)
+	}
+	hi, ok := fitInt(hi64)
+	if !ok {
+		return nil, fmt.Errorf("%w: header max is %d", ErrOverflow, hi64)
+	}
+
+	// count comes from the file, so it only bounds the allocation once
+	// the values are there to fill it.
+	data := make([]int, 0, min(count, 1<<16))
+	var m *MonotoneReader
+	if delta {
+		m = NewMonotoneReader(br)
+	}
+	var buf [8]byte
+	for i := uint64(0); i < count; i++ {
+		var v64 int64
+		if delta {
+			var err error
+			v64, err = m.ReadValue()
+			if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("%w: payload ends after %d of %d values", ErrTruncated, i, count)
+			}
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			if _, err := io.ReadFull(br, buf[:]); err != nil {
+				if err == io.EOF || err == io.ErrUnexpectedEOF {
+					return nil, fmt.Errorf("%w: payload ends after %d of %d values", ErrTruncated, i, count)
+				}
+				return nil, err
+			}
+			v64 = int64(binary.LittleEndian.Uint64(buf[:]))
+		}
+		v, ok := fitInt(v64)
+		if !ok {
+			return nil, fmt.Errorf("%w: value %d is %d", ErrOverflow, i, v64)
+		}
+		if n := len(data); n > 0 && v < data[n-1] {
+			return nil, &UnsortedError{Index: n, Prev: data[n-1], Next: v}
+		}
+		data = append(data, v)
+	}
+	if _, err := br.ReadByte(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: more than %d values", ErrCountMismatch, count)
+	}
+	var first, last int
+	if len(data) > 0 {
+		first, last = data[0], data[len(data)-1]
+	}
+	if first != lo || last != hi {
+		return nil, fmt.Errorf("%w: values span [%d, %d], header says [%d, %d]",
+			ErrHeaderMismatch, first, last, lo, hi)
+	}
+	return data, nil
+}
+
+// fitInt converts v to an int, reporting false when it does not fit, as
+// only happens where int is 32 bits.
+func fitInt(v int64) (int, bool) {
+	return int(v), int64(int(v)) == v
+}
@@ -0,0 +1,121 @@
+package testdemo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSortedFileFixtures(t *testing.T) {
+	type testCase struct {
+		Name   string
+		File   string
+		Values []int
+		Is     error
+		Err    string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			f, err := os.Open(filepath.Join("testdata", "sortedfile", tc.File))
+			require.NoError(t, err)
+			defer f.Close()
+			got, err := ReadSortedFile(f)
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				if tc.Is != nil {
+					require.ErrorIs(t, err, tc.Is)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Values, got)
+		})
+	}
+	validate(t, testCase{Name: "Valid",
+		File:   "valid.srtd",
+		Values: []int{1, 5, 9},
+	})
+	validate(t, testCase{Name: "Valid delta payload",
+		File:   "valid_delta.srtd",
+		Values: []int{1, 3, 3, 200},
+	})
+	validate(t, testCase{Name: "Bad magic",
+		File: "bad_magic.srtd",
+		Is:   ErrBadMagic,
+		Err:  `not a sorted file: magic is "SRTX"`,
+	})
+	validate(t, testCase{Name: "Bad version",
+		File: "bad_version.srtd",
+		Is:   ErrUnsupportedVersion,
+		Err:  "unsupported sorted file version: 2",
+	})
+	validate(t, testCase{Name: "Truncated header",
+		File: "truncated_header.srtd",
+		Is:   ErrTruncated,
+		Err:  "sorted file is truncated: header has 17 of 30 bytes",
+	})
+	validate(t, testCase{Name: "Truncated payload",
+		File: "truncated_payload.srtd",
+		Is:   ErrTruncated,
+		Err:  "sorted file is truncated: payload ends after 2 of 3 values",
+	})
+	validate(t, testCase{Name: "Truncated delta payload",
+		File: "truncated_delta.srtd",
+		Is:   ErrTruncated,
+		Err:  "sorted file is truncated: payload ends after 3 of 4 values",
+	})
+	validate(t, testCase{Name: "Count mismatch",
+		File: "count_mismatch.srtd",
+		Is:   ErrCountMismatch,
+		Err:  "sorted file payload does not match its count: more than 3 values",
+	})
+	validate(t, testCase{Name: "Min and max mismatch",
+		File: "min_max_mismatch.srtd",
+		Is:   ErrHeaderMismatch,
+		Err:  "sorted file payload does not match its header: values span [1, 9], header says [0, 9]",
+	})
+	validate(t, testCase{Name: "Unsorted payload",
+		File: "unsorted_payload.srtd",
+		Err:  "index 2: 10 followed by 9",
+	})
+}
+
+func TestSortedFileRoundTrip(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 100; i++ {
+		data := make([]int, rng.Intn(100))
+		for j := range data {
+			if i%2 == 0 {
+				data[j] = rng.Intn(1000) - 500 // dense, so delta-encoded
+			} else {
+				data[j] = int(rng.Uint64()) // wide, so fixed-width
+			}
+		}
+		slices.Sort(data)
+		var buf bytes.Buffer
+		require.NoError(t, WriteSortedFile(&buf, data))
+		got, err := ReadSortedFile(&buf)
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+	}
+}
+
+func TestWriteSortedFilePayload(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSortedFile(&buf, []int{10, 11, 12, 13}))
+	require.Equal(t, byte(sortedFileDelta), buf.Bytes()[5], "dense data should be delta-encoded")
+	require.Equal(t, sortedFileHeader+4, buf.Len())
+}
+
+func TestWriteSortedFileUnsorted(t *testing.T) {
+	var buf bytes.Buffer
+	require.Equal(t, &UnsortedError{Index: 1, Prev: 2, Next: 1}, WriteSortedFile(&buf, []int{2, 1}))
+	require.Zero(t, buf.Len())
+}
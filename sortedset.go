@@ -0,0 +1,129 @@
+package testdemo
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SortedSet is a set kept as a sorted slice of unique elements. For sets
+// of up to a few thousand elements it takes less memory than a map and
+// iterates in order; Add and Remove cost O(n) to shift elements, and
+// Contains O(log n). The zero value is an empty set ready to use.
+type SortedSet[T cmp.Ordered] struct {
+	elems []T
+}
+
+// NewSortedSet returns a set holding values.
+func NewSortedSet[T cmp.Ordered](values ...T) *SortedSet[T] {
+	elems := slices.Clone(values)
+	slices.Sort(elems)
+	return &SortedSet[T]{elems: slices.Compact(elems)}
+}
+
+// Add adds v to the set and reports whether it was not already there.
+func (s *SortedSet[T]) Add(v T) bool {
+	i, found := slices.BinarySearch(s.elems, v)
+	if found {
+		return false
+	}
+	s.elems = slices.Insert(s.elems, i, v)
+	return true
+}
+
+// Remove removes v from the set and reports whether it was there.
+func (s *SortedSet[T]) Remove(v T) bool {
+	i, found := slices.BinarySearch(s.elems, v)
+	if !found {
+		return false
+	}
+	s.elems = slices.Delete(s.elems, i, i+1)
+	return true
+}
+
+// Contains reports whether v is in the set.
+func (s *SortedSet[T]) Contains(v T) bool {
+	_, found := slices.BinarySearch(s.elems, v)
+	return found
+}
+
+// Len returns the number of elements in the set.
+func (s *SortedSet[T]) Len() int {
+	return len(s.elems)
+}
+
+// Min returns the smallest element, or false if the set is empty.
+func (s *SortedSet[T]) Min() (T, bool) {
+	if len(s.elems) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.elems[0], true
+}
+
+// Max returns the largest element, or false if the set is empty.
+func (s *SortedSet[T]) Max() (T, bool) {
+	if len(s.elems) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.elems[len(s.elems)-1], true
+}
+
+// Range returns an iterator over the elements in ascending order. The set
+// must not be changed while iterating.
+func (s *SortedSet[T]) Range() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.elems {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UnionWith adds every element of other to the set, merging the two
+// sorted slices in O(n+m).
+func (s *SortedSet[T]) UnionWith(other *SortedSet[T]) {
+	a, b := s.elems, other.elems
+	merged := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp.Compare(a[i], b[j]); {
+		case c < 0:
+			merged = append(merged, a[i])
+			i++
+		case c > 0:
+			merged = append(merged, b[j])
+			j++
+		default:
+			merged = append(merged, a[i])
+			i++
+			j++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	s.elems = append(merged, b[j:]...)
+}
+
+// IntersectWith removes every element of the set that is not in other,
+// in O(n+m).
+func (s *SortedSet[T]) IntersectWith(other *SortedSet[T]) {
+	a, b := s.elems, other.elems
+	kept := a[:0]
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp.Compare(a[i], b[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			kept = append(kept, a[i])
+			i++
+			j++
+		}
+	}
+	clear(a[len(kept):])
+	s.elems = kept
+}
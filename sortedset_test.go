@@ -0,0 +1,107 @@
+package testdemo
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+// requireSortedSetInvariant fails t unless s holds strictly increasing
+// elements, which is what keeps it a set.
+func requireSortedSetInvariant[T cmp.Ordered](t *testing.T, s *SortedSet[T]) {
+	t.Helper()
+	for i := 1; i < len(s.elems); i++ {
+		require.Less(t, s.elems[i-1], s.elems[i], "elements %d and %d of %v", i-1, i, s.elems)
+	}
+}
+
+func TestSortedSet(t *testing.T) {
+	var s SortedSet[string]
+	_, ok := s.Min()
+	require.False(t, ok)
+	_, ok = s.Max()
+	require.False(t, ok)
+
+	require.True(t, s.Add("b"))
+	require.True(t, s.Add("a"))
+	require.False(t, s.Add("b"))
+	require.True(t, s.Contains("a"))
+	require.False(t, s.Contains("c"))
+	require.Equal(t, 2, s.Len())
+	require.Equal(t, []string{"a", "b"}, slices.Collect(s.Range()))
+
+	first, _ := s.Min()
+	last, _ := s.Max()
+	require.Equal(t, "a", first)
+	require.Equal(t, "b", last)
+
+	require.True(t, s.Remove("a"))
+	require.False(t, s.Remove("a"))
+	require.Equal(t, []string{"b"}, slices.Collect(s.Range()))
+}
+
+func TestSortedSetUnionAndIntersect(t *testing.T) {
+	s := NewSortedSet(5, 1, 3, 3)
+	s.UnionWith(NewSortedSet(2, 3, 6))
+	requireSortedSetInvariant(t, s)
+	require.Equal(t, []int{1, 2, 3, 5, 6}, slices.Collect(s.Range()))
+
+	s.IntersectWith(NewSortedSet(0, 2, 5, 7))
+	requireSortedSetInvariant(t, s)
+	require.Equal(t, []int{2, 5}, slices.Collect(s.Range()))
+
+	s.IntersectWith(&SortedSet[int]{})
+	require.Equal(t, 0, s.Len())
+}
+
+func TestSortedSetRangeStopsEarly(t *testing.T) {
+	s := NewSortedSet(1, 2, 3)
+	var seen []int
+	for v := range s.Range() {
+		seen = append(seen, v)
+		if v == 2 {
+			break
+		}
+	}
+	require.Equal(t, []int{1, 2}, seen)
+}
+
+func TestSortedSetRandomOperations(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	s := &SortedSet[int]{}
+	model := map[int]bool{}
+	for i := 0; i < 5000; i++ {
+		v := rng.Intn(50)
+		switch rng.Intn(5) {
+		case 0, 1:
+			require.Equal(t, !model[v], s.Add(v))
+			model[v] = true
+		case 2:
+			require.Equal(t, model[v], s.Remove(v))
+			delete(model, v)
+		case 3:
+			other := NewSortedSet(rng.Intn(50), rng.Intn(50), rng.Intn(50))
+			s.UnionWith(other)
+			for w := range other.Range() {
+				model[w] = true
+			}
+		case 4:
+			other := &SortedSet[int]{}
+			for j := 0; j < 40; j++ {
+				other.Add(rng.Intn(50))
+			}
+			s.IntersectWith(other)
+			for w := range model {
+				if !other.Contains(w) {
+					delete(model, w)
+				}
+			}
+		}
+		requireSortedSetInvariant(t, s)
+		require.Equal(t, len(model), s.Len())
+		require.Equal(t, model[v], s.Contains(v))
+	}
+}
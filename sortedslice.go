@@ -0,0 +1,47 @@
+package testdemo
+
+import "slices"
+
+// SortedInsert inserts v into data, which must be sorted in
+// non-decreasing order, and returns the result, which is too. Like
+// append, it reuses the capacity of data when there is room.
+func SortedInsert(data []int, v int) []int {
+	return slices.Insert(data, UpperBound(data, v), v)
+}
+
+// SortedDelete removes one occurrence of v from data, which must be
+// sorted in non-decreasing order, found by binary search. It returns the
+// shortened slice and whether v was there.
+func SortedDelete(data []int, v int) ([]int, bool) {
+	i, found := Search(data, v)
+	if !found {
+		return data, false
+	}
+	return slices.Delete(data, i, i+1), true
+}
+
+// AppendSorted adds vals to data, which must be sorted in non-decreasing
+// order, and returns the result, which is too. vals may be in any order
+// and is not modified. Rather than inserting them one at a time, it sorts
+// a copy of vals and merges it into data from the back in a single pass,
+// reusing the capacity of data when there is room, so it takes
+// O(n + m log m) instead of O(n·m).
+func AppendSorted(data []int, vals ...int) []int {
+	if len(vals) == 0 {
+		return data
+	}
+	add := slices.Clone(vals)
+	slices.Sort(add)
+	i, j := len(data)-1, len(add)-1
+	data = slices.Grow(data, len(add))[:len(data)+len(add)]
+	for k := len(data) - 1; j >= 0; k-- {
+		if i >= 0 && data[i] > add[j] {
+			data[k] = data[i]
+			i--
+		} else {
+			data[k] = add[j]
+			j--
+		}
+	}
+	return data
+}
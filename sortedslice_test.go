@@ -0,0 +1,80 @@
+package testdemo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedInsertAndDelete(t *testing.T) {
+	data := SortedInsert(nil, 3)
+	data = SortedInsert(data, 1)
+	data = SortedInsert(data, 3)
+	data = SortedInsert(data, 2)
+	require.Equal(t, []int{1, 2, 3, 3}, data)
+
+	data, ok := SortedDelete(data, 3)
+	require.True(t, ok)
+	require.Equal(t, []int{1, 2, 3}, data)
+	data, ok = SortedDelete(data, 5)
+	require.False(t, ok)
+	require.Equal(t, []int{1, 2, 3}, data)
+	data, ok = SortedDelete(data, 1)
+	require.True(t, ok)
+	require.Equal(t, []int{2, 3}, data)
+}
+
+func TestAppendSorted(t *testing.T) {
+	require.Equal(t, []int{1, 2, 3}, AppendSorted([]int{1, 2, 3}))
+	require.Equal(t, []int{1, 2, 3}, AppendSorted(nil, 3, 1, 2))
+
+	vals := []int{9, -1, 2}
+	require.Equal(t, []int{-1, 1, 2, 2, 5, 9}, AppendSorted([]int{1, 2, 5}, vals...))
+	require.Equal(t, []int{9, -1, 2}, vals, "AppendSorted must not modify vals")
+
+	data := make([]int, 2, 8)
+	data[0], data[1] = 1, 4
+	merged := AppendSorted(data, 3, 0)
+	require.Equal(t, []int{0, 1, 3, 4}, merged)
+	require.Same(t, &data[0], &merged[0], "AppendSorted should reuse spare capacity")
+}
+
+func TestSortedSliceRandomMutations(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	var data []int
+	counts := map[int]int{}
+	for i := 0; i < 2000; i++ {
+		switch rng.Intn(3) {
+		case 0:
+			v := rng.Intn(30)
+			var ok bool
+			data, ok = SortedDelete(data, v)
+			require.Equal(t, counts[v] > 0, ok)
+			if ok {
+				counts[v]--
+			}
+		case 1:
+			v := rng.Intn(30)
+			data = SortedInsert(data, v)
+			counts[v]++
+		case 2:
+			vals := make([]int, rng.Intn(5))
+			for j := range vals {
+				vals[j] = rng.Intn(30)
+				counts[vals[j]]++
+			}
+			data = AppendSorted(data, vals...)
+		}
+		require.True(t, IsSorted(data), "%v", data)
+		total := 0
+		for v, c := range counts {
+			lo, hi := RankRange(data, v)
+			require.Equal(t, c, hi-lo, "count of %d in %v", v, data)
+			total += c
+		}
+		require.Len(t, data, total)
+	}
+	require.True(t, slices.IsSorted(data))
+}
@@ -0,0 +1,60 @@
+package testdemo
+
+// InsertionSort sorts data in place in non-decreasing order. It takes
+// O(n²) time in general but O(n) on sorted or nearly sorted input, which
+// makes it the better choice for small or almost sorted slices.
+func InsertionSort(data []int) {
+	for i := 1; i < len(data); i++ {
+		v := data[i]
+		j := i
+		for j > 0 && data[j-1] > v {
+			data[j] = data[j-1]
+			j--
+		}
+		data[j] = v
+	}
+}
+
+// MergeSortInts returns a sorted copy of data, leaving data untouched.
+func MergeSortInts(data []int) []int {
+	return MergeSortFunc(data, func(a, b int) bool { return a < b })
+}
+
+// MergeSortFunc returns a copy of data sorted by less, leaving data
+// untouched. The sort is stable: elements that compare equal keep their
+// relative order. It takes O(n log n) time and O(n) extra memory.
+func MergeSortFunc[T any](data []T, less func(a, b T) bool) []T {
+	sorted := make([]T, len(data))
+	copy(sorted, data)
+	scratch := make([]T, len(data))
+	mergeSort(sorted, scratch, less)
+	return sorted
+}
+
+// mergeSort sorts data using scratch, which is as long as data.
+func mergeSort[T any](data, scratch []T, less func(a, b T) bool) {
+	if len(data) < 2 {
+		return
+	}
+	mid := len(data) / 2
+	mergeSort(data[:mid], scratch[:mid], less)
+	mergeSort(data[mid:], scratch[mid:], less)
+	if !less(data[mid], data[mid-1]) {
+		return // already in order
+	}
+	copy(scratch, data)
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(data) {
+		// Taking from the left half on ties is what keeps the sort stable.
+		if less(scratch[j], scratch[i]) {
+			data[k] = scratch[j]
+			j++
+		} else {
+			data[k] = scratch[i]
+			i++
+		}
+		k++
+	}
+	k += copy(data[k:], scratch[i:mid])
+	copy(data[k:], scratch[j:])
+}
@@ -0,0 +1,92 @@
+package testdemo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceSorts(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Array []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			input := make([]int, len(tc.Array))
+			copy(input, tc.Array)
+
+			merged := MergeSortInts(input)
+			require.True(t, IsSorted(merged))
+			require.True(t, IsPermutationOf(tc.Array, merged))
+			require.Equal(t, tc.Array, input, "MergeSortInts must not modify its input")
+
+			InsertionSort(input)
+			require.True(t, IsSorted(input))
+			require.True(t, IsPermutationOf(tc.Array, input))
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array: []int{},
+	})
+	validate(t, testCase{Name: "Single element",
+		Array: []int{0},
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array: []int{0, math.MinInt, math.MaxInt, -1},
+	})
+	validate(t, testCase{Name: "Duplicates",
+		Array: []int{3, 1, 3, 1, 2, 2},
+	})
+	validate(t, testCase{Name: "Reversed",
+		Array: []int{5, 4, 3, 2, 1, 0},
+	})
+}
+
+func TestReferenceSortsAgainstSortInts(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]int, rng.Intn(100))
+		for j := range data {
+			data[j] = rng.Intn(20) - 10
+		}
+		expected := make([]int, len(data))
+		copy(expected, data)
+		sort.Ints(expected)
+
+		require.Equal(t, expected, MergeSortInts(data))
+		insertion := make([]int, len(data))
+		copy(insertion, data)
+		InsertionSort(insertion)
+		require.Equal(t, expected, insertion)
+	}
+}
+
+func TestMergeSortFuncIsStable(t *testing.T) {
+	type tagged struct {
+		Key   int
+		Index int
+	}
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 200; i++ {
+		data := make([]tagged, rng.Intn(64))
+		for j := range data {
+			data[j] = tagged{Key: rng.Intn(5), Index: j}
+		}
+		sorted := MergeSortFunc(data, func(a, b tagged) bool { return a.Key < b.Key })
+		// Sorting by key alone must leave equal keys in index order, which
+		// makes the result sorted by (key, index).
+		require.True(t, sort.SliceIsSorted(sorted, func(a, b int) bool {
+			if sorted[a].Key != sorted[b].Key {
+				return sorted[a].Key < sorted[b].Key
+			}
+			return sorted[a].Index < sorted[b].Index
+		}), "%v", sorted)
+	}
+}
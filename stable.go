@@ -0,0 +1,76 @@
+//go:build !tinygo && !noreflect
+
+package testdemo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsStableSorted reports whether sorted is a stable sort of original under
+// less: it has to be a permutation of original, be in non-decreasing order
+// under less, and keep elements that compare equal in the relative order
+// they had in original. Elements are matched to their original positions
+// with reflect.DeepEqual, so identical elements are interchangeable.
+//
+// When sorted is out of order the error is an *UnsortedError; otherwise it
+// describes the first element missing from original or the first pair of
+// equal elements that swapped places.
+func IsStableSorted[T any](original, sorted []T, less func(a, b T) bool) (bool, error) {
+	if len(sorted) != len(original) {
+		return false, fmt.Errorf("sorted has %d elements, original has %d", len(sorted), len(original))
+	}
+	for i := 1; i < len(sorted); i++ {
+		if less(sorted[i], sorted[i-1]) {
+			return false, &UnsortedError{Index: i, Prev: sorted[i-1], Next: sorted[i]}
+		}
+	}
+
+	// want holds the original positions in the one order a stable sort
+	// may produce, so each run of equal elements in sorted has to match
+	// the same run of want.
+	positions := make([]int, len(original))
+	for i := range positions {
+		positions[i] = i
+	}
+	want := MergeSortFunc(positions, func(a, b int) bool { return less(original[a], original[b]) })
+	equal := func(a, b T) bool { return !less(a, b) && !less(b, a) }
+
+	var unstable error
+	for lo := 0; lo < len(sorted); {
+		hi := lo + 1
+		for hi < len(sorted) && !less(sorted[lo], sorted[hi]) {
+			hi++
+		}
+		if !equal(original[want[lo]], sorted[lo]) || !equal(original[want[hi-1]], sorted[lo]) ||
+			hi < len(want) && equal(original[want[hi]], sorted[lo]) {
+			return false, fmt.Errorf("sorted is not a permutation of original: "+
+				"it has a different number of elements equal to %v", sorted[lo])
+		}
+		used := make([]bool, hi-lo)
+		next := 0 // first unused position of the run
+		for j := lo; j < hi; j++ {
+			k := next
+			for k < len(used) && (used[k] || !reflect.DeepEqual(original[want[lo+k]], sorted[j])) {
+				k++
+			}
+			if k == len(used) {
+				return false, fmt.Errorf("sorted is not a permutation of original: "+
+					"index %d: %v is not in original", j, sorted[j])
+			}
+			if k != next && unstable == nil {
+				unstable = fmt.Errorf("not stable: index %d: %v (originally at %d) comes before %v (originally at %d), which compares equal",
+					j, sorted[j], want[lo+k], original[want[lo+next]], want[lo+next])
+			}
+			used[k] = true
+			for next < len(used) && used[next] {
+				next++
+			}
+		}
+		lo = hi
+	}
+	if unstable != nil {
+		return false, unstable
+	}
+	return true, nil
+}
@@ -0,0 +1,85 @@
+//go:build !tinygo && !noreflect
+
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStableSorted(t *testing.T) {
+	type item struct {
+		Key  int
+		Name string
+	}
+	byKey := func(a, b item) bool { return a.Key < b.Key }
+	original := []item{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {3, "e"}}
+
+	type testCase struct {
+		Name     string
+		Sorted   []item
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := IsStableSorted(original, tc.Sorted, byKey)
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				require.True(t, actual)
+				return
+			}
+			require.EqualError(t, err, tc.Expected)
+			require.False(t, actual)
+		})
+	}
+	validate(t, testCase{Name: "Stable",
+		Sorted: []item{{1, "b"}, {1, "d"}, {2, "a"}, {2, "c"}, {3, "e"}},
+	})
+	validate(t, testCase{Name: "Ordered but not stable",
+		Sorted:   []item{{1, "b"}, {1, "d"}, {2, "c"}, {2, "a"}, {3, "e"}},
+		Expected: "not stable: index 2: {2 c} (originally at 2) comes before {2 a} (originally at 0), which compares equal",
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Sorted:   []item{{1, "b"}, {2, "a"}, {1, "d"}, {2, "c"}, {3, "e"}},
+		Expected: "index 2: {2 a} followed by {1 d}",
+	})
+	validate(t, testCase{Name: "Different element",
+		Sorted:   []item{{1, "b"}, {1, "d"}, {2, "a"}, {2, "x"}, {3, "e"}},
+		Expected: "sorted is not a permutation of original: index 3: {2 x} is not in original",
+	})
+	validate(t, testCase{Name: "Different counts",
+		Sorted:   []item{{1, "b"}, {1, "d"}, {2, "a"}, {3, "e"}, {3, "e"}},
+		Expected: "sorted is not a permutation of original: it has a different number of elements equal to {2 a}",
+	})
+	validate(t, testCase{Name: "Different length",
+		Sorted:   []item{{1, "b"}},
+		Expected: "sorted has 1 elements, original has 5",
+	})
+}
+
+func TestIsStableSortedIdenticalElements(t *testing.T) {
+	// Identical elements cannot be told apart, so any order of them is
+	// stable.
+	original := []int{3, 1, 3, 1}
+	ok, err := IsStableSorted(original, []int{1, 1, 3, 3}, func(a, b int) bool { return a < b })
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestIsStableSortedMergeSortFunc(t *testing.T) {
+	type tagged struct {
+		Key   int
+		Index int
+	}
+	data := make([]tagged, 100)
+	for i := range data {
+		data[i] = tagged{Key: (i * 7) % 5, Index: i}
+	}
+	less := func(a, b tagged) bool { return a.Key < b.Key }
+	ok, err := IsStableSorted(data, MergeSortFunc(data, less), less)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
@@ -0,0 +1,57 @@
+// Code generated by table2func from std_go_test.go; DO NOT EDIT.
+
+package testdemo
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+// TestStdGoIsSorted_IntNilTrue is case 1 of TestStdGoIsSorted.
+//
+// Standard Table Driven Tests
+func TestStdGoIsSorted_IntNilTrue(t *testing.T) {
+	test := struct {
+		input []int
+		want  bool
+	}{[]int(nil), true}
+	got := IsSorted(test.input)
+	require.Equal(t, test.want, got)
+}
+
+// TestStdGoIsSorted_Int0True is case 2 of TestStdGoIsSorted.
+//
+// Standard Table Driven Tests
+func TestStdGoIsSorted_Int0True(t *testing.T) {
+	test := struct {
+		input []int
+		want  bool
+	}{[]int{0}, true}
+	got := IsSorted(test.input)
+	require.Equal(t, test.want, got)
+}
+
+// TestStdGoIsSorted_Int0MathMinIntFalse is case 3 of TestStdGoIsSorted.
+//
+// Standard Table Driven Tests
+func TestStdGoIsSorted_Int0MathMinIntFalse(t *testing.T) {
+	test := struct {
+		input []int
+		want  bool
+	}{[]int{0, math.MinInt}, false}
+	got := IsSorted(test.input)
+	require.Equal(t, test.want, got)
+}
+
+// TestStdGoIsSorted_Int00True is case 4 of TestStdGoIsSorted.
+//
+// Standard Table Driven Tests
+func TestStdGoIsSorted_Int00True(t *testing.T) {
+	test := struct {
+		input []int
+		want  bool
+	}{[]int{0, 0}, true}
+	got := IsSorted(test.input)
+	require.Equal(t, test.want, got)
+}
@@ -1,10 +1,14 @@
 package testdemo
 
 import (
+	"github.com/StevenACoffman/testdemo/internal/testcases"
 	"github.com/stretchr/testify/require"
+	"math"
 	"testing"
 )
 
+//go:generate go run ./cmd/table2func std_go_test.go
+
 // Standard Table Driven Tests
 func TestStdGoIsSorted(t *testing.T) {
 	var tests = []struct {
@@ -13,7 +17,7 @@ func TestStdGoIsSorted(t *testing.T) {
 	}{
 		{[]int(nil), true},
 		{[]int{0}, true},
-		{[]int{0, -9223372036854775808}, false},
+		{[]int{0, math.MinInt}, false},
 		{[]int{0, 0}, true},
 	}
 	for _, test := range tests {
@@ -21,3 +25,11 @@ func TestStdGoIsSorted(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+// The same loop over the corpus every test style shares
+func TestStdGoIsSortedCorpus(t *testing.T) {
+	for _, test := range testcases.Cases() {
+		got := IsSorted(test.Data)
+		require.Equal(t, test.Sorted, got, test.Name)
+	}
+}
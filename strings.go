@@ -0,0 +1,146 @@
+package testdemo
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// StringOrderMode is an order strings can be compared in.
+type StringOrderMode int
+
+const (
+	// Bytes compares strings byte by byte, like the < operator. For valid
+	// UTF-8 this is the same as Runes.
+	Bytes StringOrderMode = iota
+	// Runes compares strings code point by code point. Each invalid UTF-8
+	// byte counts as utf8.RuneError (U+FFFD), so invalid strings may
+	// order differently than under Bytes.
+	Runes
+	// UTF16CodeUnits compares strings as their UTF-16 encodings, the way
+	// Java, JavaScript and Windows sort strings. Characters outside the
+	// Basic Multilingual Plane become surrogate pairs starting at 0xD800,
+	// so they sort before U+E000 to U+FFFF instead of after them. Invalid
+	// UTF-8 is treated as under Runes.
+	UTF16CodeUnits
+	// Natural compares strings as CompareNatural does, so that "file9"
+	// sorts before "file10".
+	Natural
+)
+
+// IsSortedStringsBy reports whether data is in non-decreasing order
+// under mode.
+func IsSortedStringsBy(data []string, mode StringOrderMode) bool {
+	compare := strings.Compare
+	switch mode {
+	case Runes:
+		compare = compareRunes
+	case UTF16CodeUnits:
+		compare = CompareUTF16
+	case Natural:
+		compare = CompareNatural
+	}
+	for i := 1; i < len(data); i++ {
+		if compare(data[i-1], data[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareUTF16 compares a and b in UTF16CodeUnits order, returning -1, 0
+// or +1 like strings.Compare. It does not allocate.
+func CompareUTF16(a, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		a, b = a[na:], b[nb:]
+		if ra == rb {
+			continue
+		}
+		ha, la := utf16.EncodeRune(ra)
+		hb, lb := utf16.EncodeRune(rb)
+		if ha == utf8.RuneError {
+			// Not a supplementary character, so it is a single code unit.
+			ha, la = ra, 0
+		}
+		if hb == utf8.RuneError {
+			hb, lb = rb, 0
+		}
+		if ha != hb {
+			return sign(ha - hb)
+		}
+		return sign(la - lb)
+	}
+	return sign(rune(len(a)) - rune(len(b)))
+}
+
+// CompareNatural compares a and b in natural order, returning -1, 0 or
+// +1 like strings.Compare: runs of ASCII digits compare by their numeric
+// value, however long, and everything else byte by byte. Numbers that
+// differ only in leading zeros are equal at first; if nothing else tells
+// the strings apart, they are ordered as by strings.Compare, so only
+// equal strings compare equal.
+func CompareNatural(a, b string) int {
+	origA, origB := a, b
+	for len(a) > 0 && len(b) > 0 {
+		if !isASCIIDigit(a[0]) || !isASCIIDigit(b[0]) {
+			if a[0] != b[0] {
+				return sign(rune(a[0]) - rune(b[0]))
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		na, nb := digitRun(a), digitRun(b)
+		// With leading zeros trimmed, a longer number is a larger one, and
+		// numbers of equal length compare like strings.
+		da, db := strings.TrimLeft(a[:na], "0"), strings.TrimLeft(b[:nb], "0")
+		if len(da) != len(db) {
+			return sign(rune(len(da) - len(db)))
+		}
+		if c := strings.Compare(da, db); c != 0 {
+			return c
+		}
+		a, b = a[na:], b[nb:]
+	}
+	if len(a) != len(b) {
+		return sign(rune(len(a) - len(b)))
+	}
+	return strings.Compare(origA, origB)
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digitRun returns the length of the run of ASCII digits s starts with.
+func digitRun(s string) int {
+	n := 0
+	for n < len(s) && isASCIIDigit(s[n]) {
+		n++
+	}
+	return n
+}
+
+// compareRunes compares a and b code point by code point.
+func compareRunes(a, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		a, b = a[na:], b[nb:]
+		if ra != rb {
+			return sign(ra - rb)
+		}
+	}
+	return sign(rune(len(a)) - rune(len(b)))
+}
+
+func sign(d rune) int {
+	switch {
+	case d < 0:
+		return -1
+	case d > 0:
+		return 1
+	}
+	return 0
+}
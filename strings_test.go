@@ -0,0 +1,104 @@
+package testdemo
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedStringsBy(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []string
+		Expected map[StringOrderMode]bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			for _, mode := range []StringOrderMode{Bytes, Runes, UTF16CodeUnits, Natural} {
+				require.Equal(t, tc.Expected[mode], IsSortedStringsBy(tc.Data, mode), "mode %d", mode)
+			}
+		})
+	}
+	all := map[StringOrderMode]bool{Bytes: true, Runes: true, UTF16CodeUnits: true, Natural: true}
+	validate(t, testCase{Name: "Empty",
+		Data:     []string{},
+		Expected: all,
+	})
+	validate(t, testCase{Name: "ASCII",
+		Data:     []string{"", "a", "ab", "b"},
+		Expected: all,
+	})
+	validate(t, testCase{Name: "BMP before supplementary",
+		// U+FF5E FULLWIDTH TILDE is a single code unit above the
+		// surrogates that encode U+1F600.
+		Data:     []string{"～", "\U0001F600"},
+		Expected: map[StringOrderMode]bool{Bytes: true, Runes: true, Natural: true},
+	})
+	validate(t, testCase{Name: "Supplementary before BMP",
+		Data:     []string{"\U0001F600", "～"},
+		Expected: map[StringOrderMode]bool{UTF16CodeUnits: true},
+	})
+	validate(t, testCase{Name: "Emoji",
+		Data:     []string{"x\U0001F600", "x\U0001F601", "x\U0001F601y"},
+		Expected: all,
+	})
+	validate(t, testCase{Name: "Invalid UTF-8",
+		// The invalid byte 0xff counts as U+FFFD, which is less than
+		// U+10000 as a rune, but not as bytes or UTF-16.
+		Data:     []string{"\U00010000", "\xff"},
+		Expected: map[StringOrderMode]bool{Bytes: true, UTF16CodeUnits: true, Natural: true},
+	})
+	validate(t, testCase{Name: "Numbered files",
+		Data:     []string{"file2", "file9", "file10"},
+		Expected: map[StringOrderMode]bool{Natural: true},
+	})
+}
+
+func TestCompareUTF16(t *testing.T) {
+	require.Equal(t, 0, CompareUTF16("a\U0001F600", "a\U0001F600"))
+	require.Equal(t, -1, CompareUTF16("a", "a\U0001F600"))
+	require.Equal(t, 1, CompareUTF16("～", "\U0001F600"))
+	require.Equal(t, -1, CompareUTF16("\U0001F600", "\U0001F601"))
+	require.Equal(t, -1, CompareUTF16("\U0001F600", "\U0010FFFF"))
+	require.Equal(t, 1, CompareUTF16("b", "a\U0001F600"))
+}
+
+func TestCompareNatural(t *testing.T) {
+	require.Equal(t, 0, CompareNatural("a10b", "a10b"))
+	require.Equal(t, -1, CompareNatural("a9", "a10"))
+	require.Equal(t, 1, CompareNatural("a10", "a9"))
+	require.Equal(t, -1, CompareNatural("v1.9.2", "v1.10.0"))
+	require.Equal(t, -1, CompareNatural("x", "x1"))
+	require.Equal(t, -1, CompareNatural("1", "a"))
+	require.Equal(t, 1, CompareNatural("99999999999999999999999", "99999999999999999999998"))
+	// Leading zeros only decide between strings otherwise equal.
+	require.Equal(t, -1, CompareNatural("a007", "a7"))
+	require.Equal(t, -1, CompareNatural("a007b", "a7c"))
+
+	sample := []string{"", "0", "00", "1", "01", "10", "a", "a0", "a00", "a1", "a01b", "a1a", "a10", "b", "9b", "09", "z9z", "z10"}
+	require.NoError(t, ValidateLess(sample, CompareToLess(CompareNatural)))
+}
+
+func TestBytesAndRunesAgreeOnValidUTF8(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	alphabet := []rune{'a', 'z', 'é', '߿', 'ࠀ', '￿', '\U00010000', '\U0010FFFF'}
+	randomString := func() string {
+		var sb strings.Builder
+		for n := rng.Intn(4); n > 0; n-- {
+			sb.WriteRune(alphabet[rng.Intn(len(alphabet))])
+		}
+		return sb.String()
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := randomString(), randomString()
+		require.True(t, utf8.ValidString(a) && utf8.ValidString(b))
+		require.Equal(t, strings.Compare(a, b), compareRunes(a, b), "%q %q", a, b)
+		data := []string{a, b}
+		require.Equal(t, IsSortedStringsBy(data, Bytes), IsSortedStringsBy(data, Runes), "%q", data)
+	}
+}
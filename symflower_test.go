@@ -1,6 +1,7 @@
 package testdemo
 
 import (
+	"github.com/StevenACoffman/testdemo/internal/testcases"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -36,4 +37,12 @@ func TestIsSorted(t *testing.T) {
 		Array:    []int{1, 0},
 		Expected: false, // actually false, but we want to see failures
 	})
+	t.Run("Corpus", func(t *testing.T) {
+		for _, c := range testcases.Cases() {
+			validate(t, testCase{Name: c.Name,
+				Array:    c.Data,
+				Expected: c.Sorted,
+			})
+		}
+	})
 }
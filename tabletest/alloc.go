@@ -0,0 +1,69 @@
+package tabletest
+
+import "testing"
+
+// NoAllocBudget is the tag, read with the Tags option, of the cases
+// AllocBudget leaves unmeasured.
+const NoAllocBudget = "no-alloc-budget"
+
+// allocRuns is how many runs of the body testing.AllocsPerRun averages.
+const allocRuns = 100
+
+// AllocBudget fails every case whose body allocates more than
+// allocsPerOp times on average, as measured by testing.AllocsPerRun, and
+// logs the measured value. Cases tagged NoAllocBudget are not measured.
+//
+// The measurement runs the body allocRuns times plus one warm-up run,
+// so bodies must be idempotent: each run has to start from the same
+// state and leave it unchanged. The Duration reported for the case
+// covers all of the runs.
+//
+// testing.AllocsPerRun counts the allocations of the whole process, so
+// AllocBudget cannot be combined with Parallel: Run panics when both are
+// given.
+func AllocBudget[C any](allocsPerOp float64) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.allocBudget = allocsPerOp
+		cfg.allocCheck = true
+	}
+}
+
+// checkOptions panics on options that cannot be used together.
+func (cfg *config[C]) checkOptions() {
+	if cfg.allocCheck && cfg.parallel {
+		panic("tabletest: AllocBudget cannot be combined with Parallel, as allocations are counted for the whole process")
+	}
+	if cfg.leakCheck && cfg.parallel {
+		panic("tabletest: LeakCheck cannot be combined with Parallel, as goroutines are checked for the whole process")
+	}
+}
+
+// hasTag reports whether tc is tagged with tag.
+func (cfg *config[C]) hasTag(tc C, tag string) bool {
+	if cfg.tags == nil {
+		return false
+	}
+	for _, t := range cfg.tags(tc) {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// measureAllocs returns body wrapped to fail t when it allocates over
+// the budget, or body itself when tc is not measured.
+func (cfg *config[C]) measureAllocs(t *testing.T, tc C, body func()) func() {
+	if !cfg.allocCheck || cfg.hasTag(tc, NoAllocBudget) {
+		return body
+	}
+	return func() {
+		t.Helper()
+		allocs := testing.AllocsPerRun(allocRuns, body)
+		if allocs > cfg.allocBudget {
+			t.Errorf("tabletest: case allocated %v times per run, over the budget of %v", allocs, cfg.allocBudget)
+			return
+		}
+		t.Logf("tabletest: case allocated %v times per run, within the budget of %v", allocs, cfg.allocBudget)
+	}
+}
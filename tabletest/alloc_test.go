@@ -0,0 +1,84 @@
+package tabletest
+
+import (
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// allocHelperEnv makes the test binary run TestAllocBudgetHelper when it
+// is re-executed by TestAllocBudgetFails.
+const allocHelperEnv = "TABLETEST_ALLOC_HELPER"
+
+// allocSink keeps allocations from being optimized away.
+var allocSink []byte
+
+type allocCase struct {
+	Name string
+	Data []int
+	// Allocs is how many times the body allocates.
+	Allocs int
+	Tags   []string
+}
+
+func allocBody(t *testing.T, tc allocCase) {
+	for range tc.Allocs {
+		allocSink = make([]byte, 64)
+	}
+	if !sort.IntsAreSorted(tc.Data) {
+		t.Errorf("%v is not sorted", tc.Data)
+	}
+}
+
+func TestAllocBudget(t *testing.T) {
+	runs := map[string]int{}
+	t.Run("table", func(t *testing.T) {
+		Run(t, []allocCase{
+			{Name: "zero allocs", Data: []int{1, 2, 3}},
+			{Name: "opted out", Data: []int{1, 2, 3}, Allocs: 3, Tags: []string{NoAllocBudget}},
+		}, func(tc allocCase) string { return tc.Name }, func(t *testing.T, tc allocCase) {
+			runs[tc.Name]++
+			allocBody(t, tc)
+		}, AllocBudget[allocCase](0), Tags(func(tc allocCase) []string { return tc.Tags }))
+	})
+	require.Equal(t, map[string]int{"zero allocs": allocRuns + 1, "opted out": 1}, runs)
+}
+
+func TestAllocBudgetRejectsParallel(t *testing.T) {
+	require.PanicsWithValue(t, "tabletest: AllocBudget cannot be combined with Parallel, as allocations are counted for the whole process", func() {
+		Run(t, []allocCase{{Name: "a"}}, func(tc allocCase) string { return tc.Name }, allocBody,
+			Parallel[allocCase](), AllocBudget[allocCase](0))
+	})
+}
+
+// TestAllocBudgetHelper is not a real test: it provides a table for
+// TestAllocBudgetFails to run in a child process.
+func TestAllocBudgetHelper(t *testing.T) {
+	if os.Getenv(allocHelperEnv) == "" {
+		t.Skip("only run as a helper process")
+	}
+	Run(t, []allocCase{
+		{Name: "within", Allocs: 1},
+		{Name: "over", Allocs: 2},
+	}, func(tc allocCase) string { return tc.Name }, allocBody, AllocBudget[allocCase](1))
+}
+
+func TestAllocBudgetFails(t *testing.T) {
+	if os.Getenv(allocHelperEnv) != "" {
+		t.Skip("running as a helper process")
+	}
+	cmd := exec.Command(os.Args[0], "-test.run", "^TestAllocBudgetHelper$", "-test.v")
+	cmd.Env = append(os.Environ(), allocHelperEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err, "%s", out)
+	output := string(out)
+	require.Contains(t, output, "--- PASS: TestAllocBudgetHelper/within")
+	require.Contains(t, output, "tabletest: case allocated 1 times per run, within the budget of 1")
+	require.Contains(t, output, "--- FAIL: TestAllocBudgetHelper/over")
+	require.Contains(t, output, "tabletest: case allocated 2 times per run, over the budget of 1")
+	require.Equal(t, 1, strings.Count(output, "--- FAIL: TestAllocBudgetHelper/"), output)
+}
@@ -0,0 +1,50 @@
+package tabletest
+
+import "testing"
+
+// benchRunner is the part of *testing.B the benchmark runner needs, so
+// tests can record sub-benchmarks without running them for real.
+type benchRunner interface {
+	Run(name string, f func(b *testing.B)) bool
+	Log(args ...any)
+	Name() string
+}
+
+// Bytes reports the number of bytes processed by a single iteration of
+// a case, which is passed to b.SetBytes so the output includes MB/s.
+func Bytes[C any](bytes func(C) int64) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.bytes = bytes
+	}
+}
+
+// RunBenchTable runs fn for every case as a sub-benchmark of b named by
+// name, sanitized and deduplicated the same way as Run. Cases skipped by
+// tag are not registered as sub-benchmarks at all, so they do not show
+// up in the benchmark output. Case bodies run with pprof labels as in
+// Run.
+func RunBenchTable[C any](b *testing.B, cases []C, name func(C) string, fn func(b *testing.B, c C), opts ...Option[C]) {
+	b.Helper()
+	runBenchTable(b, cases, name, fn, newConfig(opts))
+}
+
+func runBenchTable[C any](b benchRunner, cases []C, name func(C) string, fn func(b *testing.B, c C), cfg *config[C]) {
+	names, warnings := uniqueNames(cases, name)
+	for _, warning := range warnings {
+		b.Log("tabletest: " + warning)
+	}
+	for i, tc := range cases {
+		tc := tc
+		if _, ok := cfg.skipTag(tc); ok {
+			continue
+		}
+		name := names[i]
+		parent := b
+		b.Run(name, func(b *testing.B) {
+			if cfg.bytes != nil {
+				cfg.setBytes(b, cfg.bytes(tc))
+			}
+			cfg.runBody(parent, b, name, func() { fn(b, tc) })
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package tabletest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBench records the sub-benchmarks registered on it and runs each
+// body once.
+type fakeBench struct {
+	names   []string
+	logs    []string
+	current string
+}
+
+func (f *fakeBench) Log(args ...any) {
+	f.logs = append(f.logs, fmt.Sprint(args...))
+}
+
+func (f *fakeBench) Name() string {
+	return "BenchmarkFake"
+}
+
+func (f *fakeBench) Run(name string, fn func(b *testing.B)) bool {
+	f.names = append(f.names, name)
+	f.current = name
+	fn(&testing.B{N: 1})
+	return true
+}
+
+func TestRunBenchTable(t *testing.T) {
+	type benchCase struct {
+		Name string
+		Size int64
+		Tags []string
+	}
+	type testCase struct {
+		Name          string
+		Cases         []benchCase
+		Opts          []Option[benchCase]
+		ExpectedNames []string
+		ExpectedBytes map[string]int64
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			fake := &fakeBench{}
+			bytes := map[string]int64{}
+			ran := map[string]int{}
+			cfg := newConfig(tc.Opts)
+			cfg.setBytes = func(_ *testing.B, n int64) {
+				bytes[fake.current] = n
+			}
+			runBenchTable(fake, tc.Cases, func(c benchCase) string { return c.Name }, func(b *testing.B, c benchCase) {
+				ran[fake.current] += b.N
+			}, cfg)
+			require.Equal(t, tc.ExpectedNames, fake.names)
+			require.Equal(t, tc.ExpectedBytes, bytes)
+			for _, name := range tc.ExpectedNames {
+				require.Equal(t, 1, ran[name])
+			}
+		})
+	}
+	cases := []benchCase{
+		{Name: "Small", Size: 8},
+		{Name: "Large", Size: 8000, Tags: []string{"slow"}},
+	}
+	validate(t, testCase{Name: "Names only",
+		Cases:         cases,
+		ExpectedNames: []string{"Small", "Large"},
+		ExpectedBytes: map[string]int64{},
+	})
+	validate(t, testCase{Name: "Bytes",
+		Cases:         cases,
+		Opts:          []Option[benchCase]{Bytes(func(c benchCase) int64 { return c.Size })},
+		ExpectedNames: []string{"Small", "Large"},
+		ExpectedBytes: map[string]int64{"Small": 8, "Large": 8000},
+	})
+	validate(t, testCase{Name: "Skip by tag",
+		Cases: cases,
+		Opts: []Option[benchCase]{
+			Bytes(func(c benchCase) int64 { return c.Size }),
+			Tags(func(c benchCase) []string { return c.Tags }, "slow"),
+		},
+		ExpectedNames: []string{"Small"},
+		ExpectedBytes: map[string]int64{"Small": 8},
+	})
+	validate(t, testCase{Name: "Unrelated skip tag",
+		Cases:         cases,
+		Opts:          []Option[benchCase]{Tags(func(c benchCase) []string { return c.Tags }, "flaky")},
+		ExpectedNames: []string{"Small", "Large"},
+		ExpectedBytes: map[string]int64{},
+	})
+	validate(t, testCase{Name: "Sanitized and deduplicated",
+		Cases:         []benchCase{{Name: "Two elements"}, {Name: "Two elements"}},
+		ExpectedNames: []string{"Two_elements", "Two_elements#2"},
+		ExpectedBytes: map[string]int64{},
+	})
+}
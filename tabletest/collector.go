@@ -0,0 +1,176 @@
+package tabletest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Outcome is how a single case ended.
+type Outcome string
+
+const (
+	Passed  Outcome = "pass"
+	Failed  Outcome = "fail"
+	Skipped Outcome = "skip"
+)
+
+// CaseResult is what the runner reports about a single case.
+type CaseResult struct {
+	// Name is the full subtest name, e.g. "TestIsSorted/Empty".
+	Name string `json:"name"`
+	// Duration is the wall time of the case body alone.
+	Duration time.Duration `json:"duration_ns"`
+	// HookDuration is the wall time spent in BeforeEach and AfterEach.
+	HookDuration time.Duration `json:"hook_duration_ns"`
+	Outcome      Outcome       `json:"outcome"`
+	// Threshold is the WarnSlower or FailSlower limit the case went over,
+	// or 0 when it did not go over one.
+	Threshold time.Duration `json:"threshold_ns,omitempty"`
+}
+
+// slowestCount is how many cases the summary lists as the slowest.
+const slowestCount = 10
+
+// QuietEnv is the environment variable that, when set to a non-empty
+// value, stops MainSummary from printing the summary.
+const QuietEnv = "TABLETEST_QUIET"
+
+// Collector aggregates case results from any number of tests. It is safe
+// for concurrent use, since cases may run in parallel.
+type Collector struct {
+	mu      sync.Mutex
+	results []CaseResult
+}
+
+// DefaultCollector is the collector Run reports into unless the Collect
+// option says otherwise, and the one MainSummary prints.
+var DefaultCollector = &Collector{}
+
+// Report records the result of a single case.
+func (c *Collector) Report(r CaseResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = append(c.results, r)
+}
+
+// Results returns the results reported so far, sorted by name so the
+// order does not depend on how parallel cases were scheduled.
+func (c *Collector) Results() []CaseResult {
+	c.mu.Lock()
+	results := append([]CaseResult(nil), c.results...)
+	c.mu.Unlock()
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// WriteResultsJSON writes Results as a JSON array, e.g. for uploading as
+// a CI artifact.
+func (c *Collector) WriteResultsJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.Results())
+}
+
+// Results returns the results reported to DefaultCollector.
+func Results() []CaseResult {
+	return DefaultCollector.Results()
+}
+
+// WriteResultsJSON writes the results reported to DefaultCollector as a
+// JSON array.
+func WriteResultsJSON(w io.Writer) error {
+	return DefaultCollector.WriteResultsJSON(w)
+}
+
+// WriteSummary writes the number of cases, failures and skips, followed
+// by the slowest cases, slowest first, and all the cases that went over
+// their WarnSlower or FailSlower threshold. Nothing is written when no
+// case was reported.
+func (c *Collector) WriteSummary(w io.Writer) error {
+	c.mu.Lock()
+	results := append([]CaseResult(nil), c.results...)
+	c.mu.Unlock()
+	total := len(results)
+	if total == 0 {
+		return nil
+	}
+
+	var failed, skipped int
+	for _, r := range results {
+		switch r.Outcome {
+		case Failed:
+			failed++
+		case Skipped:
+			skipped++
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Duration != results[j].Duration {
+			return results[i].Duration > results[j].Duration
+		}
+		return results[i].Name < results[j].Name
+	})
+	var over []CaseResult
+	for _, r := range results {
+		if r.Threshold > 0 {
+			over = append(over, r)
+		}
+	}
+	if len(results) > slowestCount {
+		results = results[:slowestCount]
+	}
+
+	if _, err := fmt.Fprintf(w, "tabletest: %d cases, %d failed, %d skipped\n", total, failed, skipped); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "tabletest: slowest cases:"); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if _, err := fmt.Fprintf(w, "  %10s  %-4s  %s\n", r.Duration.Round(time.Microsecond), r.Outcome, r.Name); err != nil {
+			return err
+		}
+	}
+	if len(over) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, "tabletest: cases over their slow threshold:"); err != nil {
+		return err
+	}
+	for _, r := range over {
+		if _, err := fmt.Fprintf(w, "  %10s  %-4s  %s (threshold %s)\n", r.Duration.Round(time.Microsecond), r.Outcome, r.Name, r.Threshold); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Collect makes the runner report into c instead of DefaultCollector.
+func Collect[C any](c *Collector) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.collector = c
+	}
+}
+
+// MainSummary runs the tests and then prints the summary of
+// DefaultCollector to stderr, unless QuietEnv is set. Use it from
+// TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(tabletest.MainSummary(m))
+//	}
+func MainSummary(m *testing.M) int {
+	code := m.Run()
+	if os.Getenv(QuietEnv) == "" {
+		_ = DefaultCollector.WriteSummary(os.Stderr)
+	}
+	return code
+}
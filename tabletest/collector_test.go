@@ -0,0 +1,168 @@
+package tabletest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorSummary(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Results  []CaseResult
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			c := &Collector{}
+			for _, r := range tc.Results {
+				c.Report(r)
+			}
+			var sb strings.Builder
+			require.NoError(t, c.WriteSummary(&sb))
+			require.Equal(t, tc.Expected, sb.String())
+		})
+	}
+	validate(t, testCase{Name: "Nothing reported",
+		Expected: "",
+	})
+	validate(t, testCase{Name: "Counts and ordering",
+		Results: []CaseResult{
+			{Name: "TestA/fast", Duration: time.Millisecond, Outcome: Passed},
+			{Name: "TestA/slow", Duration: 2 * time.Second, Outcome: Failed},
+			{Name: "TestB/skipped", Outcome: Skipped},
+			{Name: "TestB/tie b", Duration: 5 * time.Millisecond, Outcome: Passed},
+			{Name: "TestB/tie a", Duration: 5 * time.Millisecond, Outcome: Passed},
+		},
+		Expected: "tabletest: 5 cases, 1 failed, 1 skipped\n" +
+			"tabletest: slowest cases:\n" +
+			"          2s  fail  TestA/slow\n" +
+			"         5ms  pass  TestB/tie a\n" +
+			"         5ms  pass  TestB/tie b\n" +
+			"         1ms  pass  TestA/fast\n" +
+			"          0s  skip  TestB/skipped\n",
+	})
+	validate(t, testCase{Name: "Over the slow threshold",
+		Results: []CaseResult{
+			{Name: "TestA/fast", Duration: time.Millisecond, Outcome: Passed},
+			{Name: "TestA/warned", Duration: 30 * time.Millisecond, Outcome: Passed, Threshold: 20 * time.Millisecond},
+			{Name: "TestA/failed", Duration: 2 * time.Second, Outcome: Failed, Threshold: time.Second},
+		},
+		Expected: "tabletest: 3 cases, 1 failed, 0 skipped\n" +
+			"tabletest: slowest cases:\n" +
+			"          2s  fail  TestA/failed\n" +
+			"        30ms  pass  TestA/warned\n" +
+			"         1ms  pass  TestA/fast\n" +
+			"tabletest: cases over their slow threshold:\n" +
+			"          2s  fail  TestA/failed (threshold 1s)\n" +
+			"        30ms  pass  TestA/warned (threshold 20ms)\n",
+	})
+}
+
+func TestCollectorSlowestTen(t *testing.T) {
+	c := &Collector{}
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Report(CaseResult{Name: fmt.Sprintf("TestX/%02d", i), Duration: time.Duration(i) * time.Millisecond, Outcome: Passed})
+		}(i)
+	}
+	wg.Wait()
+
+	var sb strings.Builder
+	require.NoError(t, c.WriteSummary(&sb))
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	require.Equal(t, "tabletest: 50 cases, 0 failed, 0 skipped", lines[0])
+	require.Len(t, lines, 2+slowestCount)
+	for i, line := range lines[2:] {
+		require.True(t, strings.HasSuffix(line, fmt.Sprintf("TestX/%02d", 50-i)), line)
+	}
+}
+
+func TestRunReportsToCollector(t *testing.T) {
+	type tableCase struct {
+		Name string
+		Tags []string
+	}
+	c := &Collector{}
+	t.Run("table", func(t *testing.T) {
+		Run(t, []tableCase{{Name: "a"}, {Name: "b"}, {Name: "c", Tags: []string{"slow"}}}, func(c tableCase) string { return c.Name }, func(t *testing.T, c tableCase) {},
+			Collect[tableCase](c), Parallel[tableCase](), Tags(func(c tableCase) []string { return c.Tags }, "slow"))
+	})
+	var sb strings.Builder
+	require.NoError(t, c.WriteSummary(&sb))
+	require.True(t, strings.HasPrefix(sb.String(), "tabletest: 3 cases, 0 failed, 1 skipped\n"), sb.String())
+}
+
+func TestRunRecordsDurations(t *testing.T) {
+	type tableCase struct {
+		Name  string
+		Sleep time.Duration
+		Skip  bool
+	}
+	const hookSleep = 100 * time.Millisecond
+	c := &Collector{}
+	t.Run("table", func(t *testing.T) {
+		sleep := func(t *testing.T, c tableCase) { time.Sleep(hookSleep / 2) }
+		Run(t, []tableCase{
+			{Name: "c", Sleep: 40 * time.Millisecond},
+			{Name: "a", Sleep: time.Millisecond},
+			{Name: "skipped", Sleep: time.Second, Skip: true},
+			{Name: "b", Sleep: 20 * time.Millisecond},
+		}, func(c tableCase) string { return c.Name }, func(t *testing.T, c tableCase) {
+			if c.Skip {
+				t.Skip()
+			}
+			time.Sleep(c.Sleep)
+		}, Collect[tableCase](c), Parallel[tableCase](), BeforeEach(sleep), AfterEach(sleep))
+	})
+
+	results := c.Results()
+	require.Len(t, results, 4)
+	byName := map[string]CaseResult{}
+	for _, r := range results {
+		byName[strings.TrimPrefix(r.Name, "TestRunRecordsDurations/table/")] = r
+	}
+	a, b, cc := byName["a"], byName["b"], byName["c"]
+	require.Less(t, a.Duration, b.Duration)
+	require.Less(t, b.Duration, cc.Duration)
+	for _, r := range []CaseResult{a, b, cc} {
+		require.Equal(t, Passed, r.Outcome)
+		require.Less(t, r.Duration, hookSleep)
+		require.GreaterOrEqual(t, r.HookDuration, hookSleep)
+	}
+	require.Equal(t, Skipped, byName["skipped"].Outcome)
+	require.Less(t, byName["skipped"].Duration, time.Second)
+
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+	require.IsIncreasing(t, names)
+}
+
+func TestWriteResultsJSON(t *testing.T) {
+	c := &Collector{}
+	c.Report(CaseResult{Name: "TestA/b", Duration: 1500, HookDuration: 20, Outcome: Failed, Threshold: 1000})
+	c.Report(CaseResult{Name: "TestA/a", Duration: 3, Outcome: Passed})
+
+	var sb strings.Builder
+	require.NoError(t, c.WriteResultsJSON(&sb))
+	require.JSONEq(t, `[
+		{"name": "TestA/a", "duration_ns": 3, "hook_duration_ns": 0, "outcome": "pass"},
+		{"name": "TestA/b", "duration_ns": 1500, "hook_duration_ns": 20, "outcome": "fail", "threshold_ns": 1000}
+	]`, sb.String())
+
+	var decoded []CaseResult
+	require.NoError(t, json.Unmarshal([]byte(sb.String()), &decoded))
+	require.Equal(t, c.Results(), decoded)
+}
@@ -0,0 +1,95 @@
+package tabletest
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// BenchComparator benchmarks less, a comparator users supply to sort or
+// check their data, and reports what a single comparison costs. Each
+// iteration checks a sorted copy of sample is sorted, which takes
+// len(sample)-1 comparisons, then sorts a fresh copy of sample with
+// slices.SortFunc. Besides ns/op for both, it reports the custom metrics
+// check-ns/comparison and check-comparisons/element for the check, and
+// sort-ns/comparison and sort-comparisons/element for the sort, timing
+// only the passes themselves, not the copying between them.
+func BenchComparator[T any](b *testing.B, sample []T, less func(a, b T) bool) {
+	b.Helper()
+	var comparisons int64
+	counting := countingLess(less, &comparisons)
+	compare := lessToCompare(counting)
+	sorted := slices.Clone(sample)
+	slices.SortFunc(sorted, lessToCompare(less))
+	work := make([]T, len(sample))
+
+	var check, sort passStats
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		comparisons = 0
+		start := time.Now()
+		isSortedFunc(sorted, counting)
+		check.add(time.Since(start), comparisons)
+
+		copy(work, sample)
+		comparisons = 0
+		start = time.Now()
+		slices.SortFunc(work, compare)
+		sort.add(time.Since(start), comparisons)
+	}
+	check.report(b, "check", b.N*len(sample))
+	sort.report(b, "sort", b.N*len(sample))
+}
+
+// passStats totals the time and comparisons of one kind of pass.
+type passStats struct {
+	elapsed     time.Duration
+	comparisons int64
+}
+
+func (p *passStats) add(elapsed time.Duration, comparisons int64) {
+	p.elapsed += elapsed
+	p.comparisons += comparisons
+}
+
+func (p *passStats) report(b *testing.B, prefix string, elements int) {
+	if p.comparisons > 0 {
+		b.ReportMetric(float64(p.elapsed.Nanoseconds())/float64(p.comparisons), prefix+"-ns/comparison")
+	}
+	if elements > 0 {
+		b.ReportMetric(float64(p.comparisons)/float64(elements), prefix+"-comparisons/element")
+	}
+}
+
+// countingLess returns less, counting its calls in *n.
+func countingLess[T any](less func(a, b T) bool, n *int64) func(a, b T) bool {
+	return func(a, b T) bool {
+		*n++
+		return less(a, b)
+	}
+}
+
+// lessToCompare turns less into a comparator for slices.SortFunc.
+func lessToCompare[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// isSortedFunc reports whether data is sorted by less, comparing each
+// adjacent pair once.
+func isSortedFunc[T any](data []T, less func(a, b T) bool) bool {
+	for i := 1; i < len(data); i++ {
+		if less(data[i], data[i-1]) {
+			return false
+		}
+	}
+	return true
+}
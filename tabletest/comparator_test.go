@@ -0,0 +1,41 @@
+package tabletest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedFuncComparisons(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = i
+		}
+		var comparisons int64
+		require.True(t, isSortedFunc(data, countingLess(less, &comparisons)))
+		require.Equal(t, int64(max(n-1, 0)), comparisons, "n=%d", n)
+	}
+
+	var comparisons int64
+	require.False(t, isSortedFunc([]int{1, 3, 2, 4}, countingLess(less, &comparisons)))
+	require.Equal(t, int64(2), comparisons)
+}
+
+func TestBenchComparatorMetrics(t *testing.T) {
+	sample := make([]int, 100)
+	for i := range sample {
+		sample[i] = (i * 37) % len(sample)
+	}
+	result := testing.Benchmark(func(b *testing.B) {
+		BenchComparator(b, sample, func(a, b int) bool { return a < b })
+	})
+	require.Equal(t, 99.0/100, result.Extra["check-comparisons/element"])
+	require.Greater(t, result.Extra["check-ns/comparison"], 0.0)
+	// Sorting takes at least one comparison per element and, with
+	// pattern-defeating quicksort, O(log n) of them.
+	require.GreaterOrEqual(t, result.Extra["sort-comparisons/element"], 1.0)
+	require.Less(t, result.Extra["sort-comparisons/element"], 30.0)
+	require.Greater(t, result.Extra["sort-ns/comparison"], 0.0)
+}
@@ -0,0 +1,53 @@
+package tabletest
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+	"testing"
+)
+
+// caseContexts maps the *testing.T or *testing.B of a running case to the
+// context carrying its pprof labels.
+var caseContexts sync.Map
+
+// NoLabels stops the runner from applying pprof labels to case bodies.
+func NoLabels[C any]() Option[C] {
+	return func(cfg *config[C]) {
+		cfg.noLabels = true
+	}
+}
+
+// Context returns the context carrying the pprof labels of the case tb is
+// running, or context.Background() when tb is not running a case. The
+// labels are "test", the test the table belongs to, and "case", the case
+// name, which for tables run from within another case is the outer case
+// name and the inner one joined by "/".
+func Context(tb testing.TB) context.Context {
+	return caseContext(tb)
+}
+
+func caseContext(key any) context.Context {
+	if ctx, ok := caseContexts.Load(key); ok {
+		return ctx.(context.Context)
+	}
+	return context.Background()
+}
+
+// withLabels runs body with the pprof labels of the case called name,
+// run by tb as part of the table run by parent.
+func withLabels(parent interface{ Name() string }, tb any, name string, body func()) {
+	ctx := caseContext(parent)
+	test, ok := pprof.Label(ctx, "test")
+	if !ok {
+		test = parent.Name()
+	}
+	if outer, ok := pprof.Label(ctx, "case"); ok {
+		name = outer + "/" + name
+	}
+	pprof.Do(ctx, pprof.Labels("case", name, "test", test), func(ctx context.Context) {
+		caseContexts.Store(tb, ctx)
+		defer caseContexts.Delete(tb)
+		body()
+	})
+}
@@ -0,0 +1,100 @@
+package tabletest
+
+import (
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func labelsOf(ctx context.Context) map[string]string {
+	labels := map[string]string{}
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		labels[key] = value
+		return true
+	})
+	return labels
+}
+
+// goroutineProfile returns the goroutine profile, which lists the labels
+// goroutines are currently running with.
+func goroutineProfile(t *testing.T) string {
+	t.Helper()
+	var sb strings.Builder
+	require.NoError(t, pprof.Lookup("goroutine").WriteTo(&sb, 1))
+	return sb.String()
+}
+
+func TestRunLabels(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Run      func(t *testing.T, record func(t *testing.T))
+		Expected []map[string]string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			var got []map[string]string
+			tc.Run(t, func(t *testing.T) {
+				got = append(got, labelsOf(Context(t)))
+			})
+			require.Equal(t, tc.Expected, got)
+			require.Empty(t, labelsOf(Context(t)))
+		})
+	}
+	validate(t, testCase{Name: "Labels per case",
+		Run: func(t *testing.T, record func(t *testing.T)) {
+			Run(t, []string{"Empty", "Two elements"}, func(s string) string { return s }, func(t *testing.T, _ string) {
+				record(t)
+			})
+		},
+		Expected: []map[string]string{
+			{"case": "Empty", "test": "TestRunLabels/Labels_per_case"},
+			{"case": "Two_elements", "test": "TestRunLabels/Labels_per_case"},
+		},
+	})
+	validate(t, testCase{Name: "Nested runners compose",
+		Run: func(t *testing.T, record func(t *testing.T)) {
+			Run(t, []string{"outer"}, func(s string) string { return s }, func(t *testing.T, _ string) {
+				Run(t, []string{"a", "b"}, func(s string) string { return s }, func(t *testing.T, _ string) {
+					record(t)
+				})
+				record(t)
+			})
+		},
+		Expected: []map[string]string{
+			{"case": "outer/a", "test": "TestRunLabels/Nested_runners_compose"},
+			{"case": "outer/b", "test": "TestRunLabels/Nested_runners_compose"},
+			{"case": "outer", "test": "TestRunLabels/Nested_runners_compose"},
+		},
+	})
+	validate(t, testCase{Name: "Disabled",
+		Run: func(t *testing.T, record func(t *testing.T)) {
+			Run(t, []string{"a"}, func(s string) string { return s }, func(t *testing.T, _ string) {
+				record(t)
+			}, NoLabels[string]())
+		},
+		Expected: []map[string]string{{}},
+	})
+}
+
+func TestRunLabelsAppliedToGoroutine(t *testing.T) {
+	Run(t, []string{"labelled case"}, func(s string) string { return s }, func(t *testing.T, _ string) {
+		require.Contains(t, goroutineProfile(t), `"case":"labelled_case"`)
+	})
+	require.NotContains(t, goroutineProfile(t), `"case":"labelled_case"`)
+}
+
+func TestRunBenchTableLabels(t *testing.T) {
+	var got []map[string]string
+	runBenchTable(&fakeBench{}, []string{"a", "b"}, func(s string) string { return s }, func(b *testing.B, _ string) {
+		got = append(got, labelsOf(Context(b)))
+	}, newConfig[string](nil))
+	require.Equal(t, []map[string]string{
+		{"case": "a", "test": "BenchmarkFake"},
+		{"case": "b", "test": "BenchmarkFake"},
+	}, got)
+}
@@ -0,0 +1,114 @@
+package tabletest
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// leakSettle is how long a leak check waits for goroutines started by a
+// case to exit before reporting them.
+const leakSettle = 500 * time.Millisecond
+
+// ignoredCreators are the functions whose goroutines are never reported:
+// runtime internals, and the testing package running other tests (its
+// subtest goroutines show up as new whenever tests run in parallel).
+var ignoredCreators = []string{"runtime.", "testing.", "os/signal."}
+
+// LeakCheck fails a case when goroutines it started are still running
+// shortly after its body returned, listing their stacks.
+//
+// A goroutine counts as started by the case when it was not running as
+// the body began, so goroutines other cases start meanwhile would be
+// blamed on it too: Run panics when LeakCheck is combined with Parallel.
+// Tests running in parallel with the whole table can still cause false
+// reports, so leave leak-checked tables out of t.Parallel tests.
+func LeakCheck[C any]() Option[C] {
+	return func(cfg *config[C]) {
+		cfg.leakCheck = true
+	}
+}
+
+// LeakAllow makes LeakCheck ignore goroutines whose stack contains any of
+// the given substrings, such as the name of a function known to start a
+// long-lived goroutine.
+func LeakAllow[C any](substrings ...string) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.leakAllow = append(cfg.leakAllow, substrings...)
+	}
+}
+
+// errorReporter is the part of *testing.T a leak check reports to.
+type errorReporter interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// goroutines returns the stacks of all goroutines, keyed by ID.
+func goroutines() map[uint64]string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	stacks := map[uint64]string{}
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		header, _, _ := strings.Cut(string(stack), " [")
+		id, err := strconv.ParseUint(strings.TrimPrefix(header, "goroutine "), 10, 64)
+		if err == nil {
+			stacks[id] = string(stack)
+		}
+	}
+	return stacks
+}
+
+// ignoredStack reports whether a goroutine should never count as leaked.
+func ignoredStack(stack string, allow []string) bool {
+	for _, substring := range allow {
+		if strings.Contains(stack, substring) {
+			return true
+		}
+	}
+	if strings.Contains(stack, "testing.tRunner(") {
+		return true
+	}
+	_, creator, ok := strings.Cut(stack, "\ncreated by ")
+	if !ok {
+		return true
+	}
+	for _, prefix := range ignoredCreators {
+		if strings.HasPrefix(creator, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLeaks waits up to settle for goroutines that are not in before to
+// exit, and reports the stacks of those that do not.
+func checkLeaks(t errorReporter, before map[uint64]string, allow []string, settle time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(settle)
+	for wait := time.Millisecond; ; wait *= 2 {
+		var leaked []string
+		for id, stack := range goroutines() {
+			if _, ok := before[id]; !ok && !ignoredStack(stack, allow) {
+				leaked = append(leaked, stack)
+			}
+		}
+		if len(leaked) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("tabletest: %d goroutine(s) leaked by this case:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+			return
+		}
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,95 @@
+package tabletest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT records the errors reported to it instead of failing.
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+//go:noinline
+func leakyProducer(release chan struct{}) {
+	<-release
+}
+
+func TestCheckLeaks(t *testing.T) {
+	type testCase struct {
+		Name string
+		// Body starts goroutines and returns a function that stops them.
+		Body          func() (stop func())
+		Allow         []string
+		ExpectedLeaks bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			rec := &recordingT{}
+			before := goroutines()
+			stop := tc.Body()
+			checkLeaks(rec, before, tc.Allow, 50*time.Millisecond)
+			stop()
+			if !tc.ExpectedLeaks {
+				require.Empty(t, rec.errors)
+				return
+			}
+			require.Len(t, rec.errors, 1)
+			require.Contains(t, rec.errors[0], "1 goroutine(s) leaked")
+			require.Contains(t, rec.errors[0], "leakyProducer")
+		})
+	}
+	leak := func() func() {
+		release := make(chan struct{})
+		go leakyProducer(release)
+		return func() { close(release) }
+	}
+	validate(t, testCase{Name: "Leaking producer",
+		Body:          leak,
+		ExpectedLeaks: true,
+	})
+	validate(t, testCase{Name: "Allowlisted producer",
+		Body:  leak,
+		Allow: []string{"tabletest.leakyProducer"},
+	})
+	validate(t, testCase{Name: "Spawn and join",
+		Body: func() func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					time.Sleep(time.Millisecond)
+				}()
+			}
+			wg.Wait()
+			return func() {}
+		},
+	})
+	validate(t, testCase{Name: "Exits while settling",
+		Body: func() func() {
+			go time.Sleep(10 * time.Millisecond)
+			return func() {}
+		},
+	})
+}
+
+func TestLeakCheckRejectsParallel(t *testing.T) {
+	// Parallel cases see each other's goroutines, which would be reported
+	// as leaks of whichever case finished first.
+	require.PanicsWithValue(t, "tabletest: LeakCheck cannot be combined with Parallel, as goroutines are checked for the whole process", func() {
+		Run(t, []int{1}, func(n int) string { return fmt.Sprint(n) }, func(t *testing.T, n int) {}, LeakCheck[int](), Parallel[int]())
+	})
+}
@@ -0,0 +1,10 @@
+package tabletest
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(MainSummary(m))
+}
@@ -0,0 +1,49 @@
+package tabletest
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SanitizeName returns the subtest name the runners register for a case
+// called name, so -run patterns can be predicted from case names:
+//
+//   - whitespace becomes "_", as the testing package would do itself
+//   - "/" becomes "_", since it would otherwise start a nested level
+//   - runes that are not printable become "_"
+//   - the empty name becomes "_"
+//
+// Everything else, including non-ASCII letters, is kept as is.
+func SanitizeName(name string) string {
+	if name == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '/' || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// uniqueNames sanitizes the names of cases and makes them unique by
+// appending a stable "#2", "#3", ... suffix to later duplicates. It
+// returns a warning for every name that had to be changed that way.
+func uniqueNames[C any](cases []C, name func(C) string) (names []string, warnings []string) {
+	seen := map[string]bool{}
+	names = make([]string, len(cases))
+	for i, tc := range cases {
+		base := SanitizeName(name(tc))
+		unique := base
+		for n := 2; seen[unique]; n++ {
+			unique = fmt.Sprintf("%s#%d", base, n)
+		}
+		if unique != base {
+			warnings = append(warnings, fmt.Sprintf("case %d: name %q collides with an earlier case, running it as %q", i, name(tc), unique))
+		}
+		seen[unique] = true
+		names[i] = unique
+	}
+	return names, warnings
+}
@@ -0,0 +1,79 @@
+package tabletest
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeName(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Input    string
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, SanitizeName(tc.Input))
+		})
+	}
+	validate(t, testCase{Name: "Plain",
+		Input:    "Empty",
+		Expected: "Empty",
+	})
+	validate(t, testCase{Name: "Spaces",
+		Input:    "Two elements unsorted",
+		Expected: "Two_elements_unsorted",
+	})
+	validate(t, testCase{Name: "Slash",
+		Input:    "a/b",
+		Expected: "a_b",
+	})
+	validate(t, testCase{Name: "Tabs and newlines",
+		Input:    "a\tb\nc",
+		Expected: "a_b_c",
+	})
+	validate(t, testCase{Name: "Non-printable",
+		Input:    "a\x00b",
+		Expected: "a_b",
+	})
+	validate(t, testCase{Name: "Unicode kept",
+		Input:    "größer als 🎵",
+		Expected: "größer_als_🎵",
+	})
+	validate(t, testCase{Name: "Empty",
+		Input:    "",
+		Expected: "_",
+	})
+}
+
+func TestUniqueNames(t *testing.T) {
+	names, warnings := uniqueNames([]string{"a b", "a_b", "a b", "a_b#2", "c"}, func(s string) string { return s })
+	require.Equal(t, []string{"a_b", "a_b#2", "a_b#3", "a_b#2#2", "c"}, names)
+	require.Len(t, warnings, 3)
+	require.Contains(t, warnings[0], `"a_b#2"`)
+}
+
+// TestRunNamesRoundTrip checks that the names the testing package ends up
+// giving subtests are exactly the predicted ones, and that a -run style
+// pattern built from SanitizeName selects them.
+func TestRunNamesRoundTrip(t *testing.T) {
+	inputs := []string{"Empty", "Two elements", "a/b", "größer", "🎵", "", "a(b)*", "Two elements"}
+	var got []string
+	t.Run("table", func(t *testing.T) {
+		parent := t.Name()
+		Run(t, inputs, func(s string) string { return s }, func(t *testing.T, _ string) {
+			got = append(got, strings.TrimPrefix(t.Name(), parent+"/"))
+		})
+	})
+	require.Equal(t, []string{"Empty", "Two_elements", "a_b", "größer", "🎵", "_", "a(b)*", "Two_elements#2"}, got)
+	for i, input := range inputs[:len(inputs)-1] {
+		require.NotContains(t, got[i], "/")
+		pattern := regexp.MustCompile("^" + regexp.QuoteMeta(SanitizeName(input)) + "$")
+		require.True(t, pattern.MatchString(got[i]), "pattern %q should select %q", pattern, got[i])
+	}
+}
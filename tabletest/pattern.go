@@ -0,0 +1,31 @@
+package tabletest
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RunPattern returns a -run pattern selecting exactly the given cases of
+// the test called testName, for example
+//
+//	RunPattern("TestIsSorted", "Empty", "Two elements")
+//
+// returns "^TestIsSorted$/^(Empty|Two_elements)$". Case names go through
+// SanitizeName and have regexp metacharacters escaped. testName may be a
+// nested name such as "TestIsSorted/table"; every level is anchored
+// separately, the way go test matches them. Without case names, the
+// pattern selects the whole test.
+func RunPattern(testName string, caseNames ...string) string {
+	var levels []string
+	for _, level := range strings.Split(testName, "/") {
+		levels = append(levels, "^"+regexp.QuoteMeta(level)+"$")
+	}
+	if len(caseNames) > 0 {
+		quoted := make([]string, len(caseNames))
+		for i, name := range caseNames {
+			quoted[i] = regexp.QuoteMeta(SanitizeName(name))
+		}
+		levels = append(levels, "^("+strings.Join(quoted, "|")+")$")
+	}
+	return strings.Join(levels, "/")
+}
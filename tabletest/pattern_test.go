@@ -0,0 +1,118 @@
+package tabletest
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// helperEnv makes the test binary run TestPatternHelper when it is
+// re-executed by the tests below.
+const helperEnv = "TABLETEST_PATTERN_HELPER"
+
+var helperCases = []string{"Empty", "Two elements", "Two elements unsorted", "a.b", "a(b)", "größer", ""}
+
+// TestPatternHelper is not a real test: it provides a table of subtests
+// for the tests below to select from in a child process.
+func TestPatternHelper(t *testing.T) {
+	mode := os.Getenv(helperEnv)
+	if mode == "" {
+		t.Skip("only run as a helper process")
+	}
+	Run(t, helperCases, func(s string) string { return s }, func(t *testing.T, s string) {
+		if mode == "fail" && strings.HasPrefix(s, "Two") {
+			t.Fail()
+		}
+	})
+}
+
+// runHelper runs TestPatternHelper in a child process with the given -run
+// pattern and returns the names of the subtests that ran and the output.
+func runHelper(t *testing.T, mode, pattern string) ([]string, string) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run", pattern, "-test.v")
+	cmd.Env = append(os.Environ(), helperEnv+"="+mode)
+	out, _ := cmd.CombinedOutput()
+	var ran []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if name := strings.TrimPrefix(line, "=== RUN   TestPatternHelper/"); name != line {
+			ran = append(ran, name)
+		}
+	}
+	return ran, string(out)
+}
+
+func TestRunPattern(t *testing.T) {
+	type testCase struct {
+		Name      string
+		TestName  string
+		CaseNames []string
+		Expected  string
+		// Selected are the helper subtests the pattern must run, in order.
+		Selected []string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			pattern := RunPattern(tc.TestName, tc.CaseNames...)
+			require.Equal(t, tc.Expected, pattern)
+			for _, level := range strings.Split(pattern, "/") {
+				_, err := regexp.Compile(level)
+				require.NoError(t, err)
+			}
+			if tc.Selected != nil {
+				ran, out := runHelper(t, "pass", pattern)
+				require.Equal(t, tc.Selected, ran, out)
+			}
+		})
+	}
+	validate(t, testCase{Name: "Example from the docs",
+		TestName:  "TestIsSorted",
+		CaseNames: []string{"Empty", "Two elements"},
+		Expected:  "^TestIsSorted$/^(Empty|Two_elements)$",
+	})
+	validate(t, testCase{Name: "Whole test",
+		TestName: "TestIsSorted",
+		Expected: "^TestIsSorted$",
+	})
+	validate(t, testCase{Name: "Nested test name",
+		TestName:  "TestIsSorted/table",
+		CaseNames: []string{"Empty"},
+		Expected:  "^TestIsSorted$/^table$/^(Empty)$",
+	})
+	validate(t, testCase{Name: "Prefix of another case is not selected",
+		TestName:  "TestPatternHelper",
+		CaseNames: []string{"Two elements"},
+		Expected:  "^TestPatternHelper$/^(Two_elements)$",
+		Selected:  []string{"Two_elements"},
+	})
+	validate(t, testCase{Name: "Metacharacters are escaped",
+		TestName:  "TestPatternHelper",
+		CaseNames: []string{"a.b", "a(b)"},
+		Expected:  `^TestPatternHelper$/^(a\.b|a\(b\))$`,
+		Selected:  []string{"a.b", "a(b)"},
+	})
+	validate(t, testCase{Name: "Unicode and empty names",
+		TestName:  "TestPatternHelper",
+		CaseNames: []string{"größer", ""},
+		Expected:  "^TestPatternHelper$/^(größer|_)$",
+		Selected:  []string{"größer", "_"},
+	})
+}
+
+func TestRunLogsFailedPattern(t *testing.T) {
+	if os.Getenv(helperEnv) != "" {
+		t.Skip("running as a helper process")
+	}
+	_, out := runHelper(t, "fail", "^TestPatternHelper$")
+	pattern := RunPattern("TestPatternHelper", "Two elements", "Two elements unsorted")
+	require.Contains(t, out, "rerun failed cases with -run '"+pattern+"'")
+
+	ran, out := runHelper(t, "fail", pattern)
+	require.Equal(t, []string{"Two_elements", "Two_elements_unsorted"}, ran, out)
+}
@@ -0,0 +1,77 @@
+package tabletest
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// StressEnv is the environment variable that multiplies the number of
+// times every case body runs, Repeat or not:
+//
+//	TESTDEMO_STRESS=100 go test -race ./...
+const StressEnv = "TESTDEMO_STRESS"
+
+// Repeat runs the body of every case n times in a row within its
+// subtest, to shake out races and other flaky failures. The iterations
+// stop at the first one that fails the test, and which one it was is
+// logged. With Parallel the cases run in parallel with each other, but
+// the iterations of one case still run one after the other.
+//
+// BeforeEach, AfterEach and LeakCheck run once per case, around all of
+// its iterations, and the Duration reported for the case covers all of
+// them. There is no per-iteration time limit: the -timeout of go test
+// covers the whole run, iterations included, so raise it along with n.
+func Repeat[C any](n int) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.repeat = n
+	}
+}
+
+// iterations returns how many times each case body runs: the count given
+// to Repeat, or 1, times the multiplier in TESTDEMO_STRESS.
+func (cfg *config[C]) iterations() (int, error) {
+	n := max(cfg.repeat, 1)
+	s := os.Getenv(StressEnv)
+	if s == "" {
+		return n, nil
+	}
+	multiplier, err := strconv.Atoi(s)
+	if err != nil || multiplier < 1 {
+		return 0, fmt.Errorf("%s=%q is not a positive integer", StressEnv, s)
+	}
+	return n * multiplier, nil
+}
+
+// failReporter is the part of *testing.T repeat reports to.
+type failReporter interface {
+	Helper()
+	Failed() bool
+	Logf(format string, args ...any)
+}
+
+// repeat runs body n times, stopping after the first iteration that
+// fails t, including one that stops the test with FailNow. When t had
+// already failed before the first iteration, as BeforeEach can make it,
+// no iteration can be told apart as the failing one, so it runs body
+// once and logs nothing.
+func repeat(t failReporter, n int, body func()) {
+	t.Helper()
+	if n <= 1 {
+		body()
+		return
+	}
+	failedBefore := t.Failed()
+	i := 1
+	defer func() {
+		if t.Failed() && !failedBefore {
+			t.Logf("tabletest: failed on iteration %d of %d", i, n)
+		}
+	}()
+	for ; i <= n; i++ {
+		body()
+		if t.Failed() {
+			return
+		}
+	}
+}
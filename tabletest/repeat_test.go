@@ -0,0 +1,178 @@
+package tabletest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// repeatHelperEnv makes the test binary run TestRepeatHelper when it is
+// re-executed by TestRepeatReportsFailedIteration.
+const repeatHelperEnv = "TABLETEST_REPEAT_HELPER"
+
+// fakeFailer is a failReporter whose test has failed once failed is set.
+type fakeFailer struct {
+	failed bool
+	logs   []string
+}
+
+func (f *fakeFailer) Helper()      {}
+func (f *fakeFailer) Failed() bool { return f.failed }
+func (f *fakeFailer) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func TestRepeat(t *testing.T) {
+	type testCase struct {
+		Name string
+		N    int
+		// FailOn is the iteration that fails, or 0 for none.
+		FailOn int
+		// FailedBefore fails the test before the first iteration, as a
+		// BeforeEach can.
+		FailedBefore bool
+		ExpectedRuns int
+		ExpectedLogs []string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			f := &fakeFailer{failed: tc.FailedBefore}
+			runs := 0
+			repeat(f, tc.N, func() {
+				runs++
+				if runs == tc.FailOn {
+					f.failed = true
+				}
+			})
+			require.Equal(t, tc.ExpectedRuns, runs)
+			require.Equal(t, tc.ExpectedLogs, f.logs)
+		})
+	}
+	validate(t, testCase{Name: "Not repeated",
+		N:            0,
+		ExpectedRuns: 1,
+	})
+	validate(t, testCase{Name: "Once",
+		N:            1,
+		FailOn:       1,
+		ExpectedRuns: 1,
+	})
+	validate(t, testCase{Name: "All pass",
+		N:            10,
+		ExpectedRuns: 10,
+	})
+	validate(t, testCase{Name: "Fails on iteration 7",
+		N:            10,
+		FailOn:       7,
+		ExpectedRuns: 7,
+		ExpectedLogs: []string{"tabletest: failed on iteration 7 of 10"},
+	})
+	validate(t, testCase{Name: "Fails on the last iteration",
+		N:            10,
+		FailOn:       10,
+		ExpectedRuns: 10,
+		ExpectedLogs: []string{"tabletest: failed on iteration 10 of 10"},
+	})
+	validate(t, testCase{Name: "Failed before the first iteration",
+		N:            10,
+		FailedBefore: true,
+		ExpectedRuns: 1,
+	})
+}
+
+func TestIterations(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Repeat      int
+		Stress      string
+		Expected    int
+		ExpectedErr string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			t.Setenv(StressEnv, tc.Stress)
+			n, err := newConfig([]Option[int]{Repeat[int](tc.Repeat)}).iterations()
+			if tc.ExpectedErr != "" {
+				require.EqualError(t, err, tc.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, n)
+		})
+	}
+	validate(t, testCase{Name: "Default",
+		Expected: 1,
+	})
+	validate(t, testCase{Name: "Repeat",
+		Repeat:   5,
+		Expected: 5,
+	})
+	validate(t, testCase{Name: "Stress without Repeat",
+		Stress:   "3",
+		Expected: 3,
+	})
+	validate(t, testCase{Name: "Stress multiplies Repeat",
+		Repeat:   5,
+		Stress:   "3",
+		Expected: 15,
+	})
+	validate(t, testCase{Name: "Zero multiplier",
+		Stress:      "0",
+		ExpectedErr: `TESTDEMO_STRESS="0" is not a positive integer`,
+	})
+	validate(t, testCase{Name: "Not a number",
+		Stress:      "lots",
+		ExpectedErr: `TESTDEMO_STRESS="lots" is not a positive integer`,
+	})
+}
+
+// TestRepeatHelper is not a real test: it provides a table for
+// TestRepeatReportsFailedIteration to run in a child process.
+func TestRepeatHelper(t *testing.T) {
+	if os.Getenv(repeatHelperEnv) == "" {
+		t.Skip("only run as a helper process")
+	}
+	var flaky, steady atomic.Int32
+	t.Cleanup(func() {
+		t.Logf("flaky ran %d times, steady ran %d times", flaky.Load(), steady.Load())
+	})
+	Run(t, []string{"Flaky", "Steady"}, func(s string) string { return s }, func(t *testing.T, s string) {
+		if s == "Steady" {
+			steady.Add(1)
+			return
+		}
+		require.NotEqual(t, int32(7), flaky.Add(1), "fails on the seventh run only")
+	}, Repeat[string](10), Parallel[string]())
+}
+
+func TestRepeatReportsFailedIteration(t *testing.T) {
+	if os.Getenv(repeatHelperEnv) != "" {
+		t.Skip("running as a helper process")
+	}
+	run := func(stress string) string {
+		cmd := exec.Command(os.Args[0], "-test.run", "^TestRepeatHelper$", "-test.v")
+		cmd.Env = append(os.Environ(), repeatHelperEnv+"=1", StressEnv+"="+stress)
+		out, err := cmd.CombinedOutput()
+		require.Error(t, err, "%s", out)
+		return string(out)
+	}
+	out := run("")
+	require.Contains(t, out, "tabletest: failed on iteration 7 of 10")
+	require.Contains(t, out, "flaky ran 7 times, steady ran 10 times")
+	require.Contains(t, out, "--- FAIL: TestRepeatHelper/Flaky")
+	require.Contains(t, out, "--- PASS: TestRepeatHelper/Steady")
+	require.Equal(t, 1, strings.Count(out, "failed on iteration"), out)
+
+	out = run("3")
+	require.Contains(t, out, "tabletest: failed on iteration 7 of 30")
+	require.Contains(t, out, "flaky ran 7 times, steady ran 30 times")
+}
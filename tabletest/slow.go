@@ -0,0 +1,66 @@
+package tabletest
+
+import "time"
+
+// ShortSlowerFactor is how much WarnSlower and FailSlower relax their
+// threshold under -short. Short runs are the ones made on laptops and
+// loaded CI workers, where a case several times slower than usual is
+// noise rather than a regression.
+const ShortSlowerFactor = 5
+
+// WarnSlower logs a warning for every case whose body takes longer than
+// d. BeforeEach and AfterEach are not counted, and with Repeat the
+// threshold is for each iteration, so d times the number of iterations
+// for the case as a whole. Under -short, d is multiplied by
+// ShortSlowerFactor. The cases over their threshold are listed in the
+// summary written by WriteSummary.
+func WarnSlower[C any](d time.Duration) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.slower = d
+		cfg.failSlower = false
+	}
+}
+
+// FailSlower is like WarnSlower, but fails the cases taking longer than
+// d instead of only logging them.
+func FailSlower[C any](d time.Duration) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.slower = d
+		cfg.failSlower = true
+	}
+}
+
+// slowReporter is the part of *testing.T checkSlower reports to.
+type slowReporter interface {
+	Helper()
+	Logf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// threshold returns how long the body of a case running n iterations may
+// take, or 0 when there is no limit.
+func (cfg *config[C]) threshold(n int) time.Duration {
+	if cfg.slower <= 0 {
+		return 0
+	}
+	d := cfg.slower * time.Duration(max(n, 1))
+	if cfg.short() {
+		d *= ShortSlowerFactor
+	}
+	return d
+}
+
+// checkSlower reports on t when elapsed is over threshold, and returns
+// whether it is.
+func (cfg *config[C]) checkSlower(t slowReporter, elapsed, threshold time.Duration) bool {
+	t.Helper()
+	if threshold <= 0 || elapsed <= threshold {
+		return false
+	}
+	if cfg.failSlower {
+		t.Errorf("tabletest: case took %s, more than the %s allowed", elapsed, threshold)
+	} else {
+		t.Logf("tabletest: warning: case took %s, more than the %s allowed", elapsed, threshold)
+	}
+	return true
+}
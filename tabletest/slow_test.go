@@ -0,0 +1,257 @@
+package tabletest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// slowHelperEnv makes the test binary run TestSlowerHelper when it is
+// re-executed by TestSlowerReports.
+const slowHelperEnv = "TABLETEST_SLOW_HELPER"
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// withClock times the cases with now instead of time.Now.
+func withClock[C any](now func() time.Time) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.now = now
+	}
+}
+
+// withShort makes the runner act as if -short was given or not.
+func withShort[C any](short bool) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.short = func() bool { return short }
+	}
+}
+
+// fakeSlowReporter is a slowReporter recording what is reported to it.
+type fakeSlowReporter struct {
+	logs   []string
+	errors []string
+}
+
+func (f *fakeSlowReporter) Helper() {}
+func (f *fakeSlowReporter) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+func (f *fakeSlowReporter) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestThreshold(t *testing.T) {
+	type testCase struct {
+		Name       string
+		Options    []Option[int]
+		Iterations int
+		Expected   time.Duration
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			cfg := newConfig(append([]Option[int]{withShort[int](false)}, tc.Options...))
+			require.Equal(t, tc.Expected, cfg.threshold(tc.Iterations))
+		})
+	}
+	validate(t, testCase{Name: "No threshold",
+		Iterations: 1,
+		Expected:   0,
+	})
+	validate(t, testCase{Name: "Warn",
+		Options:    []Option[int]{WarnSlower[int](20 * time.Millisecond)},
+		Iterations: 1,
+		Expected:   20 * time.Millisecond,
+	})
+	validate(t, testCase{Name: "Fail",
+		Options:    []Option[int]{FailSlower[int](20 * time.Millisecond)},
+		Iterations: 1,
+		Expected:   20 * time.Millisecond,
+	})
+	validate(t, testCase{Name: "Per iteration",
+		Options:    []Option[int]{WarnSlower[int](20 * time.Millisecond)},
+		Iterations: 10,
+		Expected:   200 * time.Millisecond,
+	})
+	validate(t, testCase{Name: "Short",
+		Options:    []Option[int]{WarnSlower[int](20 * time.Millisecond), withShort[int](true)},
+		Iterations: 10,
+		Expected:   10 * ShortSlowerFactor * 20 * time.Millisecond,
+	})
+}
+
+func TestCheckSlower(t *testing.T) {
+	type testCase struct {
+		Name           string
+		Option         Option[int]
+		Elapsed        time.Duration
+		Threshold      time.Duration
+		ExpectedSlow   bool
+		ExpectedLogs   []string
+		ExpectedErrors []string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			f := &fakeSlowReporter{}
+			cfg := newConfig([]Option[int]{tc.Option})
+			require.Equal(t, tc.ExpectedSlow, cfg.checkSlower(f, tc.Elapsed, tc.Threshold))
+			require.Equal(t, tc.ExpectedLogs, f.logs)
+			require.Equal(t, tc.ExpectedErrors, f.errors)
+		})
+	}
+	validate(t, testCase{Name: "No threshold",
+		Option:  WarnSlower[int](0),
+		Elapsed: time.Hour,
+	})
+	validate(t, testCase{Name: "Under",
+		Option:    WarnSlower[int](time.Second),
+		Elapsed:   time.Second - 1,
+		Threshold: time.Second,
+	})
+	validate(t, testCase{Name: "At",
+		Option:    FailSlower[int](time.Second),
+		Elapsed:   time.Second,
+		Threshold: time.Second,
+	})
+	validate(t, testCase{Name: "Warn over",
+		Option:       WarnSlower[int](time.Second),
+		Elapsed:      time.Second + time.Millisecond,
+		Threshold:    time.Second,
+		ExpectedSlow: true,
+		ExpectedLogs: []string{"tabletest: warning: case took 1.001s, more than the 1s allowed"},
+	})
+	validate(t, testCase{Name: "Fail over",
+		Option:         FailSlower[int](time.Second),
+		Elapsed:        time.Second + time.Millisecond,
+		Threshold:      time.Second,
+		ExpectedSlow:   true,
+		ExpectedErrors: []string{"tabletest: case took 1.001s, more than the 1s allowed"},
+	})
+}
+
+func TestRunSlowerWithFakeClock(t *testing.T) {
+	type tableCase struct {
+		Name string
+		Cost time.Duration
+	}
+	const limit = 20 * time.Millisecond
+	cases := []tableCase{
+		{Name: "under", Cost: limit - 1},
+		{Name: "at", Cost: limit},
+		{Name: "over", Cost: limit + 1},
+	}
+	for _, short := range []bool{false, true} {
+		t.Run(fmt.Sprintf("short=%t", short), func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			c := &Collector{}
+			// The hooks are far over the limit, but only the bodies count.
+			hook := func(t *testing.T, tc tableCase) { clock.Advance(time.Hour) }
+			Run(t, cases, func(tc tableCase) string { return tc.Name }, func(t *testing.T, tc tableCase) {
+				clock.Advance(tc.Cost)
+			}, WarnSlower[tableCase](limit), BeforeEach(hook), AfterEach(hook),
+				Collect[tableCase](c), withClock[tableCase](clock.Now), withShort[tableCase](short))
+
+			thresholds := map[string]time.Duration{}
+			for _, r := range c.Results() {
+				require.Equal(t, Passed, r.Outcome)
+				require.Equal(t, 2*time.Hour, r.HookDuration)
+				thresholds[r.Name[strings.LastIndex(r.Name, "/")+1:]] = r.Threshold
+			}
+			expected := map[string]time.Duration{"under": 0, "at": 0, "over": limit}
+			if short {
+				expected["over"] = 0
+			}
+			require.Equal(t, expected, thresholds)
+		})
+	}
+}
+
+func TestRunSlowerWithSleeps(t *testing.T) {
+	type tableCase struct {
+		Name  string
+		Sleep time.Duration
+	}
+	const limit = 50 * time.Millisecond
+	c := &Collector{}
+	t.Run("table", func(t *testing.T) {
+		Run(t, []tableCase{
+			{Name: "fast"},
+			{Name: "slow", Sleep: 3 * limit},
+		}, func(tc tableCase) string { return tc.Name }, func(t *testing.T, tc tableCase) {
+			time.Sleep(tc.Sleep)
+		}, WarnSlower[tableCase](limit), Parallel[tableCase](), Collect[tableCase](c), withShort[tableCase](false))
+	})
+
+	results := c.Results()
+	require.Len(t, results, 2)
+	require.Equal(t, "TestRunSlowerWithSleeps/table/fast", results[0].Name)
+	require.Zero(t, results[0].Threshold)
+	require.Equal(t, "TestRunSlowerWithSleeps/table/slow", results[1].Name)
+	require.Equal(t, limit, results[1].Threshold)
+	require.Equal(t, Passed, results[1].Outcome)
+}
+
+// TestSlowerHelper is not a real test: it provides tables for
+// TestSlowerReports to run in a child process.
+func TestSlowerHelper(t *testing.T) {
+	if os.Getenv(slowHelperEnv) == "" {
+		t.Skip("only run as a helper process")
+	}
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cost := func(t *testing.T, d time.Duration) { clock.Advance(d) }
+	name := func(d time.Duration) string { return d.String() }
+	opts := func(opt Option[time.Duration]) []Option[time.Duration] {
+		return []Option[time.Duration]{opt, withClock[time.Duration](clock.Now), withShort[time.Duration](false)}
+	}
+	cases := []time.Duration{time.Second, 3 * time.Second}
+	t.Run("warn", func(t *testing.T) {
+		Run(t, cases, name, cost, opts(WarnSlower[time.Duration](2*time.Second))...)
+	})
+	t.Run("fail", func(t *testing.T) {
+		Run(t, cases, name, cost, opts(FailSlower[time.Duration](2*time.Second))...)
+	})
+}
+
+func TestSlowerReports(t *testing.T) {
+	if os.Getenv(slowHelperEnv) != "" {
+		t.Skip("running as a helper process")
+	}
+	cmd := exec.Command(os.Args[0], "-test.run", "^TestSlowerHelper$", "-test.v")
+	cmd.Env = append(os.Environ(), slowHelperEnv+"=1", QuietEnv+"=")
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err, "%s", out)
+	output := string(out)
+	require.Contains(t, output, "--- PASS: TestSlowerHelper/warn/3s")
+	require.Contains(t, output, "tabletest: warning: case took 3s, more than the 2s allowed")
+	require.Contains(t, output, "--- FAIL: TestSlowerHelper/fail/3s")
+	require.Contains(t, output, "--- PASS: TestSlowerHelper/fail/1s")
+	require.Equal(t, 1, strings.Count(output, "tabletest: case took 3s, more than the 2s allowed"), output)
+	require.Contains(t, output, "tabletest: cases over their slow threshold:\n"+
+		"          3s  fail  TestSlowerHelper/fail/3s (threshold 2s)\n"+
+		"          3s  pass  TestSlowerHelper/warn/3s (threshold 2s)\n")
+}
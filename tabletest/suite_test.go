@@ -0,0 +1,76 @@
+package tabletest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSuite runs subtests the way testify's suite.Suite does: T returns
+// the running subtest while there is one, and the subtest hooks are
+// logged around every subtest.
+type fakeSuite struct {
+	t   *testing.T
+	log []string
+}
+
+func (s *fakeSuite) T() *testing.T { return s.t }
+
+func (s *fakeSuite) Run(name string, subtest func()) bool {
+	old := s.t
+	return old.Run(name, func(t *testing.T) {
+		s.t = t
+		defer func() { s.t = old }()
+		s.log = append(s.log, "SetupSubTest")
+		defer func() { s.log = append(s.log, "TearDownSubTest") }()
+		subtest()
+	})
+}
+
+func TestRunTableOnSuite(t *testing.T) {
+	type tableCase struct {
+		Name string
+		Tags []string
+	}
+	var s *fakeSuite
+	c := &Collector{}
+	t.Run("suite", func(t *testing.T) {
+		s = &fakeSuite{t: t}
+		hook := func(name string) func(t *testing.T, tc tableCase) {
+			return func(t *testing.T, tc tableCase) {
+				require.Same(t, s.T(), t)
+				s.log = append(s.log, name+" "+tc.Name)
+			}
+		}
+		RunTableOnSuite(s, []tableCase{{Name: "a"}, {Name: "b b"}, {Name: "skipped", Tags: []string{"slow"}}, {Name: "a"}},
+			func(tc tableCase) string { return tc.Name },
+			func(ct *testing.T, tc tableCase) {
+				require.Same(t, s.T(), ct, "fn gets the subtest's T, the one the suite returns")
+				require.NotSame(t, t, ct)
+				s.log = append(s.log, "case "+ct.Name())
+			},
+			BeforeEach(hook("BeforeEach")), AfterEach(hook("AfterEach")), Collect[tableCase](c),
+			Tags(func(tc tableCase) []string { return tc.Tags }, "slow"))
+		require.Same(t, t, s.T())
+	})
+	require.Equal(t, []string{
+		"SetupSubTest", "BeforeEach a", "case TestRunTableOnSuite/suite/a", "AfterEach a", "TearDownSubTest",
+		"SetupSubTest", "BeforeEach b b", "case TestRunTableOnSuite/suite/b_b", "AfterEach b b", "TearDownSubTest",
+		"SetupSubTest", "TearDownSubTest",
+		"SetupSubTest", "BeforeEach a", "case TestRunTableOnSuite/suite/a#2", "AfterEach a", "TearDownSubTest",
+	}, s.log)
+
+	var outcomes []Outcome
+	for _, r := range c.Results() {
+		outcomes = append(outcomes, r.Outcome)
+	}
+	require.Equal(t, []Outcome{Passed, Passed, Passed, Skipped}, outcomes)
+}
+
+func TestRunTableOnSuiteRejectsParallel(t *testing.T) {
+	s := &fakeSuite{t: t}
+	require.PanicsWithValue(t, "tabletest: RunTableOnSuite cannot be combined with Parallel, as a suite runs one subtest at a time", func() {
+		RunTableOnSuite(s, []string{"a"}, func(c string) string { return c }, func(t *testing.T, c string) {}, Parallel[string]())
+	})
+	require.Empty(t, s.log)
+}
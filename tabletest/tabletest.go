@@ -0,0 +1,245 @@
+// Package tabletest runs table-driven cases as named subtests and
+// sub-benchmarks, so every table gets the same plumbing instead of a
+// hand-rolled for loop.
+package tabletest
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// Option configures how a table of cases of type C is run.
+type Option[C any] func(*config[C])
+
+type config[C any] struct {
+	tags       func(C) []string
+	skipTags   map[string]bool
+	parallel   bool
+	beforeEach func(t *testing.T, c C)
+	afterEach  func(t *testing.T, c C)
+	leakCheck  bool
+	repeat     int
+	leakAllow  []string
+	noLabels   bool
+	collector  *Collector
+	bytes      func(C) int64
+	slower     time.Duration
+	failSlower bool
+	allocCheck bool
+	// allocBudget is only used when allocCheck is set, as 0 is a budget.
+	allocBudget float64
+	// setBytes is swapped out in tests, where there is no real *testing.B
+	// to inspect.
+	setBytes func(b *testing.B, n int64)
+	// now and short are swapped out in tests, to time cases with a fake
+	// clock and to relax thresholds without -short.
+	now   func() time.Time
+	short func() bool
+}
+
+func newConfig[C any](opts []Option[C]) *config[C] {
+	cfg := &config[C]{
+		skipTags:  map[string]bool{},
+		collector: DefaultCollector,
+		setBytes:  (*testing.B).SetBytes,
+		now:       time.Now,
+		short:     testing.Short,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// Tags tells the runner how to read the tags of a case. Cases carrying
+// any of the skip tags are skipped.
+func Tags[C any](tags func(C) []string, skip ...string) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.tags = tags
+		for _, tag := range skip {
+			cfg.skipTags[tag] = true
+		}
+	}
+}
+
+// Parallel runs the cases of a table in parallel with each other.
+func Parallel[C any]() Option[C] {
+	return func(cfg *config[C]) {
+		cfg.parallel = true
+	}
+}
+
+// BeforeEach runs before the body of every case, inside its subtest.
+// Its time is not counted in the case's Duration.
+func BeforeEach[C any](fn func(t *testing.T, c C)) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.beforeEach = fn
+	}
+}
+
+// AfterEach runs after the body of every case, inside its subtest, even
+// if the body failed. Its time is not counted in the case's Duration.
+func AfterEach[C any](fn func(t *testing.T, c C)) Option[C] {
+	return func(cfg *config[C]) {
+		cfg.afterEach = fn
+	}
+}
+
+// runBody runs the body of the case called name, which tb runs as part
+// of the table run by parent.
+func (cfg *config[C]) runBody(parent interface{ Name() string }, tb any, name string, body func()) {
+	if cfg.noLabels {
+		body()
+		return
+	}
+	withLabels(parent, tb, name, body)
+}
+
+// skipTag returns the first tag of tc that is configured to be skipped.
+func (cfg *config[C]) skipTag(tc C) (string, bool) {
+	if cfg.tags == nil {
+		return "", false
+	}
+	for _, tag := range cfg.tags(tc) {
+		if cfg.skipTags[tag] {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+// Run runs fn for every case as a subtest of t named by name. Names are
+// passed through SanitizeName, and duplicates get a "#2", "#3", ... suffix
+// with a warning logged on t. Once all cases are done, a -run pattern
+// selecting the failed ones is logged on t. The result of every case is
+// reported to DefaultCollector, or the collector given with Collect.
+// Case bodies run with pprof labels, see Context, as many times as
+// Repeat and TESTDEMO_STRESS ask for, and are timed against the
+// threshold of WarnSlower or FailSlower. Run panics on options that
+// cannot be combined, such as AllocBudget or LeakCheck with Parallel.
+func Run[C any](t *testing.T, cases []C, name func(C) string, fn func(t *testing.T, c C), opts ...Option[C]) {
+	t.Helper()
+	tbl := newTable(t, newConfig(opts), cases, name)
+	for i, tc := range cases {
+		tc, name := tc, tbl.names[i]
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+			if tbl.cfg.parallel {
+				t.Parallel()
+			}
+			tbl.runCase(t, tc, name, fn)
+		})
+	}
+}
+
+// RunTableOnSuite is Run for a testify suite: every case is a subtest
+// run through s.Run, so SetupSubTest and TearDownSubTest fire around it,
+// and fn is handed the subtest's own *testing.T, which is also what
+// s.T() returns while the case runs, so logs and failures stay attached
+// to the case. BeforeEach and AfterEach run between SetupSubTest and
+// TearDownSubTest. A suite runs one subtest at a time, so it panics when
+// given Parallel.
+func RunTableOnSuite[C any](s interface {
+	T() *testing.T
+	Run(name string, subtest func()) bool
+}, cases []C, name func(C) string, fn func(t *testing.T, c C), opts ...Option[C]) {
+	t := s.T()
+	t.Helper()
+	cfg := newConfig(opts)
+	if cfg.parallel {
+		panic("tabletest: RunTableOnSuite cannot be combined with Parallel, as a suite runs one subtest at a time")
+	}
+	tbl := newTable(t, cfg, cases, name)
+	for i, tc := range cases {
+		tc, name := tc, tbl.names[i]
+		s.Run(name, func() {
+			t := s.T()
+			t.Helper()
+			tbl.runCase(t, tc, name, fn)
+		})
+	}
+}
+
+// table is what the cases of a single table share.
+type table[C any] struct {
+	cfg       *config[C]
+	parent    *testing.T
+	names     []string
+	n         int
+	threshold time.Duration
+	mu        sync.Mutex
+	failed    []string
+}
+
+// newTable checks cfg and names the cases of a table run as subtests of
+// t, and logs the -run pattern of the failed ones once they are done.
+func newTable[C any](t *testing.T, cfg *config[C], cases []C, name func(C) string) *table[C] {
+	t.Helper()
+	cfg.checkOptions()
+	n, err := cfg.iterations()
+	if err != nil {
+		t.Fatal("tabletest: " + err.Error())
+	}
+	names, warnings := uniqueNames(cases, name)
+	for _, warning := range warnings {
+		t.Log("tabletest: " + warning)
+	}
+	tbl := &table[C]{cfg: cfg, parent: t, names: names, n: n, threshold: cfg.threshold(n)}
+	t.Cleanup(func() {
+		if len(tbl.failed) > 0 {
+			t.Logf("tabletest: rerun failed cases with -run '%s'", RunPattern(t.Name(), tbl.failed...))
+		}
+	})
+	return tbl
+}
+
+// runCase runs fn on the case tc called name, within its subtest t.
+func (tbl *table[C]) runCase(t *testing.T, tc C, name string, fn func(t *testing.T, c C)) {
+	t.Helper()
+	cfg := tbl.cfg
+	var elapsed, hooks time.Duration
+	var slow bool
+	t.Cleanup(func() {
+		result := CaseResult{Name: t.Name(), Duration: elapsed, HookDuration: hooks, Outcome: Passed}
+		if slow {
+			result.Threshold = tbl.threshold
+		}
+		switch {
+		case t.Failed():
+			result.Outcome = Failed
+			tbl.mu.Lock()
+			tbl.failed = append(tbl.failed, name)
+			tbl.mu.Unlock()
+		case t.Skipped():
+			result.Outcome = Skipped
+		}
+		cfg.collector.Report(result)
+	})
+	if tag, ok := cfg.skipTag(tc); ok {
+		t.Skipf("skipping case tagged %q", tag)
+	}
+	if cfg.afterEach != nil {
+		defer func() {
+			start := cfg.now()
+			cfg.afterEach(t, tc)
+			hooks += cfg.now().Sub(start)
+		}()
+	}
+	if cfg.beforeEach != nil {
+		start := cfg.now()
+		cfg.beforeEach(t, tc)
+		hooks += cfg.now().Sub(start)
+	}
+	if cfg.leakCheck {
+		before := goroutines()
+		t.Cleanup(func() { checkLeaks(t, before, cfg.leakAllow, leakSettle) })
+	}
+	start := cfg.now()
+	defer func() {
+		elapsed = cfg.now().Sub(start)
+		slow = cfg.checkSlower(t, elapsed, tbl.threshold)
+	}()
+	body := cfg.measureAllocs(t, tc, func() { fn(t, tc) })
+	cfg.runBody(tbl.parent, t, name, func() { repeat(t, tbl.n, body) })
+}
@@ -0,0 +1,26 @@
+package tabletest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSkipsTaggedCases(t *testing.T) {
+	type tableCase struct {
+		Name string
+		Tags []string
+	}
+	cases := []tableCase{
+		{Name: "Plain"},
+		{Name: "Slow", Tags: []string{"slow"}},
+		{Name: "Flaky", Tags: []string{"net", "flaky"}},
+	}
+	var ran []string
+	t.Run("table", func(t *testing.T) {
+		Run(t, cases, func(c tableCase) string { return c.Name }, func(t *testing.T, c tableCase) {
+			ran = append(ran, c.Name)
+		}, Tags(func(c tableCase) []string { return c.Tags }, "slow", "flaky"))
+	})
+	require.Equal(t, []string{"Plain"}, ran)
+}
@@ -1,23 +1,47 @@
 package testdemo
 
 import (
+	"github.com/StevenACoffman/testdemo/internal/testcases"
+	"github.com/StevenACoffman/testdemo/tabletest"
+	"github.com/StevenACoffman/testdemo/testsuite"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
 
-// Define the suite, and absorb the built-in basic suite
-// functionality from testify - including a T() method which
-// returns the current testing context
+// Define the suite, and absorb the basic suite functionality from
+// testsuite.BaseSuite, which embeds testify's suite.Suite - including a
+// T() method which returns the current testing context
 type ExampleTestSuite struct {
-	suite.Suite
+	testsuite.BaseSuite
 	VariableThatShouldStartAtFive int
+	snapshot                      *testsuite.Snapshot
+}
+
+// Register the fixture VariableThatShouldStartAtFive is taken from
+func (suite *ExampleTestSuite) SetupSuite() {
+	suite.RegisterFixture("five", func() (any, func()) {
+		return 5, nil
+	})
 }
 
 // Make sure that VariableThatShouldStartAtFive is set to five
 // before each test
 func (suite *ExampleTestSuite) SetupTest() {
-	suite.VariableThatShouldStartAtFive = 5
+	suite.BaseSuite.SetupTest()
+	suite.VariableThatShouldStartAtFive = testsuite.Fixture[int](&suite.BaseSuite, "five")
+}
+
+// Remember the state SetupTest left behind before each suite.Run subtest
+func (suite *ExampleTestSuite) SetupSubTest() {
+	var err error
+	suite.snapshot, err = testsuite.SnapshotFields(suite, testsuite.DeepCopy())
+	suite.Require().NoError(err)
+}
+
+// Put the state back, so the next subtest starts from it too
+func (suite *ExampleTestSuite) TearDownSubTest() {
+	suite.snapshot.Restore()
 }
 
 // All methods that begin with "Test" are run as tests within a
@@ -74,31 +98,49 @@ func (suite *ExampleTestSuite) TestExampleLogLinesLost() {
 		Array    []int
 		Expected bool
 	}
-	validate := func(suite *ExampleTestSuite, tc testCase) {
-		suite.T().Helper()
-		suite.Run(tc.Name, func() {
-			suite.T().Helper()
-			suite.T().Log("case:", tc.Name)
-			actual := IsSorted(tc.Array)
-			suite.Require().Equal(tc.Expected, actual)
-		})
+	cases := []testCase{
+		{Name: "Empty",
+			Array:    []int{},
+			Expected: true,
+		},
+		{Name: "Single element",
+			Array:    []int{0},
+			Expected: true,
+		},
+		{Name: "Two elements",
+			Array:    []int{0, 1},
+			Expected: false, // actually true, but we want to see failures
+		},
+		{Name: "Two elements unsorted",
+			Array:    []int{1, 0},
+			Expected: true, // actually false, but we want to see failures
+		},
 	}
-
-	validate(suite, testCase{Name: "Empty",
-		Array:    []int{},
-		Expected: true,
+	// RunTableOnSuite hands every case its own subtest through
+	// suite.Run, and fn that subtest's T, so no log lines get lost.
+	tabletest.RunTableOnSuite(suite, cases, func(tc testCase) string { return tc.Name }, func(t *testing.T, tc testCase) {
+		t.Log("case:", tc.Name)
+		actual := IsSorted(tc.Array)
+		require.Equal(t, tc.Expected, actual)
 	})
-	validate(suite, testCase{Name: "Single element",
-		Array:    []int{0},
-		Expected: true,
+}
+
+// The corpus every test style shares, with the right expectations
+func (suite *ExampleTestSuite) TestCorpus() {
+	tabletest.RunTableOnSuite(suite, testcases.Cases(), func(c testcases.Case) string { return c.Name }, func(t *testing.T, c testcases.Case) {
+		require.Equal(t, c.Sorted, IsSorted(c.Data))
 	})
-	validate(suite, testCase{Name: "Two elements",
-		Array:    []int{0, 1},
-		Expected: false, // actually true, but we want to see failures
+}
+
+// Subtests run through suite.Run share the suite's fields, so without
+// SetupSubTest/TearDownSubTest the second subtest would see six.
+func (suite *ExampleTestSuite) TestExampleSubTestsStartAtFive() {
+	suite.Run("Mutates", func() {
+		suite.Require().Equal(5, suite.VariableThatShouldStartAtFive)
+		suite.VariableThatShouldStartAtFive++
 	})
-	validate(suite, testCase{Name: "Two elements unsorted",
-		Array:    []int{1, 0},
-		Expected: true, // actually false, but we want to see failures
+	suite.Run("Observes restored value", func() {
+		suite.Require().Equal(5, suite.VariableThatShouldStartAtFive)
 	})
 }
 
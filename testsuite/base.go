@@ -0,0 +1,90 @@
+// Package testsuite provides building blocks for testify suites: shared
+// fixtures, typed case tables and per-subtest state restoration.
+package testsuite
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// BaseSuite is a suite.Suite with composable fixtures. Register fixtures
+// in SetupSuite; every SetupTest tears down the previous instances and
+// sets the fixtures up again, so each test starts from a fresh copy.
+// Suites that define their own SetupTest or TearDownSuite must call the
+// BaseSuite versions.
+type BaseSuite struct {
+	suite.Suite
+	fixtures []*fixture
+	byName   map[string]*fixture
+}
+
+type fixture struct {
+	name     string
+	setup    func() (any, func())
+	value    any
+	teardown func()
+	live     bool
+}
+
+// RegisterFixture registers a fixture called name. setup returns its
+// value and a function tearing it down, which may be nil.
+func (s *BaseSuite) RegisterFixture(name string, setup func() (any, func())) {
+	if s.byName == nil {
+		s.byName = map[string]*fixture{}
+	}
+	if _, ok := s.byName[name]; ok {
+		panic(fmt.Sprintf("testsuite: fixture %q registered twice", name))
+	}
+	f := &fixture{name: name, setup: setup}
+	s.fixtures = append(s.fixtures, f)
+	s.byName[name] = f
+}
+
+// SetupTest resets all fixtures: the previous instances are torn down in
+// the reverse order of registration, then set up again in order.
+func (s *BaseSuite) SetupTest() {
+	s.teardownFixtures()
+	for _, f := range s.fixtures {
+		f.value, f.teardown = f.setup()
+		f.live = true
+	}
+}
+
+// TearDownSuite tears down the remaining fixture instances in the reverse
+// order of registration.
+func (s *BaseSuite) TearDownSuite() {
+	s.teardownFixtures()
+}
+
+func (s *BaseSuite) teardownFixtures() {
+	for i := len(s.fixtures) - 1; i >= 0; i-- {
+		f := s.fixtures[i]
+		if !f.live {
+			continue
+		}
+		if f.teardown != nil {
+			f.teardown()
+		}
+		f.value, f.teardown, f.live = nil, nil, false
+	}
+}
+
+// Fixture returns the current value of the fixture called name. It panics
+// if there is no such fixture, if it has not been set up yet, or if its
+// value is not a T.
+func Fixture[T any](s *BaseSuite, name string) T {
+	f, ok := s.byName[name]
+	if !ok {
+		panic(fmt.Sprintf("testsuite: no fixture named %q", name))
+	}
+	if !f.live {
+		panic(fmt.Sprintf("testsuite: fixture %q is not set up", name))
+	}
+	v, ok := f.value.(T)
+	if !ok {
+		var zero T
+		panic(fmt.Sprintf("testsuite: fixture %q is a %T, not a %T", name, f.value, zero))
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+package testsuite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderSuite struct {
+	BaseSuite
+	log *[]string
+}
+
+func (s *orderSuite) SetupSuite() {
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		s.RegisterFixture(name, func() (any, func()) {
+			*s.log = append(*s.log, "setup "+name)
+			return name, func() { *s.log = append(*s.log, "teardown "+name) }
+		})
+	}
+}
+
+func (s *orderSuite) TestFirst() {
+	s.Require().Equal("a", Fixture[string](&s.BaseSuite, "a"))
+	*s.log = append(*s.log, "test")
+}
+
+func (s *orderSuite) TestSecond() {
+	s.Require().Equal("c", Fixture[string](&s.BaseSuite, "c"))
+	*s.log = append(*s.log, "test")
+}
+
+func TestBaseSuiteTeardownOrder(t *testing.T) {
+	var log []string
+	suite.Run(t, &orderSuite{log: &log})
+	require.Equal(t, []string{
+		"setup a", "setup b", "setup c",
+		"test",
+		"teardown c", "teardown b", "teardown a",
+		"setup a", "setup b", "setup c",
+		"test",
+		"teardown c", "teardown b", "teardown a",
+	}, log)
+}
+
+func TestFixturePanics(t *testing.T) {
+	s := &BaseSuite{}
+	s.RegisterFixture("n", func() (any, func()) { return 5, nil })
+	require.PanicsWithValue(t, `testsuite: fixture "n" is not set up`, func() { Fixture[int](s, "n") })
+
+	s.SetupTest()
+	require.Equal(t, 5, Fixture[int](s, "n"))
+	require.PanicsWithValue(t, `testsuite: no fixture named "missing"`, func() { Fixture[int](s, "missing") })
+	require.PanicsWithValue(t, `testsuite: fixture "n" is a int, not a string`, func() { Fixture[string](s, "n") })
+	require.PanicsWithValue(t, `testsuite: fixture "n" registered twice`, func() {
+		s.RegisterFixture("n", func() (any, func()) { return 6, nil })
+	})
+
+	s.TearDownSuite()
+	require.PanicsWithValue(t, `testsuite: fixture "n" is not set up`, func() { Fixture[int](s, "n") })
+}
@@ -0,0 +1,18 @@
+package testsuite
+
+import "github.com/stretchr/testify/suite"
+
+// RunCases runs fn for every case as a subtest named by name. The subtests
+// go through s.Run, so SetupSubTest and TearDownSubTest fire once per case
+// when the suite defines them, and s.T() inside fn is the subtest's own
+// *testing.T, which keeps its logs and failures attached to the case.
+func RunCases[C any](s *suite.Suite, cases []C, name func(C) string, fn func(c C)) {
+	s.T().Helper()
+	for _, tc := range cases {
+		tc := tc
+		s.Run(name(tc), func() {
+			s.T().Helper()
+			fn(tc)
+		})
+	}
+}
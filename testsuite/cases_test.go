@@ -0,0 +1,47 @@
+package testsuite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type subTestSuite struct {
+	suite.Suite
+	log   []string
+	names []string
+}
+
+func (s *subTestSuite) SetupSubTest() {
+	s.log = append(s.log, "setup")
+}
+
+func (s *subTestSuite) TearDownSubTest() {
+	s.log = append(s.log, "teardown")
+}
+
+func (s *subTestSuite) TestCases() {
+	parent := s.T()
+	RunCases(&s.Suite, []string{"a", "b b", "c"}, func(c string) string { return c }, func(c string) {
+		s.Require().NotSame(parent, s.T())
+		s.names = append(s.names, s.T().Name())
+		s.log = append(s.log, "case "+c)
+	})
+	s.Require().Same(parent, s.T())
+}
+
+func TestRunCasesSubTestHooks(t *testing.T) {
+	s := &subTestSuite{}
+	suite.Run(t, s)
+	require.Equal(t, []string{
+		"setup", "case a", "teardown",
+		"setup", "case b b", "teardown",
+		"setup", "case c", "teardown",
+	}, s.log)
+	require.Equal(t, []string{
+		"TestRunCasesSubTestHooks/TestCases/a",
+		"TestRunCasesSubTestHooks/TestCases/b_b",
+		"TestRunCasesSubTestHooks/TestCases/c",
+	}, s.names)
+}
@@ -0,0 +1,220 @@
+package testsuite
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Snapshot holds copies of the exported fields of a struct, taken by
+// SnapshotFields.
+type Snapshot struct {
+	target reflect.Value
+	fields map[int]reflect.Value
+	deep   bool
+}
+
+// SnapshotOption configures SnapshotFields.
+type SnapshotOption func(*Snapshot)
+
+// DeepCopy makes SnapshotFields copy the contents of slices, maps,
+// pointers and interfaces, so that mutating them in place is undone by
+// Restore as well. Without it only the field values themselves are
+// copied, and a slice or map restored by Restore still shares its
+// contents with the mutated one.
+func DeepCopy() SnapshotOption {
+	return func(snap *Snapshot) {
+		snap.deep = true
+	}
+}
+
+// SnapshotFields records the exported, non-embedded fields of the struct
+// s points to. It is meant to be called from SetupSubTest, with Restore
+// called from TearDownSubTest, so every suite.Run subtest starts from the
+// state SetupTest left behind:
+//
+//	func (s *MySuite) SetupSubTest() {
+//		s.snapshot, err = testsuite.SnapshotFields(s, testsuite.DeepCopy())
+//		s.Require().NoError(err)
+//	}
+//
+//	func (s *MySuite) TearDownSubTest() {
+//		s.snapshot.Restore()
+//	}
+//
+// With DeepCopy, fields holding channels, functions, unsafe pointers or
+// structs with unexported reference fields cannot be copied and make
+// SnapshotFields return an error.
+func SnapshotFields(s any, opts ...SnapshotOption) (*Snapshot, error) {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("testsuite: SnapshotFields needs a pointer to a struct, got %T", s)
+	}
+	snap := &Snapshot{target: v.Elem(), fields: map[int]reflect.Value{}}
+	for _, opt := range opts {
+		opt(snap)
+	}
+	typ := snap.target.Type()
+	seen := map[pointerKey]reflect.Value{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		value := snap.target.Field(i)
+		if !snap.deep {
+			snap.fields[i] = copyShallow(value)
+			continue
+		}
+		copied, err := copyDeep(value, seen)
+		if err != nil {
+			return nil, fmt.Errorf("testsuite: cannot snapshot field %s: %w", field.Name, err)
+		}
+		snap.fields[i] = copied
+	}
+	return snap, nil
+}
+
+// Restore sets the recorded fields back to the values they had when the
+// snapshot was taken. A deep snapshot can be restored more than once.
+func (snap *Snapshot) Restore() {
+	seen := map[pointerKey]reflect.Value{}
+	for i, value := range snap.fields {
+		if snap.deep {
+			// Copy again, so a later Restore is not affected by mutations
+			// of the restored value.
+			value, _ = copyDeep(value, seen)
+		}
+		snap.target.Field(i).Set(value)
+	}
+}
+
+func copyShallow(v reflect.Value) reflect.Value {
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+	return copied
+}
+
+// pointerKey identifies a pointer copyDeep has copied. A pointer to a
+// struct and one to its first field share an address, so the type is
+// part of the key: only pointers of the same type share a copy.
+type pointerKey struct {
+	p uintptr
+	t reflect.Type
+}
+
+// copyDeep returns a copy of v sharing no mutable memory with it. seen
+// maps already copied pointers to their copies, to preserve aliasing and
+// cycles.
+func copyDeep(v reflect.Value, seen map[pointerKey]reflect.Value) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return reflect.Value{}, fmt.Errorf("values of type %s cannot be copied", v.Type())
+	case reflect.Pointer:
+		if v.IsNil() {
+			return copyShallow(v), nil
+		}
+		key := pointerKey{v.Pointer(), v.Type()}
+		if copied, ok := seen[key]; ok {
+			return copied, nil
+		}
+		copied := reflect.New(v.Type().Elem())
+		seen[key] = copied
+		elem, err := copyDeep(v.Elem(), seen)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		copied.Elem().Set(elem)
+		return copied, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return copyShallow(v), nil
+		}
+		elem, err := copyDeep(v.Elem(), seen)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(elem)
+		return copied, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return copyShallow(v), nil
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, err := copyDeep(v.Index(i), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			copied.Index(i).Set(elem)
+		}
+		return copied, nil
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			elem, err := copyDeep(v.Index(i), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			copied.Index(i).Set(elem)
+		}
+		return copied, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return copyShallow(v), nil
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := copyDeep(iter.Key(), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			elem, err := copyDeep(iter.Value(), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			copied.SetMapIndex(key, elem)
+		}
+		return copied, nil
+	case reflect.Struct:
+		copied := copyShallow(v)
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				if hasReferences(field.Type) {
+					return reflect.Value{}, fmt.Errorf("unexported field %s.%s of type %s cannot be copied", typ, field.Name, field.Type)
+				}
+				continue
+			}
+			elem, err := copyDeep(v.Field(i), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			copied.Field(i).Set(elem)
+		}
+		return copied, nil
+	default:
+		return copyShallow(v), nil
+	}
+}
+
+// hasReferences reports whether values of type t can share memory with
+// their copies.
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Pointer,
+		reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasReferences(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
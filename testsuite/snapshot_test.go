@@ -0,0 +1,166 @@
+package testsuite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type restoringSuite struct {
+	suite.Suite
+	Count    int
+	Items    []int
+	Lookup   map[string][]int
+	snapshot *Snapshot
+	observed []int
+}
+
+func (s *restoringSuite) SetupTest() {
+	s.Count = 5
+	s.Items = []int{1, 2, 3}
+	s.Lookup = map[string][]int{"a": {1}}
+}
+
+func (s *restoringSuite) SetupSubTest() {
+	var err error
+	s.snapshot, err = SnapshotFields(s, DeepCopy())
+	s.Require().NoError(err)
+}
+
+func (s *restoringSuite) TearDownSubTest() {
+	s.snapshot.Restore()
+}
+
+func (s *restoringSuite) TestMutations() {
+	for _, name := range []string{"first", "second", "third"} {
+		s.Run(name, func() {
+			s.observed = append(s.observed, s.Count)
+			s.Require().Equal([]int{1, 2, 3}, s.Items)
+			s.Require().Equal(map[string][]int{"a": {1}}, s.Lookup)
+			s.Count++
+			s.Items[0] = 99
+			s.Items = append(s.Items, 4)
+			s.Lookup["a"][0] = 99
+			s.Lookup["b"] = nil
+		})
+	}
+}
+
+func TestSnapshotFieldsRestoresPerSubTest(t *testing.T) {
+	s := &restoringSuite{}
+	suite.Run(t, s)
+	require.Equal(t, []int{5, 5, 5}, s.observed)
+}
+
+func TestSnapshotFieldsShallow(t *testing.T) {
+	type state struct {
+		Count  int
+		Items  []int
+		hidden int
+	}
+	st := &state{Count: 1, Items: []int{1, 2}, hidden: 7}
+	snap, err := SnapshotFields(st)
+	require.NoError(t, err)
+
+	st.Count = 2
+	st.Items[0] = 99
+	st.Items = nil
+	st.hidden = 8
+	snap.Restore()
+
+	// The field values are restored, but the slice still shares its
+	// contents with the mutated one, and unexported fields are not touched.
+	require.Equal(t, &state{Count: 1, Items: []int{99, 2}, hidden: 8}, st)
+}
+
+func TestSnapshotFieldsDeepPointers(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+	type state struct {
+		Head  *node
+		Alias *node
+		Any   any
+	}
+	head := &node{Value: 1}
+	head.Next = head
+	st := &state{Head: head, Alias: head, Any: []string{"x"}}
+	snap, err := SnapshotFields(st, DeepCopy())
+	require.NoError(t, err)
+
+	head.Value = 2
+	st.Any.([]string)[0] = "y"
+	snap.Restore()
+
+	require.Equal(t, 1, st.Head.Value)
+	require.Same(t, st.Head, st.Head.Next)
+	require.Same(t, st.Head, st.Alias)
+	require.Equal(t, []string{"x"}, st.Any)
+
+	// Restoring twice works from the same pristine copy.
+	st.Head.Value = 3
+	snap.Restore()
+	require.Equal(t, 1, st.Head.Value)
+}
+
+func TestSnapshotFieldsDeepFirstFieldAlias(t *testing.T) {
+	type inner struct {
+		X, Y int
+	}
+	type state struct {
+		Whole *inner
+		First *int
+	}
+	whole := &inner{X: 1, Y: 2}
+	// First has the same address as Whole, but not its type.
+	st := &state{Whole: whole, First: &whole.X}
+	snap, err := SnapshotFields(st, DeepCopy())
+	require.NoError(t, err)
+
+	whole.X, whole.Y = 3, 4
+	snap.Restore()
+
+	require.Equal(t, &inner{X: 1, Y: 2}, st.Whole)
+	require.Equal(t, 1, *st.First)
+}
+
+func TestSnapshotFieldsErrors(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Target   any
+		Expected string
+	}
+	type withUnexported struct {
+		items []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			_, err := SnapshotFields(tc.Target, DeepCopy())
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	validate(t, testCase{Name: "Not a pointer",
+		Target:   restoringSuite{},
+		Expected: "testsuite: SnapshotFields needs a pointer to a struct, got testsuite.restoringSuite",
+	})
+	validate(t, testCase{Name: "Pointer to non-struct",
+		Target:   new(int),
+		Expected: "testsuite: SnapshotFields needs a pointer to a struct, got *int",
+	})
+	validate(t, testCase{Name: "Channel",
+		Target:   &struct{ Events chan int }{},
+		Expected: "testsuite: cannot snapshot field Events: values of type chan int cannot be copied",
+	})
+	validate(t, testCase{Name: "Function in a map",
+		Target:   &struct{ Hooks map[string]func() }{Hooks: map[string]func(){"a": nil}},
+		Expected: "testsuite: cannot snapshot field Hooks: values of type func() cannot be copied",
+	})
+	validate(t, testCase{Name: "Unexported reference field",
+		Target:   &struct{ State withUnexported }{},
+		Expected: "testsuite: cannot snapshot field State: unexported field testsuite.withUnexported.items of type []int cannot be copied",
+	})
+}
@@ -0,0 +1,51 @@
+package testdemo
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsSortedTimes reports whether ts is in non-decreasing order.
+func IsSortedTimes(ts []time.Time) bool {
+	for i := 1; i < len(ts); i++ {
+		if ts[i].Before(ts[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsMonotonicWithSkew reports whether ts never steps back in time by more
+// than maxSkew, the clock skew to tolerate between the sources of
+// neighboring timestamps. A maxSkew of zero makes this IsSortedTimes. It
+// panics if maxSkew is negative.
+//
+// Only single steps are limited, so many small regressions in a row may
+// add up to more than maxSkew: skew does not accumulate between
+// independent clocks, and telling drift from skew is beyond this check.
+func IsMonotonicWithSkew(ts []time.Time, maxSkew time.Duration) bool {
+	if maxSkew < 0 {
+		panic(fmt.Sprintf("testdemo: negative clock skew %v", maxSkew))
+	}
+	return CheckMonotonicWithSkew(ts, maxSkew) == nil
+}
+
+// CheckMonotonicWithSkew is IsMonotonicWithSkew returning an error, which
+// reports the largest regression seen, wrapping an *UnsortedError for the
+// pair of timestamps involved. A negative maxSkew is an error too.
+func CheckMonotonicWithSkew(ts []time.Time, maxSkew time.Duration) error {
+	if maxSkew < 0 {
+		return fmt.Errorf("negative clock skew %v", maxSkew)
+	}
+	worst, at := time.Duration(0), 0
+	for i := 1; i < len(ts); i++ {
+		if d := ts[i-1].Sub(ts[i]); d > worst {
+			worst, at = d, i
+		}
+	}
+	if worst <= maxSkew {
+		return nil
+	}
+	return fmt.Errorf("timestamps regress by up to %v, more than the allowed skew of %v: %w",
+		worst, maxSkew, &UnsortedError{Index: at, Prev: ts[at-1], Next: ts[at]})
+}
@@ -0,0 +1,79 @@
+package testdemo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsMonotonicWithSkew(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms ...int) []time.Time {
+		ts := make([]time.Time, len(ms))
+		for i, m := range ms {
+			ts[i] = base.Add(time.Duration(m) * time.Millisecond)
+		}
+		return ts
+	}
+	type testCase struct {
+		Name     string
+		Times    []time.Time
+		MaxSkew  time.Duration
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			err := CheckMonotonicWithSkew(tc.Times, tc.MaxSkew)
+			require.Equal(t, tc.Expected == "", IsMonotonicWithSkew(tc.Times, tc.MaxSkew))
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		MaxSkew: time.Millisecond,
+	})
+	validate(t, testCase{Name: "Sorted",
+		Times: at(0, 1, 1, 5),
+	})
+	validate(t, testCase{Name: "Within skew",
+		Times:   at(0, 10, 7, 12),
+		MaxSkew: 3 * time.Millisecond,
+	})
+	validate(t, testCase{Name: "Beyond skew",
+		Times:   at(0, 10, 7, 20, 15),
+		MaxSkew: 3 * time.Millisecond,
+		Expected: "timestamps regress by up to 5ms, more than the allowed skew of 3ms: " +
+			"index 4: 2024-05-01 12:00:00.02 +0000 UTC followed by 2024-05-01 12:00:00.015 +0000 UTC",
+	})
+	validate(t, testCase{Name: "Cumulative drift is allowed",
+		// Each step goes back 2ms, 8ms in all.
+		Times:   at(10, 8, 6, 4, 2),
+		MaxSkew: 3 * time.Millisecond,
+	})
+}
+
+func TestNegativeSkew(t *testing.T) {
+	require.EqualError(t, CheckMonotonicWithSkew(nil, -time.Millisecond), "negative clock skew -1ms")
+	require.PanicsWithValue(t, "testdemo: negative clock skew -1ns", func() {
+		IsMonotonicWithSkew(nil, -1)
+	})
+}
+
+func TestZeroSkewIsSortedTimes(t *testing.T) {
+	base := time.Unix(0, 0)
+	for _, ts := range [][]time.Time{
+		{},
+		{base},
+		{base, base, base.Add(1)},
+		{base.Add(1), base},
+		{base, base.Add(2), base.Add(1)},
+	} {
+		require.Equal(t, IsSortedTimes(ts), IsMonotonicWithSkew(ts, 0), "%v", ts)
+	}
+}
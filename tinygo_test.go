@@ -0,0 +1,42 @@
+package testdemo
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCoreImportsWithoutReflect requires that with the noreflect tag,
+// which leaves out the same files as building with TinyGo, the package
+// imports neither reflect nor encoding/json.
+func TestCoreImportsWithoutReflect(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	out, err := exec.Command(goTool, "list", "-tags", "noreflect", "-f", "{{join .Imports \" \"}}", ".").CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	imports := strings.Fields(string(out))
+	require.NotContains(t, imports, "reflect")
+	require.NotContains(t, imports, "encoding/json")
+
+	out, err = exec.Command(goTool, "vet", "-tags", "noreflect", ".").CombinedOutput()
+	require.NoError(t, err, "%s", out)
+}
+
+// TestTinyGoBuild builds and runs ./internal/tinygosmoke with TinyGo when
+// it is installed.
+func TestTinyGoBuild(t *testing.T) {
+	tinygo, err := exec.LookPath("tinygo")
+	if err != nil {
+		t.Skip("tinygo command not found")
+	}
+	bin := filepath.Join(t.TempDir(), "tinygosmoke")
+	out, err := exec.Command(tinygo, "build", "-o", bin, "./internal/tinygosmoke").CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	out, err = exec.Command(bin).CombinedOutput()
+	require.NoError(t, err, "%s", out)
+}
@@ -0,0 +1,59 @@
+package testdemo
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// VerifyTopK checks that topk holds the k largest elements of source in
+// non-increasing order, as a top-k query should return them. When k is
+// larger than source, all of source is expected. The true top k is found
+// with introselect, as SelectKth finds its element, so source is never
+// fully sorted and no input makes the check quadratic; it is not
+// modified.
+//
+// The error says which check failed: the wrong number of elements, an
+// order violation (wrapping an *UnsortedError), or the first index where
+// topk holds a different value than the true top k.
+func VerifyTopK(source, topk []int, k int) error {
+	if k < 0 {
+		k = 0
+	}
+	if k > len(source) {
+		k = len(source)
+	}
+	if len(topk) != k {
+		return fmt.Errorf("top-k has wrong length: got %d elements, want %d", len(topk), k)
+	}
+	for i := 1; i < len(topk); i++ {
+		if topk[i] > topk[i-1] {
+			return fmt.Errorf("top-k is not sorted in descending order: %w",
+				&UnsortedError{Index: i, Prev: topk[i-1], Next: topk[i]})
+		}
+	}
+
+	// Equal values are interchangeable, so the k largest are the same
+	// values however ties at the boundary were broken.
+	want, _ := largest(source, k)
+	for i := range want {
+		if topk[i] != want[i] {
+			return fmt.Errorf("top-k has wrong elements: index %d: got %d, want %d", i, topk[i], want[i])
+		}
+	}
+	return nil
+}
+
+// largest returns the k largest elements of source, 0 <= k <=
+// len(source), in non-increasing order, and the work introselect did to
+// find them. source is not modified.
+func largest(source []int, k int) (top []int, work int) {
+	top = slices.Clone(source)
+	if k > 0 && k < len(top) {
+		// introselect leaves no element less than top[len-k] after it.
+		work = introselect(top, len(top)-k)
+	}
+	top = top[len(top)-k:]
+	sort.Sort(sort.Reverse(sort.IntSlice(top)))
+	return top, work
+}
@@ -0,0 +1,137 @@
+package testdemo
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyTopK(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Source   []int
+		TopK     []int
+		K        int
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			err := VerifyTopK(tc.Source, tc.TopK, tc.K)
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Source: []int{},
+		TopK:   []int{},
+		K:      3,
+	})
+	validate(t, testCase{Name: "Zero k",
+		Source: []int{1, 2, 3},
+		TopK:   []int{},
+		K:      0,
+	})
+	validate(t, testCase{Name: "Correct",
+		Source: []int{5, 1, 9, 3, 7},
+		TopK:   []int{9, 7, 5},
+		K:      3,
+	})
+	validate(t, testCase{Name: "k beyond length",
+		Source: []int{2, 1, 3},
+		TopK:   []int{3, 2, 1},
+		K:      10,
+	})
+	validate(t, testCase{Name: "Duplicates at the boundary",
+		Source: []int{4, 9, 4, 1, 4},
+		TopK:   []int{9, 4},
+		K:      2,
+	})
+	validate(t, testCase{Name: "All equal",
+		Source: []int{4, 4, 4, 4},
+		TopK:   []int{4, 4, 4},
+		K:      3,
+	})
+	validate(t, testCase{Name: "Wrong length",
+		Source:   []int{5, 1, 9},
+		TopK:     []int{9},
+		K:        2,
+		Expected: "top-k has wrong length: got 1 elements, want 2",
+	})
+	validate(t, testCase{Name: "Not sorted",
+		Source:   []int{5, 1, 9},
+		TopK:     []int{5, 9},
+		K:        2,
+		Expected: "top-k is not sorted in descending order: index 1: 5 followed by 9",
+	})
+	validate(t, testCase{Name: "Wrong elements",
+		Source:   []int{5, 1, 9, 7},
+		TopK:     []int{9, 5},
+		K:        2,
+		Expected: "top-k has wrong elements: index 1: got 5, want 7",
+	})
+	validate(t, testCase{Name: "Too many duplicates",
+		Source:   []int{4, 9, 3},
+		TopK:     []int{9, 9},
+		K:        2,
+		Expected: "top-k has wrong elements: index 1: got 9, want 4",
+	})
+}
+
+func TestVerifyTopKAgainstSort(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		source := make([]int, rng.Intn(50))
+		for j := range source {
+			source[j] = rng.Intn(10)
+		}
+		k := rng.Intn(len(source) + 1)
+		sorted := make([]int, len(source))
+		copy(sorted, source)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+		require.NoError(t, VerifyTopK(source, sorted[:k], k), "%v k=%d", source, k)
+		if k > 0 && sorted[k-1] > 0 {
+			wrong := make([]int, k)
+			copy(wrong, sorted[:k])
+			wrong[k-1]--
+			require.Error(t, VerifyTopK(source, wrong, k), "%v k=%d", source, k)
+		}
+	}
+}
+
+// TestVerifyTopKLinearWork bounds the work of finding the true top k on
+// inputs that push quickselect towards its quadratic worst case, with
+// the same ceiling as TestIntroselectLinearWork.
+func TestVerifyTopKLinearWork(t *testing.T) {
+	const n = 100_000
+	inputs := map[string]func(i int) int{
+		"ascending":  func(i int) int { return i },
+		"descending": func(i int) int { return n - 1 - i },
+		"organ pipe": func(i int) int { return min(i, n-1-i) },
+		"sawtooth":   func(i int) int { return i % 64 },
+		"all equal":  func(i int) int { return 0 },
+	}
+	for name, value := range inputs {
+		t.Run(name, func(t *testing.T) {
+			source := make([]int, n)
+			for i := range source {
+				source[i] = value(i)
+			}
+			sorted := make([]int, n)
+			copy(sorted, source)
+			sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+			for _, k := range []int{1, n / 3, n / 2, n - 1} {
+				top, work := largest(source, k)
+				require.Equal(t, sorted[:k], top, "k=%d", k)
+				require.LessOrEqual(t, work, 32*n, "k=%d", k)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package testdemo
+
+import "slices"
+
+// The Uint64 functions are the int ones for uint64 data such as hashes
+// and keys. Converting those to int to reuse IsSorted, Search or
+// SortedInsert is a trap: every value at or above 1<<63 becomes negative,
+// so it orders before the small values rather than after them, and
+// sorted data checks as unsorted, or unsorted data as sorted.
+
+// IsSortedUint64 reports whether data is sorted in non-decreasing order.
+func IsSortedUint64(data []uint64) bool {
+	return slices.IsSorted(data)
+}
+
+// SearchUint64 is Search for uint64 data: it reports whether target is
+// in data, which must be sorted in non-decreasing order, along with the
+// index of its first occurrence when it is, and where it would be
+// inserted when it is not.
+func SearchUint64(data []uint64, target uint64) (int, bool) {
+	return slices.BinarySearch(data, target)
+}
+
+// SortedInsertUint64 is SortedInsert for uint64 data: it inserts v into
+// data, which must be sorted in non-decreasing order, and returns the
+// result, which is too.
+func SortedInsertUint64(data []uint64, v uint64) []uint64 {
+	i, _ := slices.BinarySearch(data, v)
+	return slices.Insert(data, i, v)
+}
@@ -0,0 +1,89 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedUint64(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []uint64
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, IsSortedUint64(tc.Array))
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []uint64{},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Above 2^63 after small values",
+		Array:    []uint64{0, 1, 1 << 63, math.MaxUint64},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Above 2^63 before small values",
+		Array: []uint64{1 << 63, 1},
+	})
+	validate(t, testCase{Name: "Equal large values",
+		Array:    []uint64{math.MaxUint64, math.MaxUint64},
+		Expected: true,
+	})
+}
+
+func TestIsSortedUint64IntCastTrap(t *testing.T) {
+	// Just past MaxInt, whatever the size of int.
+	data := []uint64{1, uint64(math.MaxInt) + 2}
+	asInts := make([]int, len(data))
+	for i, v := range data {
+		asInts[i] = int(v)
+	}
+	require.False(t, IsSorted(asInts), "the int cast reorders MaxInt+2 before 1")
+	require.True(t, IsSortedUint64(data))
+}
+
+func TestSearchUint64(t *testing.T) {
+	data := []uint64{3, 1 << 62, 1 << 63, 1 << 63, math.MaxUint64}
+	for _, tc := range []struct {
+		Target uint64
+		Index  int
+		Found  bool
+	}{
+		{Target: 0, Index: 0},
+		{Target: 3, Index: 0, Found: true},
+		{Target: 1 << 63, Index: 2, Found: true},
+		{Target: 1<<63 + 1, Index: 4},
+		{Target: math.MaxUint64, Index: 4, Found: true},
+		{Target: math.MaxUint64 - 1, Index: 4},
+	} {
+		i, found := SearchUint64(data, tc.Target)
+		require.Equal(t, tc.Index, i, "index of %d", tc.Target)
+		require.Equal(t, tc.Found, found, "found %d", tc.Target)
+	}
+}
+
+func TestSortedInsertUint64(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	var data []uint64
+	var want []uint64
+	for i := 0; i < 200; i++ {
+		v := rng.Uint64()
+		if i%4 == 0 {
+			v = uint64(rng.Intn(4))
+		}
+		data = SortedInsertUint64(data, v)
+		want = append(want, v)
+		require.True(t, IsSortedUint64(data))
+	}
+	slices.Sort(want)
+	require.Equal(t, want, data)
+	require.Greater(t, data[len(data)-1], uint64(math.MaxInt64), "the seed produced no values above 2^63")
+}
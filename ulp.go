@@ -0,0 +1,73 @@
+package testdemo
+
+import (
+	"math"
+)
+
+// NaNPolicy says where NaNs, which compare false with everything, may
+// appear in data checked for sortedness.
+type NaNPolicy int
+
+const (
+	// NaNInvalid rejects data containing any NaN.
+	NaNInvalid NaNPolicy = iota
+	// NaNFirst allows NaNs before every other value, the order of
+	// cmp.Compare and slices.Sort.
+	NaNFirst
+	// NaNLast allows NaNs after every other value.
+	NaNLast
+)
+
+// IsSortedFloat32ULP reports whether data is sorted in non-decreasing
+// order, allowing each element to fall below the largest before it by at
+// most maxULPs units in the last place: that many representable float32
+// values. Unlike an epsilon, the tolerance scales with the magnitude of
+// the values. -0 and +0 are one value, and the distance between values of
+// opposite signs counts the values on both sides of zero, denormals
+// included; infinity is one unit past math.MaxFloat32. With a maxULPs of
+// 0 only exact order is allowed. NaNs are allowed where nan says.
+func IsSortedFloat32ULP(data []float32, maxULPs uint32, nan NaNPolicy) bool {
+	i := 0
+	if nan == NaNFirst {
+		for i < len(data) && isNaN32(data[i]) {
+			i++
+		}
+	}
+	end := len(data)
+	if nan == NaNLast {
+		for end > i && isNaN32(data[end-1]) {
+			end--
+		}
+	}
+	var highest int64
+	for j, v := range data[i:end] {
+		if isNaN32(v) {
+			return false
+		}
+		k := ulpKey(v)
+		if j == 0 || k > highest {
+			highest = k
+			continue
+		}
+		if highest-k > int64(maxULPs) {
+			return false
+		}
+	}
+	return true
+}
+
+// ulpKey maps v to an integer that counts the float32 values between it
+// and zero, negated for negative v, so that subtracting keys gives the
+// distance between values in units in the last place.
+func ulpKey(v float32) int64 {
+	b := math.Float32bits(v)
+	k := int64(b &^ (1 << 31))
+	if b&(1<<31) != 0 {
+		return -k
+	}
+	return k
+}
+
+func isNaN32(v float32) bool {
+	return v != v
+}
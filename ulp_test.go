@@ -0,0 +1,139 @@
+package testdemo
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedFloat32ULP(t *testing.T) {
+	nan := float32(math.NaN())
+	inf := float32(math.Inf(1))
+	tiny := math.Float32frombits(1) // the smallest denormal
+	one := float32(1)
+	nextAfterOne := math.Nextafter32(one, 2)
+	type testCase struct {
+		Name     string
+		Array    []float32
+		MaxULPs  uint32
+		Policy   NaNPolicy
+		Expected bool
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, IsSortedFloat32ULP(tc.Array, tc.MaxULPs, tc.Policy))
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []float32{},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Exact sorted",
+		Array:    []float32{-1, 0, 1, 2},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Exact one ULP regression",
+		Array: []float32{nextAfterOne, one},
+	})
+	validate(t, testCase{Name: "One ULP regression allowed",
+		Array:    []float32{nextAfterOne, one},
+		MaxULPs:  1,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Regressions measured from the largest",
+		Array:   []float32{math.Nextafter32(nextAfterOne, 2), nextAfterOne, one},
+		MaxULPs: 1,
+	})
+	validate(t, testCase{Name: "Signed zeros are equal",
+		Array:    []float32{0, float32(math.Copysign(0, -1)), 0},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Straddling zero",
+		Array:    []float32{tiny, -tiny},
+		MaxULPs:  2,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Straddling zero too far",
+		Array:   []float32{tiny, -tiny},
+		MaxULPs: 1,
+	})
+	validate(t, testCase{Name: "Denormals",
+		Array:    []float32{tiny * 3, tiny, tiny * 4},
+		MaxULPs:  2,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Infinity one past MaxFloat32",
+		Array:    []float32{inf, math.MaxFloat32},
+		MaxULPs:  1,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Infinities",
+		Array:    []float32{-inf, -math.MaxFloat32, 0, math.MaxFloat32, inf},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Infinity to negative infinity",
+		Array:    []float32{inf, -inf},
+		MaxULPs:  2 * 0x7f800000,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Infinity to negative infinity too far",
+		Array:   []float32{inf, -inf},
+		MaxULPs: 2*0x7f800000 - 1,
+	})
+	validate(t, testCase{Name: "NaN invalid",
+		Array:   []float32{nan, 1},
+		MaxULPs: math.MaxUint32,
+	})
+	validate(t, testCase{Name: "NaN first",
+		Array:    []float32{nan, nan, 1, 2},
+		Policy:   NaNFirst,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "NaN first, found last",
+		Array:  []float32{1, 2, nan},
+		Policy: NaNFirst,
+	})
+	validate(t, testCase{Name: "NaN last",
+		Array:    []float32{1, 2, nan, nan},
+		Policy:   NaNLast,
+		Expected: true,
+	})
+	validate(t, testCase{Name: "NaN last, found in the middle",
+		Array:  []float32{1, nan, 2},
+		Policy: NaNLast,
+	})
+	validate(t, testCase{Name: "Only NaNs",
+		Array:    []float32{nan, nan},
+		Policy:   NaNLast,
+		Expected: true,
+	})
+}
+
+func TestIsSortedFloat32ULPMatchesStrict(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]float32, rng.Intn(10))
+		for j := range data {
+			switch rng.Intn(4) {
+			case 0:
+				data[j] = math.Float32frombits(rng.Uint32() % 4)
+			case 1:
+				data[j] = float32(rng.Intn(3))
+			default:
+				data[j] = float32(rng.NormFloat64())
+			}
+			if rng.Intn(2) == 0 {
+				data[j] = -data[j]
+			}
+		}
+		if rng.Intn(2) == 0 {
+			slices.Sort(data)
+		}
+		require.Equal(t, slices.IsSorted(data), IsSortedFloat32ULP(data, 0, NaNInvalid), "%v", data)
+	}
+}
@@ -0,0 +1,87 @@
+package testdemo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// UUIDOptions configures CheckUUIDs.
+type UUIDOptions struct {
+	Order
+	// RequireV7 rejects UUIDs whose version is not 7, the version whose
+	// byte order is creation-time order.
+	RequireV7 bool
+}
+
+// CheckUUIDs checks that data holds UUIDs in the canonical 36-character
+// form, such as "0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d", sorted by their
+// bytes. Hex digits may be in either case. For version 7 UUIDs byte order
+// is creation-time order, since they start with a big-endian millisecond
+// timestamp. It returns nil when they are sorted, an *UnsortedError for
+// the first pair out of order, or an error naming the index of the first
+// malformed UUID.
+func CheckUUIDs(data []string, opts UUIDOptions) error {
+	var prev [16]byte
+	for i, s := range data {
+		u, err := parseUUID(s)
+		if err != nil {
+			return fmt.Errorf("index %d: invalid UUID %q: %w", i, s, err)
+		}
+		if version := u[6] >> 4; opts.RequireV7 && version != 7 {
+			return fmt.Errorf("index %d: UUID %q is version %d, not 7", i, s, version)
+		}
+		// Comparing prev and u has the same outcome as comparing their
+		// bytes.Compare result with zero.
+		if i > 0 && !inOrder(int64(bytes.Compare(prev[:], u[:])), 0, opts.Descending, opts.Strict) {
+			return &UnsortedError{Index: i, Prev: data[i-1], Next: s}
+		}
+		prev = u
+	}
+	return nil
+}
+
+// IsSortedUUIDs reports whether data holds canonical UUIDs in byte order,
+// which is creation-time order for version 7 UUIDs. The error is only
+// non-nil when one of them is malformed.
+func IsSortedUUIDs(data []string) (bool, error) {
+	return sortedResult(CheckUUIDs(data, UUIDOptions{}))
+}
+
+// parseUUID parses the canonical 8-4-4-4-12 hex form of a UUID.
+func parseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+	if len(s) != 36 {
+		return u, fmt.Errorf("length %d, want 36", len(s))
+	}
+	for i, j := 0, 0; i < len(s); {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if s[i] != '-' {
+				return u, fmt.Errorf("want '-' at offset %d", i)
+			}
+			i++
+			continue
+		}
+		hi, ok1 := fromHex(s[i])
+		lo, ok2 := fromHex(s[i+1])
+		if !ok1 || !ok2 {
+			return u, errors.New("invalid hex digit")
+		}
+		u[j] = hi<<4 | lo
+		i += 2
+		j++
+	}
+	return u, nil
+}
+
+func fromHex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUUIDs(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Data     []string
+		Opts     UUIDOptions
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			err := CheckUUIDs(tc.Data, tc.Opts)
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	validate(t, testCase{Name: "Empty"})
+	validate(t, testCase{Name: "Sorted v7",
+		Data: []string{
+			"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+			"0190f0c4-7d3f-7000-8000-000000000000",
+			"0191a000-0000-7fff-bfff-ffffffffffff",
+		},
+		Opts: UUIDOptions{RequireV7: true},
+	})
+	validate(t, testCase{Name: "Mixed case",
+		Data: []string{
+			"0190F0C4-7D3E-7A2B-9C41-5E6F7A8B9C0D",
+			"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0e",
+			"0190F0C4-7D3E-7A2B-9C41-5E6F7A8B9C0F",
+		},
+	})
+	validate(t, testCase{Name: "Case does not change order",
+		// "A" sorts before "a" as text, but they are the same byte.
+		Data: []string{
+			"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0a",
+			"0190F0C4-7D3E-7A2B-9C41-5E6F7A8B9C0A",
+		},
+		Opts: UUIDOptions{Order: Order{Strict: true}},
+		Expected: "index 1: 0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0a followed by " +
+			"0190F0C4-7D3E-7A2B-9C41-5E6F7A8B9C0A",
+	})
+	validate(t, testCase{Name: "Unsorted",
+		Data: []string{
+			"0191a000-0000-7fff-bfff-ffffffffffff",
+			"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+		},
+		Expected: "index 1: 0191a000-0000-7fff-bfff-ffffffffffff followed by 0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+	})
+	validate(t, testCase{Name: "Non-v7 in byte order",
+		Data: []string{
+			"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+			"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		},
+	})
+	validate(t, testCase{Name: "Non-v7 rejected",
+		Data: []string{
+			"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+			"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		},
+		Opts:     UUIDOptions{RequireV7: true},
+		Expected: `index 1: UUID "6ba7b810-9dad-11d1-80b4-00c04fd430c8" is version 1, not 7`,
+	})
+	validate(t, testCase{Name: "Too short",
+		Data:     []string{"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0"},
+		Expected: `index 0: invalid UUID "0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0": length 35, want 36`,
+	})
+	validate(t, testCase{Name: "Braces",
+		Data: []string{
+			"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+			"{0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d}",
+		},
+		Expected: `index 1: invalid UUID "{0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d}": length 38, want 36`,
+	})
+	validate(t, testCase{Name: "Misplaced dash",
+		Data:     []string{"0190f0c47-d3e-7a2b-9c41-5e6f7a8b9c0d"},
+		Expected: `index 0: invalid UUID "0190f0c47-d3e-7a2b-9c41-5e6f7a8b9c0d": want '-' at offset 8`,
+	})
+	validate(t, testCase{Name: "Bad hex",
+		Data:     []string{"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0g"},
+		Expected: `index 0: invalid UUID "0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0g": invalid hex digit`,
+	})
+}
+
+func TestIsSortedUUIDs(t *testing.T) {
+	sorted, err := IsSortedUUIDs([]string{
+		"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+		"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+	})
+	require.NoError(t, err)
+	require.True(t, sorted)
+
+	sorted, err = IsSortedUUIDs([]string{
+		"0191a000-0000-7fff-bfff-ffffffffffff",
+		"0190f0c4-7d3e-7a2b-9c41-5e6f7a8b9c0d",
+	})
+	require.NoError(t, err)
+	require.False(t, sorted)
+
+	_, err = IsSortedUUIDs([]string{"not a uuid"})
+	require.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package testdemo
+
+import "fmt"
+
+// maxValidateLessValues caps how many values ValidateLess checks, since
+// it tries every triple of them: 64 values make about 260,000.
+const maxValidateLessValues = 64
+
+// The laws a LessError can report as broken.
+const (
+	LawIrreflexive            = "irreflexivity"
+	LawAsymmetric             = "asymmetry"
+	LawTransitive             = "transitivity"
+	LawTransitiveIncomparable = "transitivity of incomparability"
+)
+
+// LessError describes a law of strict weak ordering that a less function
+// breaks, with the values that show it.
+type LessError struct {
+	// Law is the law broken: LawIrreflexive, LawAsymmetric,
+	// LawTransitive or LawTransitiveIncomparable.
+	Law string
+	// A, B and C are the witnesses. Irreflexivity takes only A, and
+	// asymmetry A and B.
+	A, B, C any
+}
+
+func (e *LessError) Error() string {
+	switch e.Law {
+	case LawIrreflexive:
+		return fmt.Sprintf("less breaks %s: less(%v, %v) is true", e.Law, e.A, e.A)
+	case LawAsymmetric:
+		return fmt.Sprintf("less breaks %s: less(%v, %v) and less(%v, %v) are both true", e.Law, e.A, e.B, e.B, e.A)
+	case LawTransitive:
+		return fmt.Sprintf("less breaks %s: less(%v, %v) and less(%v, %v) but not less(%v, %v)", e.Law, e.A, e.B, e.B, e.C, e.A, e.C)
+	default:
+		return fmt.Sprintf("less breaks %s: %v is incomparable with %v, and %v with %v, but %v and %v are ordered", e.Law, e.A, e.B, e.B, e.C, e.A, e.C)
+	}
+}
+
+// ValidateLess checks that less is a strict weak ordering over values,
+// as sorting and the checks in this package assume, and returns a
+// *LessError naming the first law it finds broken. Besides
+// irreflexivity, asymmetry and transitivity, it checks that
+// incomparability is transitive, which a less that treats some value
+// like NaN as incomparable with everything breaks.
+//
+// It tries every pair and triple, so only the first 64 values are used.
+func ValidateLess[T any](values []T, less func(a, b T) bool) error {
+	values = values[:min(len(values), maxValidateLessValues)]
+	n := len(values)
+	// lt[i*n+j] caches less(values[i], values[j]) for the triple loop.
+	lt := make([]bool, n*n)
+	for i, a := range values {
+		for j, b := range values {
+			lt[i*n+j] = less(a, b)
+		}
+	}
+	for i, a := range values {
+		if lt[i*n+i] {
+			return &LessError{Law: LawIrreflexive, A: a}
+		}
+	}
+	for i, a := range values {
+		for j := i + 1; j < n; j++ {
+			if lt[i*n+j] && lt[j*n+i] {
+				return &LessError{Law: LawAsymmetric, A: a, B: values[j]}
+			}
+		}
+	}
+	incomparable := func(i, j int) bool { return !lt[i*n+j] && !lt[j*n+i] }
+	for i := range n {
+		for j := range n {
+			for k := range n {
+				if lt[i*n+j] && lt[j*n+k] && !lt[i*n+k] {
+					return &LessError{Law: LawTransitive, A: values[i], B: values[j], C: values[k]}
+				}
+				if incomparable(i, j) && incomparable(j, k) && !incomparable(i, k) {
+					return &LessError{Law: LawTransitiveIncomparable, A: values[i], B: values[j], C: values[k]}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package testdemo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// overflowLess is the classic broken comparator: a-b wraps around for
+// operands far apart, so it orders the int32 extremes inconsistently.
+func overflowLess(a, b int32) bool { return a-b < 0 }
+
+func TestValidateLess(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Check    func() error
+		Expected string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			err := tc.Check()
+			if tc.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			var lessErr *LessError
+			require.ErrorAs(t, err, &lessErr)
+			require.EqualError(t, err, tc.Expected)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Check: func() error { return ValidateLess(nil, func(a, b int) bool { return a < b }) },
+	})
+	validate(t, testCase{Name: "Correct comparator",
+		Check: func() error {
+			return ValidateLess([]int32{math.MinInt32, -1, 0, 0, 1, math.MaxInt32},
+				func(a, b int32) bool { return a < b })
+		},
+	})
+	validate(t, testCase{Name: "Overflowing subtraction",
+		Check: func() error {
+			return ValidateLess([]int32{math.MinInt32, 0, math.MaxInt32}, overflowLess)
+		},
+		Expected: "less breaks asymmetry: less(-2147483648, 0) and less(0, -2147483648) are both true",
+	})
+	validate(t, testCase{Name: "Overflowing subtraction past MinInt32",
+		Check: func() error {
+			return ValidateLess([]int32{math.MinInt32 + 1, 0, math.MaxInt32}, overflowLess)
+		},
+		Expected: "less breaks transitivity: less(-2147483647, 0) and less(0, 2147483647) but not less(-2147483647, 2147483647)",
+	})
+	validate(t, testCase{Name: "Not irreflexive",
+		Check: func() error {
+			return ValidateLess([]int{1, 2}, func(a, b int) bool { return a <= b })
+		},
+		Expected: "less breaks irreflexivity: less(1, 1) is true",
+	})
+	validate(t, testCase{Name: "Not asymmetric",
+		Check: func() error {
+			return ValidateLess([]int{1, 2}, func(a, b int) bool { return a != b })
+		},
+		Expected: "less breaks asymmetry: less(1, 2) and less(2, 1) are both true",
+	})
+	validate(t, testCase{Name: "NaN is incomparable",
+		Check: func() error {
+			return ValidateLess([]float64{1, math.NaN(), 2}, func(a, b float64) bool { return a < b })
+		},
+		Expected: "less breaks transitivity of incomparability: 1 is incomparable with NaN, and NaN with 2, but 1 and 2 are ordered",
+	})
+	validate(t, testCase{Name: "NaN ordered first",
+		Check: func() error {
+			return ValidateLess([]float64{1, math.NaN(), 2, math.NaN()}, func(a, b float64) bool {
+				return a < b || (math.IsNaN(a) && !math.IsNaN(b))
+			})
+		},
+	})
+}
+
+func TestValidateLessCapsValues(t *testing.T) {
+	values := make([]int, 2*maxValidateLessValues)
+	for i := range values {
+		values[i] = i
+	}
+	var calls int
+	err := ValidateLess(values, func(a, b int) bool {
+		calls++
+		// Wrong only past the cap, where it is not checked.
+		if a >= maxValidateLessValues || b >= maxValidateLessValues {
+			return true
+		}
+		return a < b
+	})
+	require.NoError(t, err)
+	require.Equal(t, maxValidateLessValues*maxValidateLessValues, calls)
+}
@@ -0,0 +1,34 @@
+package testdemo
+
+import "sync/atomic"
+
+// vectorMinLen is the shortest slice FirstUnsortedIndex checks with the
+// vectorized loop; for shorter ones calling it costs more than it saves.
+const vectorMinLen = 32
+
+// vectorized is whether FirstUnsortedIndex, and so IsSorted, use the
+// vectorized loop for long slices.
+var vectorized atomic.Bool
+
+func init() {
+	vectorized.Store(haveVector)
+}
+
+// SetVectorized turns the vectorized sortedness check on or off, and
+// returns whether it was on. It is on by default where it is available,
+// which is amd64 CPUs with AVX2 in builds without the purego tag;
+// elsewhere it cannot be turned on. Its results never differ from the
+// scalar loop's, so this is for tests and benchmarks comparing the two.
+func SetVectorized(enabled bool) (previous bool) {
+	return vectorized.Swap(enabled && haveVector)
+}
+
+// firstUnsortedVector is FirstUnsortedIndex using the vectorized loop
+// for as much of data as it can check.
+func firstUnsortedVector(data []int) int {
+	start := vectorSortedPrefix(data)
+	if i := firstUnsortedScalar(data[start:]); i >= 0 {
+		return start + i
+	}
+	return -1
+}
@@ -0,0 +1,17 @@
+//go:build amd64 && !purego && !tinygo
+
+package testdemo
+
+import "golang.org/x/sys/cpu"
+
+// haveVector reports whether vectorSortedPrefix can run on this CPU.
+var haveVector = cpu.X86.HasAVX2
+
+// vectorSortedPrefix returns an index i such that data[:i+1] is sorted,
+// checked 8 pairs at a time with AVX2 compares of data against itself
+// shifted by one. It stops at the first block of 8 holding a pair out of
+// order, or with fewer than 9 elements left, leaving the rest to the
+// scalar loop. It requires haveVector.
+//
+//go:noescape
+func vectorSortedPrefix(data []int) int
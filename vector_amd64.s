@@ -0,0 +1,34 @@
+//go:build amd64 && !purego && !tinygo
+
+#include "textflag.h"
+
+// func vectorSortedPrefix(data []int) int
+TEXT ·vectorSortedPrefix(SB), NOSPLIT, $0-32
+	MOVQ data_base+0(FP), SI
+	MOVQ data_len+8(FP), CX
+	XORQ AX, AX
+
+	// Each block reads data[i:i+9], so blocks start below len-8.
+	SUBQ $8, CX
+
+loop:
+	CMPQ AX, CX
+	JGE  done
+	VMOVDQU  (SI)(AX*8), Y0
+	VMOVDQU  8(SI)(AX*8), Y1
+	VMOVDQU  32(SI)(AX*8), Y2
+	VMOVDQU  40(SI)(AX*8), Y3
+
+	// Y4 and Y5 have all bits set in each lane where data[j] > data[j+1].
+	VPCMPGTQ Y1, Y0, Y4
+	VPCMPGTQ Y3, Y2, Y5
+	VPOR     Y4, Y5, Y4
+	VPTEST   Y4, Y4
+	JNZ      done
+	ADDQ     $8, AX
+	JMP      loop
+
+done:
+	VZEROUPPER
+	MOVQ AX, ret+24(FP)
+	RET
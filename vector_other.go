@@ -0,0 +1,11 @@
+//go:build !amd64 || purego || tinygo
+
+package testdemo
+
+// haveVector is false where there is no vectorized check, so IsSorted
+// always runs the scalar loop.
+const haveVector = false
+
+func vectorSortedPrefix(data []int) int {
+	return 0
+}
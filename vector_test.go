@@ -0,0 +1,58 @@
+package testdemo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorSortedPrefix(t *testing.T) {
+	if !haveVector {
+		t.Skip("no vectorized check on this platform")
+	}
+	for n := 0; n <= 40; n++ {
+		sorted := make([]int, n)
+		for i := range sorted {
+			sorted[i] = i
+		}
+		// With no pair out of order, the prefix stops within 8 of the end.
+		p := vectorSortedPrefix(sorted)
+		require.True(t, p >= n-9 && p <= max(n-1, 0), "n=%d prefix=%d", n, p)
+
+		// Out of order at each position in turn, the prefix must stop at
+		// or before it.
+		for bad := 1; bad < n; bad++ {
+			data := append([]int(nil), sorted...)
+			data[bad] = math.MinInt
+			p := vectorSortedPrefix(data)
+			require.Less(t, p, bad, "n=%d bad=%d", n, bad)
+			require.Equal(t, bad, firstUnsortedVector(data), "n=%d bad=%d", n, bad)
+		}
+	}
+}
+
+func TestSetVectorized(t *testing.T) {
+	previous := SetVectorized(false)
+	defer SetVectorized(previous)
+	require.Equal(t, haveVector, previous)
+	require.False(t, SetVectorized(true))
+	require.Equal(t, haveVector, SetVectorized(true))
+
+	rng := testhelper.SeededRand(t)
+	for range 2000 {
+		data := make([]int, rng.Intn(300))
+		for i := range data {
+			data[i] = i
+		}
+		if len(data) > 0 && rng.Intn(2) == 0 {
+			data[rng.Intn(len(data))] = rng.Intn(len(data)) - 1
+		}
+		SetVectorized(true)
+		vector := FirstUnsortedIndex(data)
+		SetVectorized(false)
+		scalar := FirstUnsortedIndex(data)
+		require.Equal(t, scalar, vector, "%v", data)
+	}
+}
@@ -0,0 +1,42 @@
+package testdemo
+
+import "fmt"
+
+// WindowMin returns the minimum of each window of w consecutive elements
+// of data, len(data)-w+1 of them, in O(n) overall. It returns an error if
+// w is not within [1, len(data)].
+func WindowMin(data []int, w int) ([]int, error) {
+	return windowExtremes(data, w, func(a, b int) bool { return a <= b })
+}
+
+// WindowMax is WindowMin for the maximum of each window.
+func WindowMax(data []int, w int) ([]int, error) {
+	return windowExtremes(data, w, func(a, b int) bool { return a >= b })
+}
+
+// windowExtremes keeps a monotonic deque of indices into data, oldest
+// first, whose elements get steadily worse by keep: an element that a
+// newer one is at least as good as can never be an extreme again, so it
+// is dropped. The front of the deque is then the extreme of the window.
+func windowExtremes(data []int, w int, keep func(a, b int) bool) ([]int, error) {
+	if w <= 0 || w > len(data) {
+		return nil, fmt.Errorf("window size %d is outside [1, %d]", w, len(data))
+	}
+	out := make([]int, 0, len(data)-w+1)
+	deque := make([]int, 0, w)
+	for i, v := range data {
+		for len(deque) > 0 && keep(v, data[deque[len(deque)-1]]) {
+			deque = deque[:len(deque)-1]
+		}
+		// Popping the front shrinks the capacity, but append reallocates
+		// with room for at most w live indices, so this stays O(n).
+		deque = append(deque, i)
+		if deque[0] <= i-w {
+			deque = deque[1:]
+		}
+		if i >= w-1 {
+			out = append(out, data[deque[0]])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,92 @@
+package testdemo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowMinMax(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		W        int
+		Min, Max []int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			lo, err := WindowMin(tc.Array, tc.W)
+			require.NoError(t, err)
+			require.Equal(t, tc.Min, lo, "WindowMin")
+			hi, err := WindowMax(tc.Array, tc.W)
+			require.NoError(t, err)
+			require.Equal(t, tc.Max, hi, "WindowMax")
+		})
+	}
+	validate(t, testCase{Name: "Window of one",
+		Array: []int{3, 1, 2},
+		W:     1,
+		Min:   []int{3, 1, 2},
+		Max:   []int{3, 1, 2},
+	})
+	validate(t, testCase{Name: "Whole slice",
+		Array: []int{3, 1, 2},
+		W:     3,
+		Min:   []int{1},
+		Max:   []int{3},
+	})
+	validate(t, testCase{Name: "All equal",
+		Array: []int{4, 4, 4, 4},
+		W:     2,
+		Min:   []int{4, 4, 4},
+		Max:   []int{4, 4, 4},
+	})
+	validate(t, testCase{Name: "Strictly decreasing",
+		Array: []int{5, 4, 3, 2, 1},
+		W:     3,
+		Min:   []int{3, 2, 1},
+		Max:   []int{5, 4, 3},
+	})
+	validate(t, testCase{Name: "Mixed",
+		Array: []int{1, 3, -1, -3, 5, 3, 6, 7},
+		W:     3,
+		Min:   []int{-1, -3, -3, -3, 3, 3},
+		Max:   []int{3, 3, 5, 5, 6, 7},
+	})
+}
+
+func TestWindowMinMaxBadSize(t *testing.T) {
+	_, err := WindowMin([]int{1, 2}, 0)
+	require.EqualError(t, err, "window size 0 is outside [1, 2]")
+	_, err = WindowMax([]int{1, 2}, 3)
+	require.EqualError(t, err, "window size 3 is outside [1, 2]")
+	_, err = WindowMin(nil, 1)
+	require.EqualError(t, err, "window size 1 is outside [1, 0]")
+}
+
+func TestWindowMinMaxAgainstBruteForce(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]int, rng.Intn(40)+1)
+		for j := range data {
+			data[j] = rng.Intn(10)
+		}
+		w := rng.Intn(len(data)) + 1
+		var wantMin, wantMax []int
+		for lo := 0; lo+w <= len(data); lo++ {
+			wantMin = append(wantMin, slices.Min(data[lo:lo+w]))
+			wantMax = append(wantMax, slices.Max(data[lo:lo+w]))
+		}
+		lo, err := WindowMin(data, w)
+		require.NoError(t, err)
+		hi, err := WindowMax(data, w)
+		require.NoError(t, err)
+		require.Len(t, lo, len(data)-w+1)
+		require.Equal(t, wantMin, lo, "%v w=%d", data, w)
+		require.Equal(t, wantMax, hi, "%v w=%d", data, w)
+	}
+}
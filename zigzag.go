@@ -0,0 +1,44 @@
+package testdemo
+
+// IsZigZag reports whether the steps between neighbors of data strictly
+// alternate between up and down, such as 1, 3, 2, 5, 4. Equal neighbors
+// are a step in neither direction and break the zigzag. Slices of fewer
+// than three elements have no direction to alternate, so they are
+// zigzags unless they are two equal elements.
+func IsZigZag(data []int) bool {
+	_, length := LongestZigZagRun(data)
+	return length == len(data)
+}
+
+// LongestZigZagRun returns the longest stretch data[start:start+length]
+// that IsZigZag, the first one if several are longest. It is (0, 0) for
+// an empty slice, and otherwise length is at least one.
+func LongestZigZagRun(data []int) (start, length int) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+	length = 1
+	runStart, lastStep := 0, 0
+	for i := 1; i < len(data); i++ {
+		step := 0
+		switch {
+		case data[i] > data[i-1]:
+			step = 1
+		case data[i] < data[i-1]:
+			step = -1
+		}
+		switch {
+		case step == 0:
+			runStart, lastStep = i, 0
+		case step == lastStep:
+			// Two steps the same way: the run starts over with this one.
+			runStart, lastStep = i-1, step
+		default:
+			lastStep = step
+		}
+		if n := i - runStart + 1; n > length {
+			start, length = runStart, n
+		}
+	}
+	return start, length
+}
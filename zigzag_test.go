@@ -0,0 +1,92 @@
+package testdemo
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/StevenACoffman/testdemo/internal/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZigZag(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Array    []int
+		Expected bool
+		Start    int
+		Length   int
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			require.Equal(t, tc.Expected, IsZigZag(tc.Array), "IsZigZag")
+			start, length := LongestZigZagRun(tc.Array)
+			require.Equal(t, tc.Start, start, "start")
+			require.Equal(t, tc.Length, length, "length")
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Array:    []int{},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Single element",
+		Array:    []int{4},
+		Expected: true,
+		Length:   1,
+	})
+	validate(t, testCase{Name: "Two different",
+		Array:    []int{4, 2},
+		Expected: true,
+		Length:   2,
+	})
+	validate(t, testCase{Name: "Two equal",
+		Array:  []int{4, 4},
+		Length: 1,
+	})
+	validate(t, testCase{Name: "Zigzag",
+		Array:    []int{1, 3, 2, 5, 4},
+		Expected: true,
+		Length:   5,
+	})
+	validate(t, testCase{Name: "Zigzag starting down",
+		Array:    []int{3, 1, 2, 0},
+		Expected: true,
+		Length:   4,
+	})
+	validate(t, testCase{Name: "Plateau",
+		Array:  []int{1, 3, 3, 2, 5, 4},
+		Start:  2,
+		Length: 4,
+	})
+	validate(t, testCase{Name: "Two steps up",
+		Array:  []int{1, 3, 2, 4, 6, 5},
+		Start:  0,
+		Length: 4,
+	})
+	validate(t, testCase{Name: "Longest run last",
+		Array:  []int{1, 2, 3, 1, 4, 2, 5},
+		Start:  1,
+		Length: 6,
+	})
+	validate(t, testCase{Name: "Extremes",
+		Array:    []int{math.MinInt, math.MaxInt, math.MinInt},
+		Expected: true,
+		Length:   3,
+	})
+}
+
+func TestSortedIsNeverZigZag(t *testing.T) {
+	rng := testhelper.SeededRand(t)
+	for i := 0; i < 500; i++ {
+		data := make([]int, rng.Intn(20)+3)
+		for j := range data {
+			data[j] = rng.Int()
+		}
+		sort.Ints(data)
+		require.False(t, IsZigZag(data), "%v", data)
+		_, length := LongestZigZagRun(data)
+		require.LessOrEqual(t, length, 2, "%v", data)
+	}
+}
@@ -0,0 +1,36 @@
+package testdemo
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// IsSortedZip2 reports whether the rows of two parallel columns, a and
+// b, are in non-decreasing lexicographic order: sorted by a, with ties
+// broken by b. The error is only non-nil when the columns differ in
+// length.
+func IsSortedZip2[A, B cmp.Ordered](a []A, b []B) (bool, error) {
+	if len(a) != len(b) {
+		return false, fmt.Errorf("column lengths differ: %d and %d", len(a), len(b))
+	}
+	for i := 1; i < len(a); i++ {
+		if c := cmp.Or(cmp.Compare(a[i-1], a[i]), cmp.Compare(b[i-1], b[i])); c > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// IsSortedZip3 is IsSortedZip2 for three columns: rows are sorted by a,
+// then b, then c.
+func IsSortedZip3[A, B, C cmp.Ordered](a []A, b []B, c []C) (bool, error) {
+	if len(a) != len(b) || len(a) != len(c) {
+		return false, fmt.Errorf("column lengths differ: %d, %d and %d", len(a), len(b), len(c))
+	}
+	for i := 1; i < len(a); i++ {
+		if r := cmp.Or(cmp.Compare(a[i-1], a[i]), cmp.Compare(b[i-1], b[i]), cmp.Compare(c[i-1], c[i])); r > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
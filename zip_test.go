@@ -0,0 +1,71 @@
+package testdemo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSortedZip2(t *testing.T) {
+	type testCase struct {
+		Name     string
+		IDs      []int64
+		Names    []string
+		Expected bool
+		Err      string
+	}
+	validate := func(t *testing.T, tc testCase) {
+		t.Helper()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			actual, err := IsSortedZip2(tc.IDs, tc.Names)
+			if tc.Err != "" {
+				require.EqualError(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+	validate(t, testCase{Name: "Empty",
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Sorted by first column",
+		IDs:      []int64{1, 2, 3},
+		Names:    []string{"c", "b", "a"},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Ties broken correctly",
+		IDs:      []int64{1, 2, 2, 3},
+		Names:    []string{"z", "a", "b", "a"},
+		Expected: true,
+	})
+	validate(t, testCase{Name: "Ties broken incorrectly",
+		IDs:      []int64{1, 2, 2, 3},
+		Names:    []string{"z", "b", "a", "a"},
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Unsorted first column",
+		IDs:      []int64{2, 1},
+		Names:    []string{"a", "b"},
+		Expected: false,
+	})
+	validate(t, testCase{Name: "Lengths differ by one",
+		IDs:   []int64{1, 2, 3},
+		Names: []string{"a", "b"},
+		Err:   "column lengths differ: 3 and 2",
+	})
+}
+
+func TestIsSortedZip3(t *testing.T) {
+	sorted, err := IsSortedZip3([]int{1, 1, 1, 2}, []string{"a", "b", "b", "a"}, []float64{9, 1, 2, 0})
+	require.NoError(t, err)
+	require.True(t, sorted)
+
+	sorted, err = IsSortedZip3([]int{1, 1, 1}, []string{"a", "b", "b"}, []float64{9, 2, 1})
+	require.NoError(t, err)
+	require.False(t, sorted)
+
+	_, err = IsSortedZip3([]int{1, 2}, []string{"a", "b"}, []float64{1})
+	require.EqualError(t, err, "column lengths differ: 2, 2 and 1")
+}